@@ -103,6 +103,7 @@ import (
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
@@ -145,6 +146,20 @@ const appName = "agoric"
 // TODO: document this flag in config, likely alongside the genesis path
 const FlagSwingStoreExportDir = "swing-store-export-dir"
 
+// FlagVstorageMaxPatternMatches defines the config flag used to limit how many
+// matching paths a single vstorage path pattern query can return.
+const FlagVstorageMaxPatternMatches = "vstorage-max-pattern-matches"
+
+// FlagVstorageMaxPatternVisits defines the config flag used to limit how many
+// entries a single vstorage path pattern query can read while looking for
+// matches.
+const FlagVstorageMaxPatternVisits = "vstorage-max-pattern-visits"
+
+// FlagVstorageChangeLog defines the config flag used to specify a file (relative
+// to the home directory unless absolute) to which every vstorage change is
+// appended as JSON Lines at the end of each block, for indexers.
+const FlagVstorageChangeLog = "vstorage-change-log"
+
 var (
 	// DefaultNodeHome default home directories for the application daemon
 	DefaultNodeHome string
@@ -201,6 +216,7 @@ var (
 		vbanktypes.ReservePoolName:     nil,
 		vbanktypes.ProvisionPoolName:   nil,
 		vbanktypes.GiveawayPoolName:    nil,
+		swingsettypes.RefundPoolName:   nil,
 	}
 )
 
@@ -333,7 +349,7 @@ func NewAgoricApp(
 		govtypes.StoreKey, paramstypes.StoreKey, ibchost.StoreKey, upgradetypes.StoreKey,
 		evidencetypes.StoreKey, ibctransfertypes.StoreKey, packetforwardtypes.StoreKey,
 		capabilitytypes.StoreKey, feegrant.StoreKey, authzkeeper.StoreKey, icahosttypes.StoreKey,
-		swingset.StoreKey, vstorage.StoreKey, vibc.StoreKey,
+		swingset.StoreKey, vstorage.StoreKey, vstorage.UsageStoreKey, vibc.StoreKey,
 		vlocalchain.StoreKey, vtransfer.StoreKey, vbank.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
@@ -468,8 +484,46 @@ func NewAgoricApp(
 		app.bootstrapNeeded = true
 	}
 
+	var vstorageOpts []vstorage.KeeperOption
+	if changeLog := cast.ToString(appOpts.Get(FlagVstorageChangeLog)); changeLog != "" {
+		if !filepath.IsAbs(changeLog) {
+			changeLog = filepath.Join(homePath, changeLog)
+		}
+		sink, err := vstorage.NewFileChangeSink(changeLog)
+		if err != nil {
+			panic(fmt.Errorf("cannot open vstorage change log: %w", err))
+		}
+		vstorageOpts = append(vstorageOpts, vstorage.WithChangeManager(vstorage.NewSinkChangeManager(sink)))
+	}
 	app.VstorageKeeper = vstorage.NewKeeper(
-		keys[vstorage.StoreKey],
+		keys[vstorage.StoreKey], vstorageOpts...,
+	).WithVersionedStoreGetter(func(height int64) (sdk.KVStore, error) {
+		ms, err := app.CommitMultiStore().CacheMultiStoreWithVersion(height)
+		if err != nil {
+			return nil, err
+		}
+		return ms.GetKVStore(keys[vstorage.StoreKey]), nil
+	}).WithStoreProofGetter(func(key []byte, height int64) ([]byte, *tmcrypto.ProofOps, int64, error) {
+		queryable, ok := app.CommitMultiStore().(storetypes.Queryable)
+		if !ok {
+			return nil, nil, 0, fmt.Errorf("multistore %T does not support queries", app.CommitMultiStore())
+		}
+		res := queryable.Query(abci.RequestQuery{
+			Path:   "/" + vstorage.StoreKey + "/key",
+			Data:   key,
+			Height: height,
+			Prove:  true,
+		})
+		if !res.IsOK() {
+			return nil, nil, 0, fmt.Errorf("vstorage proof query failed: %s", res.Log)
+		}
+		return res.Value, res.ProofOps, res.Height, nil
+	}).WithStorageQuotas(
+		app.GetSubspace(vstorage.ModuleName), keys[vstorage.UsageStoreKey],
+	).WithMaxPatternMatches(
+		cast.ToInt(appOpts.Get(FlagVstorageMaxPatternMatches)),
+	).WithMaxPatternVisits(
+		cast.ToInt(appOpts.Get(FlagVstorageMaxPatternVisits)),
 	)
 	app.vstoragePort = app.AgdServer.MustRegisterPortHandler("vstorage", vstorage.NewStorageHandler(app.VstorageKeeper))
 
@@ -879,6 +933,7 @@ func NewAgoricApp(
 				packetforwardtypes.ModuleName, // Added PFM
 				vlocalchain.ModuleName,        // Agoric added vlocalchain
 				vtransfer.ModuleName,          // Agoric added vtransfer
+				vstorage.UsageStoreKey,        // Agoric added vstorage byte accounting
 			},
 			Deleted: []string{
 				"lien", // Agoric removed the lien module
@@ -1041,10 +1096,11 @@ func (app *GaiaApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
 	res := app.mm.InitGenesis(ctx, app.appCodec, genesisState)
 
-	// initialize the provision and reserve module accounts, to avoid their implicit creation
+	// initialize the provision, reserve, and refund module accounts, to avoid their implicit creation
 	// as a default account upon receiving a transfer. See BlockedAddrs().
 	normalizeModuleAccount(ctx, app.AccountKeeper, vbanktypes.ProvisionPoolName)
 	normalizeModuleAccount(ctx, app.AccountKeeper, vbanktypes.ReservePoolName)
+	normalizeModuleAccount(ctx, app.AccountKeeper, swingsettypes.RefundPoolName)
 
 	// Init early (before first BeginBlock) to run the potentially lengthy bootstrap
 	if app.bootstrapNeeded {
@@ -1113,12 +1169,12 @@ func (app *GaiaApp) ModuleAccountAddrs() map[string]bool {
 func (app *GaiaApp) BlockedAddrs() map[string]bool {
 	modAccAddrs := make(map[string]bool)
 	for acc := range maccPerms {
-		// The provision and reserve pools are not blocked from receiving funds.
+		// The provision, reserve, and refund pools are not blocked from receiving funds.
 		// NOTE: because of this, these pools must be explicitly
 		// initialized as module accounts during bootstrap to avoid
 		// implicit creation as a default account when funds are received.
 		switch acc {
-		case vbanktypes.ProvisionPoolName, vbanktypes.ReservePoolName:
+		case vbanktypes.ProvisionPoolName, vbanktypes.ReservePoolName, swingsettypes.RefundPoolName:
 			continue
 		}
 		modAccAddrs[authtypes.NewModuleAddress(acc).String()] = true
@@ -1251,6 +1307,7 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	paramsKeeper.Subspace(icahosttypes.SubModuleName)
 	paramsKeeper.Subspace(swingset.ModuleName)
 	paramsKeeper.Subspace(vbank.ModuleName)
+	paramsKeeper.Subspace(vstorage.ModuleName)
 
 	return paramsKeeper
 }
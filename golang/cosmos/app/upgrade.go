@@ -8,6 +8,7 @@ import (
 
 	"github.com/Agoric/agoric-sdk/golang/cosmos/vm"
 	swingsetkeeper "github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/keeper"
+	swingsettypes "github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
@@ -210,6 +211,9 @@ func unreleasedUpgradeHandler(app *GaiaApp, targetUpgrade string) func(sdk.Conte
 			return mvm, err
 		}
 
+		// Initialize the new refund module account, as InitChainer does.
+		normalizeModuleAccount(ctx, app.AccountKeeper, swingsettypes.RefundPoolName)
+
 		return mvm, nil
 	}
 }
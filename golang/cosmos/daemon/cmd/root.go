@@ -37,6 +37,8 @@ import (
 	"github.com/Agoric/agoric-sdk/golang/cosmos/app/params"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/vm"
 	swingsetkeeper "github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/keeper"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage"
+	vstoragecli "github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/client/cli"
 )
 
 var AppName = "agd"
@@ -142,6 +144,7 @@ func initRootCmd(sender vm.Sender, rootCmd *cobra.Command, encodingConfig params
 		config.Cmd(),
 		pruning.Cmd(ac.newSnapshotsApp, gaia.DefaultNodeHome),
 		snapshot.Cmd(ac.newSnapshotsApp),
+		vstoragecli.GetToolsCmd(),
 	)
 
 	server.AddCommands(rootCmd, gaia.DefaultNodeHome, ac.newApp, ac.appExport, addModuleInitFlags)
@@ -199,6 +202,21 @@ func addAgoricVMFlags(cmd *cobra.Command) {
 
 func addModuleInitFlags(startCmd *cobra.Command) {
 	addAgoricVMFlags(startCmd)
+	startCmd.Flags().Int(
+		gaia.FlagVstorageMaxPatternMatches,
+		vstorage.DefaultMaxPatternMatches,
+		"Maximum number of paths returned by a single vstorage path pattern query",
+	)
+	startCmd.Flags().Int(
+		gaia.FlagVstorageMaxPatternVisits,
+		vstorage.DefaultMaxPatternVisits,
+		"Maximum number of entries read by a single vstorage path pattern query",
+	)
+	startCmd.Flags().String(
+		gaia.FlagVstorageChangeLog,
+		"",
+		"File (relative to the home directory unless absolute) to which vstorage changes are appended as JSON Lines",
+	)
 }
 
 func queryCommand() *cobra.Command {
@@ -13,6 +13,11 @@ const (
 	AttributeKeyStoreSubkey   = "key"
 	AttributeKeyAnchoredKey   = "anckey"
 	AttributeKeyUnprovedValue = "value"
+	AttributeKeyDeleted       = "deleted"
+
+	// Values of AttributeKeyDeleted.
+	DeletedValue   = "true"
+	DeletedSubtree = "subtree"
 
 	// We chose \1 so that it is not a valid character in a vstorage path.
 	AnchoredKeyStart = "\x01"
@@ -39,3 +44,19 @@ func NewStateChangeEvent(storeName string, subkey, value []byte) sdk.Event {
 		sdk.NewAttribute(AttributeKeyUnprovedValue, string(value)),
 	)
 }
+
+// NewStateDeleteEvent returns a state change event for the removal of the
+// value at subkey, which has the shape of a change to an empty value plus an
+// AttributeKeyDeleted attribute to distinguish it from one.
+func NewStateDeleteEvent(storeName string, subkey []byte) sdk.Event {
+	event := NewStateChangeEvent(storeName, subkey, []byte{})
+	return event.AppendAttributes(sdk.NewAttribute(AttributeKeyDeleted, DeletedValue))
+}
+
+// NewStateDeleteSubtreeEvent is like NewStateDeleteEvent, but represents the
+// removal of the values at subkey and every key that it encodes as descending
+// from it.
+func NewStateDeleteSubtreeEvent(storeName string, subkey []byte) sdk.Event {
+	event := NewStateChangeEvent(storeName, subkey, []byte{})
+	return event.AppendAttributes(sdk.NewAttribute(AttributeKeyDeleted, DeletedSubtree))
+}
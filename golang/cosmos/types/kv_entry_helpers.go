@@ -37,6 +37,8 @@ import (
 //     types are available:
 //     - NewVstorageDataEntriesReader constructs a reader from a slice of
 //       vstorage DataEntry values.
+//     - NewVstorageDataDiffsReader constructs a reader from a slice of
+//       vstorage DataDiff values, representing removal as no value.
 //     - NewSwingStoreExportDataEntriesReader constructs a reader from a slice
 //       of SwingStoreExportDataEntry values.
 //     - NewJsonRawMessageKVEntriesReader constructs a reader from a slice of
@@ -157,6 +159,21 @@ func NewVstorageDataEntriesReader(vstorageDataEntries []*vstoragetypes.DataEntry
 	}
 }
 
+// NewVstorageDataDiffsReader creates a KVEntryReader backed by a vstorage
+// DataDiff slice, yielding each added or changed path with its new value and
+// each removed path with no value
+func NewVstorageDataDiffsReader(vstorageDataDiffs []vstoragetypes.DataDiff) KVEntryReader {
+	return &kvEntriesReader[vstoragetypes.DataDiff]{
+		entries: vstorageDataDiffs,
+		toKVEntry: func(sourceDiff vstoragetypes.DataDiff) (KVEntry, error) {
+			if sourceDiff.Change == vstoragetypes.DataDiffRemoved {
+				return NewKVEntryWithNoValue(sourceDiff.Path), nil
+			}
+			return NewKVEntry(sourceDiff.Path, sourceDiff.NewValue), nil
+		},
+	}
+}
+
 // NewSwingStoreExportDataEntriesReader creates a KVEntryReader backed by
 // a SwingStoreExportDataEntry slice
 func NewSwingStoreExportDataEntriesReader(exportDataEntries []*swingsettypes.SwingStoreExportDataEntry) KVEntryReader {
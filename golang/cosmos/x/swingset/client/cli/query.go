@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"strconv"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
@@ -21,6 +24,12 @@ func GetQueryCmd(storeKey string) *cobra.Command {
 		GetCmdGetEgress(storeKey),
 		GetCmdQueryParams(storeKey),
 		GetCmdMailbox(storeKey),
+		GetCmdInboundQueue(storeKey),
+		GetCmdActionByTx(storeKey),
+		GetCmdSmartWalletProvision(storeKey),
+		GetCmdFeeCharges(storeKey),
+		GetCmdBeansOwing(storeKey),
+		GetCmdEstimateFee(storeKey),
 	)
 
 	return swingsetQueryCmd
@@ -115,3 +124,205 @@ func GetCmdMailbox(queryRoute string) *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
+
+// GetCmdInboundQueue queries the records waiting in the inbound queues
+func GetCmdInboundQueue(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inbound-queue [highPriorityQueue|actionQueue]",
+		Short: "get the records waiting in the inbound queues (high priority first)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			req := &types.QueryInboundQueueRequest{
+				Pagination: pageReq,
+			}
+			if len(args) > 0 {
+				req.Queue = args[0]
+			}
+
+			res, err := queryClient.InboundQueue(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "inbound-queue")
+	return cmd
+}
+
+// GetCmdActionByTx queries the queued actions of a transaction
+func GetCmdActionByTx(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "action-by-tx <tx-hash>",
+		Short: "get the inbound queue records of the actions from a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.ActionByTx(cmd.Context(), &types.QueryActionByTxRequest{
+				TxHash: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdSmartWalletProvision queries the progress of provisioning a smart wallet
+func GetCmdSmartWalletProvision(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "smart-wallet-provision <account>",
+		Short: "get the progress of provisioning the smart wallet of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.SmartWalletProvision(cmd.Context(), &types.QuerySmartWalletProvisionRequest{
+				Address: addr,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func GetCmdFeeCharges(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-charges <block-height> <tx-hash>",
+		Short: "get the fees charged by a transaction, and their refunds",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			blockHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.FeeCharges(cmd.Context(), &types.QueryFeeChargesRequest{
+				BlockHeight: blockHeight,
+				TxHash:      args[1],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func GetCmdBeansOwing(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "beans-owing <account>",
+		Short: "get the beans that an account owes but has not yet paid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.BeansOwing(cmd.Context(), &types.QueryBeansOwingRequest{
+				Address: addr,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func GetCmdEstimateFee(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate-fee <msg-json>",
+		Short: "estimate the fee that admitting a message would charge",
+		Long: `Estimate the fee that admitting a message would charge, given the message as
+JSON with its "@type" (e.g., "/agoric.swingset.MsgWalletAction").`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			var msg sdk.Msg
+			if err := clientCtx.Codec.UnmarshalInterfaceJSON([]byte(args[0]), &msg); err != nil {
+				return err
+			}
+			anyMsg, err := codectypes.NewAnyWithValue(msg)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.EstimateFee(cmd.Context(), &types.QueryEstimateFeeRequest{
+				Msg: anyMsg,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
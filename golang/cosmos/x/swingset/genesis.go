@@ -23,14 +23,25 @@ func ValidateGenesis(data *types.GenesisState) error {
 	if err := data.Params.ValidateBasic(); err != nil {
 		return err
 	}
+	seen := make(map[string]bool, len(data.SmartWalletProvisions))
+	for i, provision := range data.SmartWalletProvisions {
+		if err := sdk.VerifyAddressFormat(provision.Address); err != nil {
+			return fmt.Errorf("smart wallet provision %d: %w", i, err)
+		}
+		if seen[provision.Address.String()] {
+			return fmt.Errorf("duplicate smart wallet provision for %s", provision.Address)
+		}
+		seen[provision.Address.String()] = true
+	}
 	return nil
 }
 
 func DefaultGenesisState() *types.GenesisState {
 	return &types.GenesisState{
-		Params:               types.DefaultParams(),
-		State:                types.State{},
-		SwingStoreExportData: []*types.SwingStoreExportDataEntry{},
+		Params:                types.DefaultParams(),
+		State:                 types.State{},
+		SwingStoreExportData:  []*types.SwingStoreExportDataEntry{},
+		SmartWalletProvisions: []types.SmartWalletProvision{},
 	}
 }
 
@@ -39,6 +50,9 @@ func DefaultGenesisState() *types.GenesisState {
 func InitGenesis(ctx sdk.Context, k Keeper, swingStoreExportsHandler *SwingStoreExportsHandler, swingStoreExportDir string, data *types.GenesisState) bool {
 	k.SetParams(ctx, data.GetParams())
 	k.SetState(ctx, data.GetState())
+	for _, provision := range data.GetSmartWalletProvisions() {
+		k.SetSmartWalletProvision(ctx, provision)
+	}
 
 	swingStoreExportData := data.GetSwingStoreExportData()
 	if len(swingStoreExportData) == 0 && data.SwingStoreExportDataHash == "" {
@@ -132,9 +146,10 @@ func InitGenesis(ctx sdk.Context, k Keeper, swingStoreExportsHandler *SwingStore
 
 func ExportGenesis(ctx sdk.Context, k Keeper, swingStoreExportsHandler *SwingStoreExportsHandler, swingStoreExportDir string) *types.GenesisState {
 	gs := &types.GenesisState{
-		Params:               k.GetParams(ctx),
-		State:                k.GetState(ctx),
-		SwingStoreExportData: nil,
+		Params:                k.GetParams(ctx),
+		State:                 k.GetState(ctx),
+		SwingStoreExportData:  nil,
+		SmartWalletProvisions: k.GetSmartWalletProvisions(ctx),
 	}
 
 	snapshotHeight := uint64(ctx.BlockHeight())
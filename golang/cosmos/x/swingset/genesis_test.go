@@ -1,7 +1,12 @@
 package swingset
 
 import (
+	"strings"
 	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
 )
 
 func TestDefaultGenesis(t *testing.T) {
@@ -10,3 +15,33 @@ func TestDefaultGenesis(t *testing.T) {
 		t.Errorf("DefaultGenesisState did not validate %v: %e", defaultGenesisState, err)
 	}
 }
+
+func TestValidateGenesisSmartWalletProvisions(t *testing.T) {
+	addr := sdk.AccAddress([]byte("addr"))
+	tests := []struct {
+		name        string
+		provisions  []types.SmartWalletProvision
+		errContains string
+	}{
+		{name: "valid", provisions: []types.SmartWalletProvision{
+			{Address: addr, State: types.SmartWalletStatePending},
+			{Address: sdk.AccAddress([]byte("other")), State: types.SmartWalletStateFailed},
+		}},
+		{name: "empty address", provisions: []types.SmartWalletProvision{{}}, errContains: "smart wallet provision 0"},
+		{name: "duplicate", provisions: []types.SmartWalletProvision{{Address: addr}, {Address: addr}}, errContains: "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := DefaultGenesisState()
+			gs.SmartWalletProvisions = tt.provisions
+			err := ValidateGenesis(gs)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("got error %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("got error %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
@@ -2,14 +2,22 @@ package keeper
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	sdkmath "cosmossdk.io/math"
+
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
+// MaxInboundQueueEntries bounds the number of records that a single
+// InboundQueue request can return.
+const MaxInboundQueueEntries = 1000
+
 // Querier is used as Keeper will have duplicate methods if used directly, and gRPC names take precedence over keeper
 type Querier struct {
 	Keeper
@@ -61,3 +69,176 @@ func (k Querier) Mailbox(c context.Context, req *types.QueryMailboxRequest) (*ty
 		Value: value,
 	}, nil
 }
+
+func (k Querier) InboundQueue(c context.Context, req *types.QueryInboundQueueRequest) (*types.QueryInboundQueueResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	queuePaths := InboundQueuePaths
+	if req.Queue != "" {
+		queuePaths = nil
+		for _, path := range InboundQueuePaths {
+			if path == req.Queue {
+				queuePaths = []string{path}
+			}
+		}
+		if queuePaths == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown inbound queue %q", req.Queue)
+		}
+	}
+
+	limit := uint64(query.DefaultLimit)
+	startIndex := sdkmath.ZeroInt()
+	if page := req.Pagination; page != nil {
+		if page.Offset > 0 || page.CountTotal || page.Reverse {
+			return nil, status.Error(codes.InvalidArgument, "pagination supports only key and limit")
+		}
+		if page.Limit > 0 {
+			limit = page.Limit
+		}
+		if len(page.Key) > 0 {
+			// Skip the queues that precede the one of the key.
+			keyQueue, keyIndex, _ := strings.Cut(string(page.Key), ".")
+			for len(queuePaths) > 0 && queuePaths[0] != keyQueue {
+				queuePaths = queuePaths[1:]
+			}
+			index, ok := sdkmath.NewIntFromString(keyIndex)
+			if len(queuePaths) == 0 || !ok || index.IsNegative() {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid pagination key %q", page.Key)
+			}
+			startIndex = index
+		}
+	}
+	if limit > MaxInboundQueueEntries {
+		limit = MaxInboundQueueEntries
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries := []types.InboundQueueEntry{}
+	var nextKey []byte
+	for i, queuePath := range queuePaths {
+		if i > 0 {
+			startIndex = sdkmath.ZeroInt()
+		}
+		err := k.IterateInboundQueue(ctx, queuePath, startIndex, func(entry types.InboundQueueEntry) bool {
+			if uint64(len(entries)) >= limit {
+				nextKey = []byte(entry.Queue + "." + entry.Index)
+				return true
+			}
+			entries = append(entries, entry)
+			return false
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if nextKey != nil {
+			break
+		}
+	}
+
+	return &types.QueryInboundQueueResponse{
+		Entries:    entries,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
+func (k Querier) ActionByTx(c context.Context, req *types.QueryActionByTxRequest) (*types.QueryActionByTxResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.TxHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty tx_hash")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries := []types.InboundQueueEntry{}
+	for _, queuePath := range InboundQueuePaths {
+		err := k.IterateInboundQueue(ctx, queuePath, sdkmath.ZeroInt(), func(entry types.InboundQueueEntry) bool {
+			if strings.EqualFold(entry.TxHash, req.TxHash) {
+				entries = append(entries, entry)
+			}
+			return false
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if len(entries) == 0 {
+		return nil, status.Error(codes.NotFound, "no queued action for transaction")
+	}
+
+	return &types.QueryActionByTxResponse{
+		Entries: entries,
+	}, nil
+}
+
+func (k Querier) SmartWalletProvision(c context.Context, req *types.QuerySmartWalletProvisionRequest) (*types.QuerySmartWalletProvisionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Address.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "empty address")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	provision, _ := k.GetSmartWalletProvision(ctx, req.Address)
+	provision.State = k.GetSmartWalletState(ctx, req.Address)
+
+	return &types.QuerySmartWalletProvisionResponse{
+		Provision: provision,
+	}, nil
+}
+
+func (k Querier) FeeCharges(c context.Context, req *types.QueryFeeChargesRequest) (*types.QueryFeeChargesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.TxHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty tx hash")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryFeeChargesResponse{
+		Charges: k.GetFeeCharges(ctx, req.BlockHeight, req.TxHash),
+	}, nil
+}
+
+func (k Querier) BeansOwing(c context.Context, req *types.QueryBeansOwingRequest) (*types.QueryBeansOwingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Address.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "empty address")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryBeansOwingResponse{
+		BeansOwing: k.GetBeansOwing(ctx, req.Address),
+	}, nil
+}
+
+func (k Querier) EstimateFee(c context.Context, req *types.QueryEstimateFeeRequest) (*types.QueryEstimateFeeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Msg == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty msg")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var msg sdk.Msg
+	if err := k.cdc.UnpackAny(req.Msg, &msg); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	payer, beans, fee, beansOwing, err := k.EstimateAdmissionFee(ctx, msg)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryEstimateFeeResponse{
+		Payer:      payer,
+		Beans:      beans,
+		Fee:        fee,
+		BeansOwing: beansOwing,
+	}, nil
+}
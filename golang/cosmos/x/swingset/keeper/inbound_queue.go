@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
+)
+
+// InboundQueuePaths are the paths of the inbound queues, in the order in which
+// the controller consumes them.
+var InboundQueuePaths = []string{StoragePathHighPriorityQueue, StoragePathActionQueue}
+
+// inboundQueueRecordHeader is the part of an InboundQueueRecord that describes
+// its action.
+type inboundQueueRecordHeader struct {
+	Action struct {
+		Type string `json:"type"`
+	} `json:"action"`
+	Context types.ActionContext `json:"context"`
+}
+
+// NewInboundQueueEntry describes the record at an index of an inbound queue.
+// A record that is not a well-formed InboundQueueRecord is described only by
+// its text.
+func NewInboundQueueEntry(queuePath string, index sdkmath.Int, record string) types.InboundQueueEntry {
+	entry := types.InboundQueueEntry{
+		Queue:  queuePath,
+		Index:  index.String(),
+		Record: record,
+	}
+	var header inboundQueueRecordHeader
+	if err := json.Unmarshal([]byte(record), &header); err == nil {
+		entry.ActionType = header.Action.Type
+		entry.BlockHeight = header.Context.BlockHeight
+		entry.TxHash = header.Context.TxHash
+		entry.MsgIdx = int64(header.Context.MsgIdx)
+	}
+	return entry
+}
+
+// IterateInboundQueue calls cb with each record of an inbound queue in order
+// from startIndex (or the head of the queue, if later), until either cb
+// returns true or there are no more records.
+func (k Keeper) IterateInboundQueue(ctx sdk.Context, queuePath string, startIndex sdkmath.Int, cb func(entry types.InboundQueueEntry) (stop bool)) error {
+	isInboundQueue := false
+	for _, path := range InboundQueuePaths {
+		isInboundQueue = isInboundQueue || path == queuePath
+	}
+	if !isInboundQueue {
+		return fmt.Errorf("unknown inbound queue %q", queuePath)
+	}
+	head, tail, err := k.vstorageKeeper.GetQueueBounds(ctx, queuePath)
+	if err != nil {
+		return err
+	}
+	if startIndex.GT(head) {
+		head = startIndex
+	}
+	for index := head; index.LT(tail); index = index.AddRaw(1) {
+		entry := k.vstorageKeeper.GetEntry(ctx, queuePath+"."+index.String())
+		if !entry.HasValue() {
+			return fmt.Errorf("queue %s has no item at index %s", queuePath, index)
+		}
+		if cb(NewInboundQueueEntry(queuePath, index, entry.StringValue())) {
+			break
+		}
+	}
+	return nil
+}
@@ -47,8 +47,10 @@ const (
 )
 
 const (
-	stateKey            = "state"
-	swingStoreKeyPrefix = "swingStore."
+	stateKey                      = "state"
+	swingStoreKeyPrefix           = "swingStore."
+	smartWalletProvisionKeyPrefix = "smartWalletProvision."
+	feeChargeKeyPrefix            = "feeCharge."
 )
 
 // Keeper maintains the link to data vstorage and exposes getter/setter methods for the various parts of the state machine
@@ -162,12 +164,12 @@ func (k Keeper) GetSmartWalletState(ctx sdk.Context, addr sdk.AccAddress) types.
 	// `provideSmartWallet` from packages/smart-wallet/src/walletFactory.js
 	walletStoragePath := StoragePathCustom + "." + WalletStoragePathSegment + "." + addr.String()
 
-	// TODO: implement a pending provision state
 	if k.vstorageKeeper.HasEntry(ctx, walletStoragePath) {
 		return types.SmartWalletStateProvisioned
 	}
 
-	return types.SmartWalletStateNone
+	provision, _ := k.GetSmartWalletProvision(ctx, addr)
+	return provision.State
 }
 
 func (k Keeper) InboundQueueLength(ctx sdk.Context) (int32, error) {
@@ -305,9 +307,43 @@ func (k Keeper) SetBeansOwing(ctx sdk.Context, addr sdk.AccAddress, beans sdkmat
 // the beans into the number to debit immediately vs. the number to store in the
 // beansOwing.
 func (k Keeper) ChargeBeans(ctx sdk.Context, addr sdk.AccAddress, beans sdkmath.Uint) error {
+	debit, remainderOwing := k.beansCharge(ctx, addr, k.GetBeansOwing(ctx, addr), beans, nil)
+
+	// Charge the account immediately if they owe more than BeansPerMinFeeDebit.
+	if !debit.fee.IsZero() {
+		err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, addr, k.feeRecipient(ctx), debit.fee)
+		if err != nil {
+			return err
+		}
+		ctx.EventManager().EmitEvent(types.NewFeeDebitEvent(addr, debit.fee, debit.beans, debit.feeUnitPrice))
+	}
+
+	// Record the new owing value, whether we have debited immediately or not
+	// (i.e. there is more owing than before, but not enough to debit).
+	k.SetBeansOwing(ctx, addr, remainderOwing)
+	k.recordFeeCharge(ctx, addr, debit.fee, beans)
+	return nil
+}
+
+// beansDebit is the part of a charge of beans that is debited immediately, and
+// the fee unit price at which it is converted to coins.
+type beansDebit struct {
+	beans        sdkmath.Uint
+	feeUnitPrice sdk.Coins
+	fee          sdk.Coins
+}
+
+// beansCharge returns the debit for charging beans to an account that was
+// owing wasOwing beans, and the beans that remain owing. The debit is at the
+// FeeUnitPrice if the account's spendable balance (less spent) covers it, or
+// else at the first of the AlternativeFeeUnitPrices that it covers. The
+// alternatives are tried in the order that governance configured them, which
+// is their order of preference, so a payer that could cover several is charged
+// in the earliest rather than in whichever they hold the most of.
+func (k Keeper) beansCharge(ctx sdk.Context, addr sdk.AccAddress, wasOwing, beans sdkmath.Uint, spent sdk.Coins) (beansDebit, sdkmath.Uint) {
 	beansPerUnit := k.GetBeansPerUnit(ctx)
+	params := k.GetParams(ctx)
 
-	wasOwing := k.GetBeansOwing(ctx, addr)
 	nowOwing := wasOwing.Add(beans)
 
 	// Actually debit immediately in integer multiples of the minimum debit, since
@@ -316,30 +352,91 @@ func (k Keeper) ChargeBeans(ctx sdk.Context, addr sdk.AccAddress, beans sdkmath.
 	remainderOwing := nowOwing.Mod(beansPerMinFeeDebit)
 	beansToDebit := nowOwing.Sub(remainderOwing)
 
+	debit := beansDebit{
+		beans:        beansToDebit,
+		feeUnitPrice: params.FeeUnitPrice,
+		fee:          sdk.NewCoins(),
+	}
+	if beansToDebit.IsZero() {
+		return debit, remainderOwing
+	}
+
 	// Convert the debit to coins.
+	// NOTE: We assume that BeansPerMinFeeDebit is a multiple of BeansPerFeeUnit.
 	beansPerFeeUnitDec := sdk.NewDecFromBigInt(beansPerUnit[types.BeansPerFeeUnit].BigInt())
 	beansToDebitDec := sdk.NewDecFromBigInt(beansToDebit.BigInt())
-	feeUnitPrice := k.GetParams(ctx).FeeUnitPrice
-	feeDecCoins := sdk.NewDecCoinsFromCoins(feeUnitPrice...).MulDec(beansToDebitDec).QuoDec(beansPerFeeUnitDec)
+	feeAt := func(feeUnitPrice sdk.Coins) sdk.Coins {
+		feeDecCoins := sdk.NewDecCoinsFromCoins(feeUnitPrice...).MulDec(beansToDebitDec).QuoDec(beansPerFeeUnitDec)
+		feeCoins, _ := feeDecCoins.TruncateDecimal()
+		return feeCoins
+	}
+	debit.fee = feeAt(params.FeeUnitPrice)
+	if len(params.AlternativeFeeUnitPrices) == 0 {
+		return debit, remainderOwing
+	}
+
+	spendable, _ := k.bankKeeper.SpendableCoins(ctx, addr).SafeSub(spent...)
+	if debit.fee.IsAllLTE(spendable) {
+		return debit, remainderOwing
+	}
+	for _, price := range params.AlternativeFeeUnitPrices {
+		feeUnitPrice := sdk.NewCoins(price)
+		if fee := feeAt(feeUnitPrice); fee.IsAllLTE(spendable) {
+			debit.feeUnitPrice = feeUnitPrice
+			debit.fee = fee
+			break
+		}
+	}
 
-	// Charge the account immediately if they owe more than BeansPerMinFeeDebit.
-	// NOTE: We assume that BeansPerMinFeeDebit is a multiple of BeansPerFeeUnit.
-	feeCoins, _ := feeDecCoins.TruncateDecimal()
-	if !feeCoins.IsZero() {
-		err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, addr, k.feeCollectorName, feeCoins)
-		if err != nil {
-			return err
+	// If no price is covered, the debit at FeeUnitPrice fails as it would have
+	// without alternatives.
+	return debit, remainderOwing
+}
+
+// EstimateAdmissionFee returns what admitting a message would charge, without charging
+// it: the address to charge, the beans charged (including for provisioning a
+// smart wallet, if that would be charged too), the coins to debit immediately,
+// and the beans that would then be owing.
+func (k Keeper) EstimateAdmissionFee(ctx sdk.Context, msg sdk.Msg) (sdk.AccAddress, sdkmath.Uint, sdk.Coins, sdkmath.Uint, error) {
+	beansPerUnit := k.GetBeansPerUnit(ctx)
+	addr, msgBeans, needsSmartWallet, err := types.AdmissionCharge(beansPerUnit, msg)
+	if err != nil {
+		return nil, sdkmath.ZeroUint(), nil, sdkmath.ZeroUint(), err
+	}
+
+	// Charge in the same order and amounts as CheckAdmissibility.
+	charges := []sdkmath.Uint{}
+	if needsSmartWallet {
+		switch k.GetSmartWalletState(ctx, addr) {
+		case types.SmartWalletStateProvisioned, types.SmartWalletStatePending:
+		default:
+			if provision, _ := k.GetSmartWalletProvision(ctx, addr); !provision.FeeCharged {
+				charges = append(charges, beansPerUnit[types.BeansPerSmartWalletProvision])
+			}
 		}
 	}
+	charges = append(charges, msgBeans)
 
-	// Record the new owing value, whether we have debited immediately or not
-	// (i.e. there is more owing than before, but not enough to debit).
-	k.SetBeansOwing(ctx, addr, remainderOwing)
-	return nil
+	beans := sdkmath.ZeroUint()
+	fee := sdk.NewCoins()
+	owing := k.GetBeansOwing(ctx, addr)
+	for _, charge := range charges {
+		var debit beansDebit
+		debit, owing = k.beansCharge(ctx, addr, owing, charge, fee)
+		fee = fee.Add(debit.fee...)
+		beans = beans.Add(charge)
+	}
+	return addr, beans, fee, owing, nil
 }
 
-// ChargeForSmartWallet charges the fee for provisioning a smart wallet.
+// ChargeForSmartWallet charges the fee for provisioning a smart wallet, unless
+// it has already been charged for the provision.
 func (k Keeper) ChargeForSmartWallet(ctx sdk.Context, addr sdk.AccAddress) error {
+	provision, _ := k.GetSmartWalletProvision(ctx, addr)
+	if provision.FeeCharged {
+		return nil
+	}
+
 	beansPerUnit := k.GetBeansPerUnit(ctx)
 	beans := beansPerUnit[types.BeansPerSmartWalletProvision]
 	err := k.ChargeBeans(ctx, addr, beans)
@@ -347,13 +444,8 @@ func (k Keeper) ChargeForSmartWallet(ctx sdk.Context, addr sdk.AccAddress) error
 		return err
 	}
 
-	// TODO: mark that a smart wallet provision is pending. However in that case,
-	// auto-provisioning should still be performed (but without fees being charged),
-	// until the controller actually provisions the smart wallet (the operation may
-	// transiently fail, requiring retries until success).
-	// However the provisioning code is not currently idempotent, and has side
-	// effects when the smart wallet is already provisioned.
-
+	provision.FeeCharged = true
+	k.SetSmartWalletProvision(ctx, provision)
 	return nil
 }
 
@@ -403,18 +495,25 @@ func calculateFees(balances sdk.Coins, submitter, addr sdk.AccAddress, powerFlag
 	return fees, nil
 }
 
-func (k Keeper) ChargeForProvisioning(ctx sdk.Context, submitter, addr sdk.AccAddress, powerFlags []string) error {
+// ChargeForProvisioning charges the submitter the fees for provisioning an
+// address with the given power flags, and returns them.
+func (k Keeper) ChargeForProvisioning(ctx sdk.Context, submitter, addr sdk.AccAddress, powerFlags []string) (sdk.Coins, error) {
 	balances := k.bankKeeper.GetAllBalances(ctx, submitter)
 	fees, err := calculateFees(balances, submitter, addr, powerFlags, k.GetParams(ctx).PowerFlagFees)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Deduct the fee from the submitter.
 	if fees.IsZero() {
-		return nil
+		return fees, nil
 	}
-	return k.bankKeeper.SendCoinsFromAccountToModule(ctx, submitter, k.feeCollectorName, fees)
+	err = k.bankKeeper.SendCoinsFromAccountToModule(ctx, submitter, k.feeRecipient(ctx), fees)
+	if err != nil {
+		return nil, err
+	}
+	k.recordFeeCharge(ctx, submitter, fees, sdkmath.ZeroUint())
+	return fees, nil
 }
 
 // GetEgress gets the entire egress struct for a peer
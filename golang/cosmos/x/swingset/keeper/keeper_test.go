@@ -1,17 +1,38 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/app/params"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/vm"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
+	vstoragekeeper "github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/keeper"
+	vstoragetypes "github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/store"
 	prefixstore "github.com/cosmos/cosmos-sdk/store/prefix"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
+	grpcCodes "google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 func mkcoin(denom string) func(amt int64) sdk.Coin {
@@ -258,3 +279,823 @@ func TestSwingStore(t *testing.T) {
 		t.Errorf("got export %q, want %q", gotEntries, expectedEntries)
 	}
 }
+
+func TestInboundQueueQueries(t *testing.T) {
+	vstorageStoreKey := storetypes.NewKVStoreKey(vstoragetypes.StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 3}, false, log.NewNopLogger())
+	keeper := Keeper{vstorageKeeper: vstoragekeeper.NewKeeper(vstorageStoreKey)}
+	querier := Querier{keeper}
+
+	push := func(queuePath, txHash string, msgIdx int) {
+		t.Helper()
+		txCtx := ctx.WithContext(context.WithValue(
+			context.WithValue(ctx.Context(), baseapp.TxHashContextKey, txHash),
+			baseapp.TxMsgIdxContextKey, msgIdx,
+		))
+		action := &walletAction{Owner: "owner", Action: "{}"}
+		if err := keeper.pushAction(txCtx, queuePath, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	push(StoragePathActionQueue, "AAAA", 0)
+	push(StoragePathActionQueue, "BBBB", 0)
+	push(StoragePathActionQueue, "AAAA", 1)
+	push(StoragePathHighPriorityQueue, "CCCC", 0)
+	// Consumed records are not listed.
+	if _, err := keeper.vstorageKeeper.PopQueueItems(ctx, StoragePathActionQueue, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	type location struct {
+		queue, index, txHash string
+	}
+	locations := func(entries []types.InboundQueueEntry) []location {
+		locs := []location{}
+		for _, entry := range entries {
+			if entry.ActionType != "WALLET_ACTION" || entry.BlockHeight != 3 {
+				t.Errorf("got unexpected entry %+v", entry)
+			}
+			locs = append(locs, location{entry.Queue, entry.Index, entry.TxHash})
+		}
+		return locs
+	}
+
+	type testCase struct {
+		label    string
+		request  *types.QueryInboundQueueRequest
+		expected []location
+		nextKey  string
+		errCode  grpcCodes.Code
+	}
+	testCases := []testCase{
+		{label: "all", request: &types.QueryInboundQueueRequest{}, expected: []location{
+			{"highPriorityQueue", "0", "CCCC"},
+			{"actionQueue", "1", "BBBB"},
+			{"actionQueue", "2", "AAAA"},
+		}},
+		{label: "one queue", request: &types.QueryInboundQueueRequest{Queue: "actionQueue"}, expected: []location{
+			{"actionQueue", "1", "BBBB"},
+			{"actionQueue", "2", "AAAA"},
+		}},
+		{label: "first page",
+			request:  &types.QueryInboundQueueRequest{Pagination: &query.PageRequest{Limit: 1}},
+			expected: []location{{"highPriorityQueue", "0", "CCCC"}},
+			nextKey:  "actionQueue.1",
+		},
+		{label: "later page",
+			request:  &types.QueryInboundQueueRequest{Pagination: &query.PageRequest{Key: []byte("actionQueue.2")}},
+			expected: []location{{"actionQueue", "2", "AAAA"}},
+		},
+		{label: "unknown queue", request: &types.QueryInboundQueueRequest{Queue: "other"}, errCode: grpcCodes.InvalidArgument},
+		{label: "bad key",
+			request: &types.QueryInboundQueueRequest{Pagination: &query.PageRequest{Key: []byte("other.1")}},
+			errCode: grpcCodes.InvalidArgument,
+		},
+		{label: "offset",
+			request: &types.QueryInboundQueueRequest{Pagination: &query.PageRequest{Offset: 1}},
+			errCode: grpcCodes.InvalidArgument,
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.InboundQueue(sdk.WrapSDKContext(ctx), desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error %v, want code %q", desc.label, err, desc.errCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if got := locations(resp.Entries); !reflect.DeepEqual(got, desc.expected) {
+			t.Errorf("%s: got entries %v, want %v", desc.label, got, desc.expected)
+		}
+		if got := string(resp.Pagination.NextKey); got != desc.nextKey {
+			t.Errorf("%s: got next key %q, want %q", desc.label, got, desc.nextKey)
+		}
+	}
+
+	resp, err := querier.ActionByTx(sdk.WrapSDKContext(ctx), &types.QueryActionByTxRequest{TxHash: "aaaa"})
+	if err != nil {
+		t.Fatalf("action by tx: got error %v", err)
+	}
+	if got, want := locations(resp.Entries), []location{{"actionQueue", "2", "AAAA"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("action by tx: got entries %v, want %v", got, want)
+	}
+	if resp.Entries[0].MsgIdx != 1 {
+		t.Errorf("action by tx: got msg index %d, want 1", resp.Entries[0].MsgIdx)
+	}
+	_, err = querier.ActionByTx(sdk.WrapSDKContext(ctx), &types.QueryActionByTxRequest{TxHash: "DDDD"})
+	if code := grpcStatus.Code(err); code != grpcCodes.NotFound {
+		t.Errorf("action by absent tx: got error %v, want code %q", err, grpcCodes.NotFound)
+	}
+}
+
+func TestSmartWalletProvision(t *testing.T) {
+	vstorageStoreKey := storetypes.NewKVStoreKey(vstoragetypes.StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(swingsetStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 10}, false, log.NewNopLogger())
+	keeper := Keeper{
+		storeKey:       swingsetStoreKey,
+		cdc:            codec.NewProtoCodec(codectypes.NewInterfaceRegistry()),
+		vstorageKeeper: vstoragekeeper.NewKeeper(vstorageStoreKey),
+	}
+	addr := sdk.AccAddress([]byte("wallet"))
+
+	checkState := func(label string, want types.SmartWalletState, wantAttempts uint32) {
+		t.Helper()
+		if got := keeper.GetSmartWalletState(ctx, addr); got != want {
+			t.Errorf("%s: got state %d, want %d", label, got, want)
+		}
+		if provision, _ := keeper.GetSmartWalletProvision(ctx, addr); provision.Attempts != wantAttempts {
+			t.Errorf("%s: got %d attempts, want %d", label, provision.Attempts, wantAttempts)
+		}
+	}
+	checkState("initial", types.SmartWalletStateNone, 0)
+
+	// A previously charged fee is not charged again (which would fail without
+	// a bank keeper).
+	keeper.SetSmartWalletProvision(ctx, types.SmartWalletProvision{Address: addr, State: types.SmartWalletStateNone, FeeCharged: true})
+	if err := keeper.ChargeForSmartWallet(ctx, addr); err != nil {
+		t.Fatalf("got error %v charging again", err)
+	}
+
+	if !keeper.StartSmartWalletProvision(ctx, addr) {
+		t.Errorf("got no provision action for new provision")
+	}
+	checkState("started", types.SmartWalletStatePending, 1)
+	if keeper.StartSmartWalletProvision(ctx.WithBlockHeight(10+SmartWalletProvisionTimeoutBlocks-1), addr) {
+		t.Errorf("got provision action before timeout")
+	}
+
+	// Timed out attempts are retried until exhausted.
+	height := int64(10)
+	for attempt := uint32(2); attempt <= MaxSmartWalletProvisionAttempts; attempt++ {
+		height += SmartWalletProvisionTimeoutBlocks
+		if !keeper.StartSmartWalletProvision(ctx.WithBlockHeight(height), addr) {
+			t.Errorf("got no provision action for attempt %d", attempt)
+		}
+		checkState(fmt.Sprintf("attempt %d", attempt), types.SmartWalletStatePending, attempt)
+	}
+	height += SmartWalletProvisionTimeoutBlocks
+	if keeper.StartSmartWalletProvision(ctx.WithBlockHeight(height), addr) {
+		t.Errorf("got provision action after exhausting attempts")
+	}
+	checkState("exhausted", types.SmartWalletStateFailed, MaxSmartWalletProvisionAttempts)
+
+	// An exhausted provision is neither restarted automatically nor charged
+	// again (which would fail without a bank keeper).
+	if keeper.StartSmartWalletProvision(ctx.WithBlockHeight(height), addr) {
+		t.Errorf("got provision action for exhausted provision")
+	}
+	if err := keeper.ChargeForSmartWallet(ctx, addr); err != nil {
+		t.Errorf("got error %v charging exhausted provision", err)
+	}
+	checkState("still exhausted", types.SmartWalletStateFailed, MaxSmartWalletProvisionAttempts)
+
+	// An explicit provision starts counting attempts anew. Attempts acknowledged
+	// as failed are retried automatically, without being charged again, but
+	// only until the attempts are exhausted.
+	if !keeper.RestartSmartWalletProvision(ctx, addr) {
+		t.Errorf("got no provision action for explicit provision")
+	}
+	checkState("restarted", types.SmartWalletStatePending, 1)
+	for attempt := uint32(2); attempt <= MaxSmartWalletProvisionAttempts; attempt++ {
+		keeper.AcknowledgeSmartWalletProvision(ctx, addr, false, "no funds")
+		if provision, _ := keeper.GetSmartWalletProvision(ctx, addr); provision.State != types.SmartWalletStateFailed || provision.Error != "no funds" || !provision.FeeCharged {
+			t.Errorf("got provision %+v after failure acknowledgement", provision)
+		}
+		if err := keeper.ChargeForSmartWallet(ctx, addr); err != nil {
+			t.Errorf("got error %v charging failed provision", err)
+		}
+		if !keeper.StartSmartWalletProvision(ctx, addr) {
+			t.Errorf("got no provision action after failed attempt %d", attempt-1)
+		}
+		checkState(fmt.Sprintf("retried attempt %d", attempt), types.SmartWalletStatePending, attempt)
+	}
+	keeper.AcknowledgeSmartWalletProvision(ctx, addr, false, "no funds")
+	if keeper.StartSmartWalletProvision(ctx, addr) {
+		t.Errorf("got provision action after exhausting failed attempts")
+	}
+
+	// A late acknowledgement of success still completes the provision, which
+	// then cannot be restarted.
+	keeper.AcknowledgeSmartWalletProvision(ctx, addr, true, "")
+	checkState("acknowledged", types.SmartWalletStateProvisioned, MaxSmartWalletProvisionAttempts)
+	if keeper.RestartSmartWalletProvision(ctx, addr) {
+		t.Errorf("got explicit provision action for provisioned wallet")
+	}
+	if keeper.StartSmartWalletProvision(ctx, addr) {
+		t.Errorf("got provision action for provisioned wallet")
+	}
+
+	querier := Querier{keeper}
+	resp, err := querier.SmartWalletProvision(sdk.WrapSDKContext(ctx), &types.QuerySmartWalletProvisionRequest{Address: sdk.AccAddress([]byte("unknown"))})
+	if err != nil {
+		t.Fatalf("query: got error %v", err)
+	}
+	if resp.Provision.State != types.SmartWalletStateNone {
+		t.Errorf("query: got state %d for unknown wallet, want none", resp.Provision.State)
+	}
+	if provisions := keeper.GetSmartWalletProvisions(ctx); len(provisions) != 1 || !provisions[0].Address.Equals(addr) {
+		t.Errorf("got provisions %+v", provisions)
+	}
+}
+
+func TestProvisionAdmissibility(t *testing.T) {
+	ctx, keeper := makeParamsTestKeeper(t, nil)
+	addr := sdk.AccAddress([]byte("addr"))
+	walletMsg := &types.MsgProvision{Nickname: "n", Address: addr, Submitter: addr, PowerFlags: []string{types.PowerFlagSmartWallet}}
+	otherMsg := &types.MsgProvision{Nickname: "n", Address: addr, Submitter: addr, PowerFlags: []string{"REMOTE_WALLET"}}
+
+	check := func(label string, msg *types.MsgProvision, wantErr string) {
+		t.Helper()
+		err := msg.CheckAdmissibility(ctx, keeper)
+		if wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: got error %v", label, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%s: got error %v, want %q", label, err, wantErr)
+		}
+	}
+	check("none", walletMsg, "")
+	keeper.SetSmartWalletProvision(ctx, types.SmartWalletProvision{Address: addr, State: types.SmartWalletStateFailed})
+	check("failed", walletMsg, "")
+	keeper.RestartSmartWalletProvision(ctx, addr)
+	check("pending", walletMsg, "pending provisioning")
+	check("pending without smart wallet", otherMsg, "")
+	keeper.AcknowledgeSmartWalletProvision(ctx, addr, true, "")
+	check("provisioned", walletMsg, "already provisioned")
+	if err := walletMsg.CheckAdmissibility(ctx, nil); err == nil {
+		t.Errorf("got no error without a keeper")
+	}
+}
+
+func TestSmartWalletProvisionChargedOnce(t *testing.T) {
+	bank := newTestBankKeeper()
+	ctx, keeper := makeParamsTestKeeper(t, bank)
+	owner := sdk.AccAddress([]byte("owner"))
+	uist := mkcoin("uist")
+	initial := cns(uist(100_000_000))
+	bank.balances[owner.String()] = initial
+	charged := func() sdk.Coins {
+		return initial.Sub(bank.balances[owner.String()]...)
+	}
+
+	// admit charges for and starts a provision as admitting a message for an
+	// unprovisioned smart wallet does, returning whether an action was sent.
+	admit := func(height int64) bool {
+		t.Helper()
+		ctx := ctx.WithBlockHeight(height)
+		if err := keeper.ChargeForSmartWallet(ctx, owner); err != nil {
+			t.Fatalf("height %d: got error %v charging", height, err)
+		}
+		return keeper.StartSmartWalletProvision(ctx, owner)
+	}
+
+	if !admit(10) {
+		t.Fatalf("got no provision action for first admission")
+	}
+	fee := charged()
+	if fee.IsZero() {
+		t.Fatalf("got no fee charged for first admission")
+	}
+
+	// Neither failed nor timed out attempts are charged again, and they are
+	// bounded across both.
+	keeper.AcknowledgeSmartWalletProvision(ctx, owner, false, "no funds")
+	if !admit(11) {
+		t.Errorf("got no provision action after a failed attempt")
+	}
+	if admit(12) {
+		t.Errorf("got provision action while pending")
+	}
+	height := 11 + SmartWalletProvisionTimeoutBlocks
+	sent := 2
+	for admit(height) {
+		sent++
+		height += SmartWalletProvisionTimeoutBlocks
+	}
+	if sent != int(MaxSmartWalletProvisionAttempts) {
+		t.Errorf("got %d provision actions, want %d", sent, MaxSmartWalletProvisionAttempts)
+	}
+	for i := int64(1); i <= 3; i++ {
+		if admit(height + i*SmartWalletProvisionTimeoutBlocks) {
+			t.Errorf("got provision action after exhausting attempts")
+		}
+	}
+	if got := charged(); !got.IsEqual(fee) {
+		t.Errorf("got %s charged for exhausted provision, want %s", got, fee)
+	}
+
+	// A provision that succeeds is not charged again either.
+	keeper.RestartSmartWalletProvision(ctx, owner)
+	keeper.AcknowledgeSmartWalletProvision(ctx, owner, true, "")
+	if admit(height) {
+		t.Errorf("got provision action for provisioned wallet")
+	}
+	if got := charged(); !got.IsEqual(fee) {
+		t.Errorf("got %s charged for successful provision, want %s", got, fee)
+	}
+}
+
+// testBankKeeper tracks the balances of accounts and module accounts, and
+// records the coins sent from module accounts to accounts.
+type testBankKeeper struct {
+	bankkeeper.Keeper
+	balances map[string]sdk.Coins
+	modules  map[string]sdk.Coins
+	sent     map[string]sdk.Coins
+}
+
+func newTestBankKeeper() *testBankKeeper {
+	return &testBankKeeper{balances: map[string]sdk.Coins{}, modules: map[string]sdk.Coins{}, sent: map[string]sdk.Coins{}}
+}
+
+func (tbk *testBankKeeper) SpendableCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return tbk.balances[addr.String()]
+}
+
+func (tbk *testBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	balance, hasNeg := tbk.balances[senderAddr.String()].SafeSub(amt...)
+	if hasNeg {
+		return fmt.Errorf("insufficient funds: %s < %s", tbk.balances[senderAddr.String()], amt)
+	}
+	tbk.balances[senderAddr.String()] = balance
+	tbk.modules[recipientModule] = tbk.modules[recipientModule].Add(amt...)
+	return nil
+}
+
+func (tbk *testBankKeeper) debitModule(module string, amt sdk.Coins) error {
+	balance, hasNeg := tbk.modules[module].SafeSub(amt...)
+	if hasNeg {
+		return fmt.Errorf("insufficient module funds: %s < %s", tbk.modules[module], amt)
+	}
+	tbk.modules[module] = balance
+	return nil
+}
+
+func (tbk *testBankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := tbk.debitModule(senderModule, amt); err != nil {
+		return err
+	}
+	tbk.sent[recipientAddr.String()] = tbk.sent[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+func (tbk *testBankKeeper) SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	if err := tbk.debitModule(senderModule, amt); err != nil {
+		return err
+	}
+	tbk.modules[recipientModule] = tbk.modules[recipientModule].Add(amt...)
+	return nil
+}
+
+// makeParamsTestKeeper returns a context and a Keeper with default params and
+// the given bank keeper.
+func makeParamsTestKeeper(t *testing.T, bank bankkeeper.Keeper) (sdk.Context, Keeper) {
+	t.Helper()
+	ctx, keeper := makeUnsetParamsTestKeeper(t, bank)
+	keeper.SetParams(ctx, types.DefaultParams())
+	return ctx, keeper
+}
+
+// makeUnsetParamsTestKeeper returns a context and a Keeper with the given bank
+// keeper, whose params have not been set.
+func makeUnsetParamsTestKeeper(t *testing.T, bank bankkeeper.Keeper) (sdk.Context, Keeper) {
+	t.Helper()
+	encodingConfig := params.MakeEncodingConfig()
+	paramsTStoreKey := sdk.NewTransientStoreKey(paramstypes.TStoreKey)
+	paramsStoreKey := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	pk := paramskeeper.NewKeeper(encodingConfig.Marshaler, encodingConfig.Amino, paramsStoreKey, paramsTStoreKey)
+	vstorageStoreKey := storetypes.NewKVStoreKey(vstoragetypes.StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(swingsetStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsTStoreKey, storetypes.StoreTypeTransient, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 10}, false, log.NewNopLogger())
+	registry := codectypes.NewInterfaceRegistry()
+	types.RegisterInterfaces(registry)
+	keeper := NewKeeper(
+		codec.NewProtoCodec(registry), swingsetStoreKey, pk.Subspace(types.ModuleName),
+		nil, bank, vstoragekeeper.NewKeeper(vstorageStoreKey), authtypes.FeeCollectorName, nil,
+	)
+	return ctx, keeper
+}
+
+func TestMigrateParams(t *testing.T) {
+	ctx, keeper := makeUnsetParamsTestKeeper(t, nil)
+
+	// Set the params of a chain from before alternative fee unit prices.
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		if !bytes.Equal(pair.Key, types.ParamStoreKeyAlternativeFeeUnitPrices) {
+			keeper.paramSpace.Set(ctx, pair.Key, pair.Value)
+		}
+	}
+
+	if err := NewMigrator(keeper).MigrateParams(ctx); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if got := keeper.GetParams(ctx); got.String() != params.String() {
+		t.Errorf("got params %v, want %v", got, params)
+	}
+}
+
+func TestRefundFeeCharge(t *testing.T) {
+	vstorageStoreKey := storetypes.NewKVStoreKey(vstoragetypes.StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(swingsetStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 10}, false, log.NewNopLogger())
+	bank := newTestBankKeeper()
+	keeper := Keeper{
+		storeKey:         swingsetStoreKey,
+		cdc:              codec.NewProtoCodec(codectypes.NewInterfaceRegistry()),
+		bankKeeper:       bank,
+		vstorageKeeper:   vstoragekeeper.NewKeeper(vstorageStoreKey),
+		feeCollectorName: authtypes.FeeCollectorName,
+	}
+	payer := sdk.AccAddress([]byte("payer"))
+	txHash := "ABCDEF"
+	actionContext := types.ActionContext{BlockHeight: 10, TxHash: txHash, MsgIdx: 1}
+
+	// Charges outside of a transaction are not recorded.
+	keeper.recordFeeCharge(ctx, payer, cns(a(5)), sdkmath.NewUint(100))
+	if charges := keeper.GetFeeCharges(ctx, 10, txHash); len(charges) != 0 {
+		t.Errorf("got charges %+v outside of a transaction", charges)
+	}
+
+	txCtx := ctx.WithContext(context.WithValue(ctx.Context(), baseapp.TxHashContextKey, txHash))
+	// Of 100 beans, 70 are debited as coins and 30 left owing, then of 50
+	// more, 40 are debited (with the 30 owing) and 40 left owing.
+	keeper.SetBeansOwing(ctx, payer, sdkmath.NewUint(30))
+	keeper.recordFeeCharge(txCtx, payer, cns(a(5)), sdkmath.NewUint(100))
+	keeper.SetBeansOwing(ctx, payer, sdkmath.NewUint(40))
+	keeper.recordFeeCharge(txCtx, payer, cns(b(2)), sdkmath.NewUint(50))
+	// The coins debited by recorded charges are held in the refund pool.
+	bank.modules[types.RefundPoolName] = cns(a(5), b(2))
+
+	refundCtx := ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, cns(a(6)), sdkmath.ZeroUint()); err == nil {
+		t.Errorf("got no error refunding more coins than charged")
+	}
+	// Beans that were debited cannot also be cancelled, so refunding all the
+	// coins and beans charged would pay out more than the charge.
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, cns(a(5), b(2)), sdkmath.NewUint(150)); err == nil {
+		t.Errorf("got no error refunding both coins and debited beans")
+	}
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, nil, sdkmath.NewUint(41)); err == nil {
+		t.Errorf("got no error cancelling more beans than left owing")
+	}
+	otherContext := types.ActionContext{BlockHeight: 11, TxHash: txHash}
+	if err := keeper.RefundFeeCharge(refundCtx, otherContext, payer, cns(a(1)), sdkmath.ZeroUint()); err == nil {
+		t.Errorf("got no error refunding an unrecorded charge")
+	}
+
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, cns(a(3), b(2)), sdkmath.NewUint(40)); err != nil {
+		t.Fatalf("got error %v refunding", err)
+	}
+	if got := bank.sent[payer.String()]; !got.IsEqual(cns(a(3), b(2))) {
+		t.Errorf("got refunded %s, want %s", got, cns(a(3), b(2)))
+	}
+	if got := keeper.GetBeansOwing(ctx, payer); !got.IsZero() {
+		t.Errorf("got %s beans owing, want 0", got)
+	}
+	events := refundCtx.EventManager().Events()
+	if len(events) != 1 || events[0].Type != types.EventTypeRefund {
+		t.Errorf("got events %+v, want one %s", events, types.EventTypeRefund)
+	}
+
+	// The refund is bounded by what remains of the charge, and does not cancel
+	// beans owed for other charges.
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, cns(a(3)), sdkmath.ZeroUint()); err == nil {
+		t.Errorf("got no error refunding more coins than remain")
+	}
+	keeper.SetBeansOwing(ctx, payer, sdkmath.NewUint(90))
+	if err := keeper.RefundFeeCharge(refundCtx, actionContext, payer, nil, sdkmath.NewUint(1)); err == nil {
+		t.Errorf("got no error cancelling beans owed for other charges")
+	}
+	if got := keeper.GetBeansOwing(ctx, payer); !got.Equal(sdkmath.NewUint(90)) {
+		t.Errorf("got %s beans owing, want 90", got)
+	}
+
+	querier := Querier{keeper}
+	resp, err := querier.FeeCharges(sdk.WrapSDKContext(ctx), &types.QueryFeeChargesRequest{BlockHeight: 10, TxHash: "abcdef"})
+	if err != nil {
+		t.Fatalf("query: got error %v", err)
+	}
+	if len(resp.Charges) != 1 {
+		t.Fatalf("query: got charges %+v, want 1", resp.Charges)
+	}
+	charge := resp.Charges[0]
+	if !charge.Coins.IsEqual(cns(a(5), b(2))) || !charge.Beans.Equal(sdkmath.NewUint(150)) ||
+		!charge.RefundedCoins.IsEqual(cns(a(3), b(2))) || !charge.CancelledBeans.Equal(sdkmath.NewUint(40)) ||
+		!charge.OwingBeans.Equal(sdkmath.NewUint(40)) {
+		t.Errorf("query: got charge %+v", charge)
+	}
+	if _, err := querier.FeeCharges(sdk.WrapSDKContext(ctx), &types.QueryFeeChargesRequest{BlockHeight: 10}); grpcStatus.Code(err) != grpcCodes.InvalidArgument {
+		t.Errorf("query: got error %v for empty tx hash, want InvalidArgument", err)
+	}
+
+	// Charges are pruned once they can no longer be refunded.
+	keeper.PruneFeeCharges(ctx.WithBlockHeight(10 + RefundWindowBlocks))
+	if charges := keeper.GetFeeCharges(ctx, 10, txHash); len(charges) != 1 {
+		t.Errorf("got charges %+v pruned within the refund window", charges)
+	}
+	keeper.PruneFeeCharges(ctx.WithBlockHeight(11 + RefundWindowBlocks))
+	if charges := keeper.GetFeeCharges(ctx, 10, txHash); len(charges) != 0 {
+		t.Errorf("got charges %+v after the refund window", charges)
+	}
+	// What was not refunded is forwarded to the fee collector.
+	if got := bank.modules[authtypes.FeeCollectorName]; !got.IsEqual(cns(a(2))) {
+		t.Errorf("got %s forwarded to the fee collector, want %s", got, cns(a(2)))
+	}
+	if got := bank.modules[types.RefundPoolName]; !got.IsZero() {
+		t.Errorf("got %s left in the refund pool, want none", got)
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	ctx, keeper := makeParamsTestKeeper(t, nil)
+	querier := Querier{keeper}
+	owner := sdk.AccAddress([]byte("owner"))
+	uist := mkcoin("uist")
+
+	// The beans for a 1-byte action.
+	actionBeans := types.DefaultBeansPerInboundTx.
+		Add(types.DefaultBeansPerMessage).
+		Add(types.DefaultBeansPerMessageByte)
+
+	estimate := func(msg sdk.Msg) (*types.QueryEstimateFeeResponse, error) {
+		t.Helper()
+		anyMsg, err := codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return querier.EstimateFee(sdk.WrapSDKContext(ctx), &types.QueryEstimateFeeRequest{Msg: anyMsg})
+	}
+
+	type testCase struct {
+		name      string
+		setup     func()
+		msg       sdk.Msg
+		wantBeans sdkmath.Uint
+		wantFee   sdk.Coins
+		wantOwing sdkmath.Uint
+	}
+	testCases := []testCase{
+		{
+			name:      "unprovisioned wallet action",
+			setup:     func() {},
+			msg:       types.NewMsgWalletAction(owner, "x"),
+			wantBeans: types.DefaultBeansPerSmartWalletProvision.Add(actionBeans),
+			wantFee:   cns(uist(1_000_000)),
+			wantOwing: actionBeans,
+		},
+		{
+			name: "provision already charged",
+			setup: func() {
+				keeper.SetSmartWalletProvision(ctx, types.SmartWalletProvision{Address: owner, State: types.SmartWalletStateNone, FeeCharged: true})
+			},
+			msg:       types.NewMsgWalletAction(owner, "x"),
+			wantBeans: actionBeans,
+			wantFee:   cns(),
+			wantOwing: actionBeans,
+		},
+		{
+			name: "owing reaches the minimum debit",
+			setup: func() {
+				keeper.SetBeansOwing(ctx, owner, types.DefaultBeansPerMinFeeDebit.Sub(sdkmath.NewUint(1)))
+			},
+			msg:       types.NewMsgWalletSpendAction(owner, "x"),
+			wantBeans: actionBeans,
+			wantFee:   cns(uist(200_000)),
+			wantOwing: actionBeans.Sub(sdkmath.NewUint(1)),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+			resp, err := estimate(tc.msg)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if !resp.Payer.Equals(owner) {
+				t.Errorf("got payer %s, want %s", resp.Payer, owner)
+			}
+			if !resp.Beans.Equal(tc.wantBeans) {
+				t.Errorf("got beans %s, want %s", resp.Beans, tc.wantBeans)
+			}
+			if !resp.Fee.IsEqual(tc.wantFee) {
+				t.Errorf("got fee %s, want %s", resp.Fee, tc.wantFee)
+			}
+			if !resp.BeansOwing.Equal(tc.wantOwing) {
+				t.Errorf("got beans owing %s, want %s", resp.BeansOwing, tc.wantOwing)
+			}
+		})
+	}
+
+	// Estimating does not charge.
+	owingResp, err := querier.BeansOwing(sdk.WrapSDKContext(ctx), &types.QueryBeansOwingRequest{Address: owner})
+	if err != nil {
+		t.Fatalf("beans owing: got error %v", err)
+	}
+	if want := types.DefaultBeansPerMinFeeDebit.Sub(sdkmath.NewUint(1)); !owingResp.BeansOwing.Equal(want) {
+		t.Errorf("beans owing: got %s, want %s", owingResp.BeansOwing, want)
+	}
+
+	if _, err := estimate(types.NewMsgProvision("nick", owner, nil, owner)); grpcStatus.Code(err) != grpcCodes.InvalidArgument {
+		t.Errorf("got error %v for a message without an admission fee, want InvalidArgument", err)
+	}
+	if _, err := querier.EstimateFee(sdk.WrapSDKContext(ctx), &types.QueryEstimateFeeRequest{}); grpcStatus.Code(err) != grpcCodes.InvalidArgument {
+		t.Errorf("got error %v for an empty msg, want InvalidArgument", err)
+	}
+}
+
+func TestEstimateFeeMatchesAdmission(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner"))
+	uist := mkcoin("uist")
+	initial := cns(uist(100_000_000))
+	bundle := `{"moduleFormat":"endoZipBase64","endoZipBase64":"` + strings.Repeat("x", 1000) + `"}`
+
+	type testCase struct {
+		name  string
+		setup func(ctx sdk.Context, keeper Keeper)
+		msg   vm.ControllerAdmissionMsg
+	}
+	testCases := []testCase{
+		{name: "deliver inbound",
+			msg: types.NewMsgDeliverInbound(&types.Messages{Nums: []uint64{1, 2}, Messages: []string{"a", "bc"}}, owner)},
+		{name: "unprovisioned wallet action",
+			msg: types.NewMsgWalletAction(owner, "x")},
+		{name: "provisioned wallet action",
+			setup: func(ctx sdk.Context, keeper Keeper) {
+				keeper.AcknowledgeSmartWalletProvision(ctx, owner, true, "")
+			},
+			msg: types.NewMsgWalletAction(owner, "x")},
+		{name: "wallet spend action with provision already charged",
+			setup: func(ctx sdk.Context, keeper Keeper) {
+				keeper.SetSmartWalletProvision(ctx, types.SmartWalletProvision{Address: owner, State: types.SmartWalletStateFailed, FeeCharged: true})
+			},
+			msg: types.NewMsgWalletSpendAction(owner, "x")},
+		{name: "wallet spend action with beans owing",
+			setup: func(ctx sdk.Context, keeper Keeper) {
+				keeper.AcknowledgeSmartWalletProvision(ctx, owner, true, "")
+				keeper.SetBeansOwing(ctx, owner, types.DefaultBeansPerMinFeeDebit.Sub(sdkmath.NewUint(1)))
+			},
+			msg: types.NewMsgWalletSpendAction(owner, "x")},
+		{name: "install bundle",
+			msg: types.NewMsgInstallBundle(bundle, owner)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bank := newTestBankKeeper()
+			bank.balances[owner.String()] = initial
+			ctx, keeper := makeParamsTestKeeper(t, bank)
+			if tc.setup != nil {
+				tc.setup(ctx, keeper)
+			}
+
+			payer, _, fee, owing, err := keeper.EstimateAdmissionFee(ctx, tc.msg)
+			if err != nil {
+				t.Fatalf("estimate: got error %v", err)
+			}
+			if err := tc.msg.CheckAdmissibility(ctx, keeper); err != nil {
+				t.Fatalf("admission: got error %v", err)
+			}
+			if !payer.Equals(owner) {
+				t.Errorf("got payer %s, want %s", payer, owner)
+			}
+			if charged := initial.Sub(bank.balances[owner.String()]...); !charged.IsEqual(fee) {
+				t.Errorf("got %s charged, estimated %s", charged, fee)
+			}
+			if got := keeper.GetBeansOwing(ctx, owner); !got.Equal(owing) {
+				t.Errorf("got %s beans owing, estimated %s", got, owing)
+			}
+		})
+	}
+}
+
+func TestChargeBeansAlternativeFeeUnitPrices(t *testing.T) {
+	bank := newTestBankKeeper()
+	ctx, keeper := makeParamsTestKeeper(t, bank)
+	params := keeper.GetParams(ctx)
+	params.AlternativeFeeUnitPrices = []sdk.Coin{sdk.NewInt64Coin("ubld", 5_000_000), sdk.NewInt64Coin("uusdc", 1_000_000)}
+	keeper.SetParams(ctx, params)
+	payer := sdk.AccAddress([]byte("payer"))
+	uist, ubld, uusdc := mkcoin("uist"), mkcoin("ubld"), mkcoin("uusdc")
+
+	// Each charge debits the minimum fee debit, $0.20.
+	for _, tt := range []struct {
+		name         string
+		balance      sdk.Coins
+		wantBalance  sdk.Coins
+		wantErr      bool
+		wantEventFor sdk.Coins
+	}{
+		{
+			name:         "fee_unit_price",
+			balance:      cns(uist(200_000), ubld(1_000_000)),
+			wantBalance:  cns(ubld(1_000_000)),
+			wantEventFor: cns(uist(1_000_000)),
+		},
+		{
+			name:         "first_alternative",
+			balance:      cns(uist(199_999), ubld(1_000_000), uusdc(200_000)),
+			wantBalance:  cns(uist(199_999), uusdc(200_000)),
+			wantEventFor: cns(ubld(5_000_000)),
+		},
+		{
+			name:         "second_alternative",
+			balance:      cns(ubld(999_999), uusdc(200_000)),
+			wantBalance:  cns(ubld(999_999)),
+			wantEventFor: cns(uusdc(1_000_000)),
+		},
+		{
+			name:        "insufficient",
+			balance:     cns(ubld(1), uusdc(1)),
+			wantBalance: cns(ubld(1), uusdc(1)),
+			wantErr:     true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			bank.balances[payer.String()] = tt.balance
+			keeper.SetBeansOwing(ctx, payer, sdkmath.ZeroUint())
+			chargeCtx := ctx.WithEventManager(sdk.NewEventManager())
+			err := keeper.ChargeBeans(chargeCtx, payer, types.DefaultBeansPerMinFeeDebit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got := bank.balances[payer.String()]; !got.IsEqual(tt.wantBalance) {
+				t.Errorf("got balance %s, want %s", got, tt.wantBalance)
+			}
+			if tt.wantErr {
+				return
+			}
+			events := chargeCtx.EventManager().Events()
+			if len(events) != 1 || events[0].Type != types.EventTypeFeeDebit {
+				t.Fatalf("got events %+v, want one %s", events, types.EventTypeFeeDebit)
+			}
+			for _, attr := range events[0].Attributes {
+				if string(attr.Key) == types.AttributeKeyFeeUnitPrice && string(attr.Value) != tt.wantEventFor.String() {
+					t.Errorf("got fee unit price %s, want %s", attr.Value, tt.wantEventFor)
+				}
+			}
+		})
+	}
+}
+
+func TestChargeBeansFeeRecipient(t *testing.T) {
+	bank := newTestBankKeeper()
+	ctx, keeper := makeParamsTestKeeper(t, bank)
+	payer := sdk.AccAddress([]byte("payer"))
+	uist := mkcoin("uist")
+	bank.balances[payer.String()] = cns(uist(400_000))
+
+	// Fees charged outside of a transaction cannot be refunded, so they go
+	// straight to the fee collector.
+	if err := keeper.ChargeBeans(ctx, payer, types.DefaultBeansPerMinFeeDebit); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if got := bank.modules[authtypes.FeeCollectorName]; !got.IsEqual(cns(uist(200_000))) {
+		t.Errorf("got %s collected, want %s", got, cns(uist(200_000)))
+	}
+
+	// Fees charged by a transaction are held in the refund pool until they can
+	// no longer be refunded.
+	txCtx := ctx.WithContext(context.WithValue(ctx.Context(), baseapp.TxHashContextKey, "ABCDEF"))
+	if err := keeper.ChargeBeans(txCtx, payer, types.DefaultBeansPerMinFeeDebit); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if got := bank.modules[types.RefundPoolName]; !got.IsEqual(cns(uist(200_000))) {
+		t.Errorf("got %s held for refund, want %s", got, cns(uist(200_000)))
+	}
+	keeper.PruneFeeCharges(ctx.WithBlockHeight(11 + RefundWindowBlocks))
+	if got := bank.modules[authtypes.FeeCollectorName]; !got.IsEqual(cns(uist(400_000))) {
+		t.Errorf("got %s collected after pruning, want %s", got, cns(uist(400_000)))
+	}
+}
@@ -120,18 +120,21 @@ type provisionAction struct {
 	*vm.ActionHeader `actionType:"PLEASE_PROVISION"`
 	*types.MsgProvision
 	AutoProvision bool `json:"autoProvision"`
+	// Fee is what the submitter was charged for the provision, which the
+	// controller may refund if it fails.
+	Fee sdk.Coins `json:"fee,omitempty"`
 }
 
 // provisionIfNeeded generates a provision action if no smart wallet is already
-// provisioned for the account. This assumes that all messages for
+// provisioned or pending for the account. This assumes that all messages for
 // non-provisioned smart wallets allowed by the admission AnteHandler should
 // auto-provision the smart wallet.
 func (keeper msgServer) provisionIfNeeded(ctx sdk.Context, owner sdk.AccAddress) error {
 	// We need to generate a provision action until the smart wallet has
 	// been fully provisioned by the controller. This is because a provision is
-	// not guaranteed to succeed (e.g. lack of provision pool funds)
-	walletState := keeper.GetSmartWalletState(ctx, owner)
-	if walletState == types.SmartWalletStateProvisioned {
+	// not guaranteed to succeed (e.g. lack of provision pool funds), so a
+	// pending provision that is not acknowledged in time is retried.
+	if !keeper.StartSmartWalletProvision(ctx, owner) {
 		return nil
 	}
 
@@ -158,13 +161,23 @@ func (keeper msgServer) provisionIfNeeded(ctx sdk.Context, owner sdk.AccAddress)
 func (keeper msgServer) Provision(goCtx context.Context, msg *types.MsgProvision) (*types.MsgProvisionResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	err := keeper.ChargeForProvisioning(ctx, msg.Submitter, msg.Address, msg.PowerFlags)
+	fee, err := keeper.ChargeForProvisioning(ctx, msg.Submitter, msg.Address, msg.PowerFlags)
 	if err != nil {
 		return nil, err
 	}
 
+	// An explicit smart wallet provision pays for itself, and restarts any
+	// automatic one that has failed (admission rejects one that is pending).
+	for _, powerFlag := range msg.PowerFlags {
+		if powerFlag == types.PowerFlagSmartWallet {
+			keeper.RestartSmartWalletProvision(ctx, msg.Address)
+			break
+		}
+	}
+
 	action := provisionAction{
 		MsgProvision: msg,
+		Fee:          fee,
 	}
 
 	// Create the account, if it doesn't already exist.
@@ -0,0 +1,204 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
+)
+
+// The fees that a transaction charges each payer are recorded for
+// RefundWindowBlocks, during which the controller may refund some or all of
+// them (e.g., when the action for which they were charged is cancelled).
+// The coins debited by a recorded charge are held in the refund pool for as
+// long as it can be refunded, and whatever was not refunded is then forwarded
+// to the fee collector when the charge is pruned.
+// A refund is bounded by what remains of the recorded charge, so that the
+// controller cannot pay out more than the payer was charged: coins by the
+// coins that were debited, and beans by those of the charge that were left
+// owing rather than debited, which are disjoint parts of the charge.
+const (
+	// RefundWindowBlocks is how many blocks a fee charge may be refunded for.
+	RefundWindowBlocks int64 = 10000
+)
+
+func (k Keeper) feeChargeStore(ctx sdk.Context) sdk.KVStore {
+	return prefix.NewStore(ctx.KVStore(k.storeKey), []byte(feeChargeKeyPrefix))
+}
+
+// feeChargeTxKey is the key prefix of the fee charges of a transaction:
+// the big-endian block height followed by the (upper-case hex) transaction
+// hash and a NUL separator.
+func feeChargeTxKey(blockHeight int64, txHash string) []byte {
+	key := make([]byte, 8, 8+len(txHash)+1)
+	binary.BigEndian.PutUint64(key, uint64(blockHeight))
+	key = append(key, strings.ToUpper(txHash)...)
+	return append(key, 0)
+}
+
+func feeChargeKey(blockHeight int64, txHash string, payer sdk.AccAddress) []byte {
+	return append(feeChargeTxKey(blockHeight, txHash), payer...)
+}
+
+// GetFeeCharge returns the fee charged to a payer by a transaction, and
+// whether there is one.
+func (k Keeper) GetFeeCharge(ctx sdk.Context, blockHeight int64, txHash string, payer sdk.AccAddress) (types.FeeCharge, bool) {
+	bz := k.feeChargeStore(ctx).Get(feeChargeKey(blockHeight, txHash, payer))
+	if bz == nil {
+		return types.FeeCharge{
+			BlockHeight:    blockHeight,
+			TxHash:         strings.ToUpper(txHash),
+			Payer:          payer,
+			Coins:          sdk.NewCoins(),
+			Beans:          sdkmath.ZeroUint(),
+			RefundedCoins:  sdk.NewCoins(),
+			CancelledBeans: sdkmath.ZeroUint(),
+			OwingBeans:     sdkmath.ZeroUint(),
+		}, false
+	}
+	charge := types.FeeCharge{}
+	k.cdc.MustUnmarshal(bz, &charge)
+	return charge, true
+}
+
+// SetFeeCharge records the fee charged to a payer by a transaction.
+func (k Keeper) SetFeeCharge(ctx sdk.Context, charge types.FeeCharge) {
+	bz := k.cdc.MustMarshal(&charge)
+	k.feeChargeStore(ctx).Set(feeChargeKey(charge.BlockHeight, charge.TxHash, charge.Payer), bz)
+}
+
+// GetFeeCharges returns the fees charged by a transaction, ordered by payer.
+func (k Keeper) GetFeeCharges(ctx sdk.Context, blockHeight int64, txHash string) []types.FeeCharge {
+	charges := []types.FeeCharge{}
+	iterator := sdk.KVStorePrefixIterator(k.feeChargeStore(ctx), feeChargeTxKey(blockHeight, txHash))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		charge := types.FeeCharge{}
+		k.cdc.MustUnmarshal(iterator.Value(), &charge)
+		charges = append(charges, charge)
+	}
+	return charges
+}
+
+// contextTxHash returns the hash of the transaction being processed, if any.
+func contextTxHash(ctx sdk.Context) (string, bool) {
+	hash, ok := ctx.Context().Value(baseapp.TxHashContextKey).(string)
+	return hash, ok
+}
+
+// feeRecipient returns the module account that collects the fees charged in
+// the context: the refund pool for those charged by a transaction, which are
+// recorded so that they may be refunded, or else the fee collector.
+func (k Keeper) feeRecipient(ctx sdk.Context) string {
+	if _, ok := contextTxHash(ctx); ok {
+		return types.RefundPoolName
+	}
+	return k.feeCollectorName
+}
+
+// recordFeeCharge adds coins and beans to the fee charged to a payer by the
+// current transaction, after the beans owing by the payer have been updated.
+// Charges outside of a transaction are not recorded, and so cannot be
+// refunded.
+func (k Keeper) recordFeeCharge(ctx sdk.Context, payer sdk.AccAddress, coins sdk.Coins, beans sdkmath.Uint) {
+	if coins.IsZero() && beans.IsZero() {
+		return
+	}
+	hash, ok := contextTxHash(ctx)
+	if !ok {
+		return
+	}
+	charge, _ := k.GetFeeCharge(ctx, ctx.BlockHeight(), hash, payer)
+	charge.Coins = charge.Coins.Add(coins...)
+	charge.Beans = charge.Beans.Add(beans)
+	// Debits pay the oldest beans owing first, so the beans still owing are
+	// the most recently charged ones, which include those of this transaction.
+	charge.OwingBeans = sdkmath.MinUint(charge.OwingBeans.Add(beans), k.GetBeansOwing(ctx, payer))
+	k.SetFeeCharge(ctx, charge)
+}
+
+// PruneFeeCharges removes the fee charges that can no longer be refunded, and
+// forwards the coins that they left in the refund pool to the fee collector.
+func (k Keeper) PruneFeeCharges(ctx sdk.Context) {
+	endHeight := ctx.BlockHeight() - RefundWindowBlocks
+	if endHeight <= 0 {
+		return
+	}
+	end := make([]byte, 8)
+	binary.BigEndian.PutUint64(end, uint64(endHeight))
+
+	store := k.feeChargeStore(ctx)
+	iterator := store.Iterator(nil, end)
+	var keys [][]byte
+	unrefunded := sdk.NewCoins()
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		charge := types.FeeCharge{}
+		k.cdc.MustUnmarshal(iterator.Value(), &charge)
+		unrefunded = unrefunded.Add(charge.Coins.Sub(charge.RefundedCoins...)...)
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	if !unrefunded.IsZero() {
+		err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.RefundPoolName, k.feeCollectorName, unrefunded)
+		if err != nil {
+			// Should never happen, since the pool holds every unrefunded charge.
+			ctx.Logger().Error("failed to forward unrefunded fees", "fees", unrefunded, "error", err)
+		}
+	}
+}
+
+// RefundFeeCharge refunds part of the fee charged to a payer by the
+// transaction of an action context, paying coins from the refund pool and
+// cancelling beans from those the payer owes. Coins may not exceed what
+// remains of those debited by the charge, and beans may not exceed what
+// remains of those the charge left owing, so that together they never exceed
+// the charge. Beans that the payer no longer owes (because a later charge
+// debited them) are not cancelled, and remain refundable by cancelling beans
+// owed later.
+func (k Keeper) RefundFeeCharge(ctx sdk.Context, actionContext types.ActionContext, payer sdk.AccAddress, amount sdk.Coins, beans sdkmath.Uint) error {
+	charge, found := k.GetFeeCharge(ctx, actionContext.BlockHeight, actionContext.TxHash, payer)
+	if !found || ctx.BlockHeight()-charge.BlockHeight >= RefundWindowBlocks {
+		return fmt.Errorf("no refundable fee charged to %s by transaction %s at height %d",
+			payer, actionContext.TxHash, actionContext.BlockHeight)
+	}
+
+	refundableCoins, hasNeg := charge.Coins.SafeSub(charge.RefundedCoins...)
+	if hasNeg || !amount.IsAllLTE(refundableCoins) {
+		return fmt.Errorf("refund %s exceeds refundable %s", amount, refundableCoins)
+	}
+	refundableBeans := charge.OwingBeans.Sub(charge.CancelledBeans)
+	if beans.GT(refundableBeans) {
+		return fmt.Errorf("refund of %s beans exceeds refundable %s", beans, refundableBeans)
+	}
+
+	if !amount.IsZero() {
+		err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.RefundPoolName, payer, amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	owing := k.GetBeansOwing(ctx, payer)
+	cancelled := sdkmath.MinUint(owing, beans)
+	if !cancelled.IsZero() {
+		k.SetBeansOwing(ctx, payer, owing.Sub(cancelled))
+	}
+
+	charge.RefundedCoins = charge.RefundedCoins.Add(amount...)
+	charge.CancelledBeans = charge.CancelledBeans.Add(cancelled)
+	k.SetFeeCharge(ctx, charge)
+
+	ctx.EventManager().EmitEvent(types.NewRefundEvent(payer, amount, cancelled, actionContext))
+	return nil
+}
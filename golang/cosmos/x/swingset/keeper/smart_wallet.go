@@ -0,0 +1,145 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
+)
+
+// A smart wallet provision is recorded from the time its fee is charged or a
+// provision action is sent to the controller, and moves between states as
+// follows:
+//   - none (fee charged) or failed -> pending, when a provision action is sent
+//     automatically, while fewer than MaxSmartWalletProvisionAttempts have
+//     been made
+//   - pending -> pending, when a provision action is resent after
+//     SmartWalletProvisionTimeoutBlocks without acknowledgement, up to
+//     MaxSmartWalletProvisionAttempts times
+//   - pending -> failed, when the attempts are exhausted
+//   - none or failed -> pending, when an explicit MsgProvision pays for a new
+//     provision, which starts counting attempts anew (MsgProvision is not
+//     admitted for a provision that is pending or provisioned)
+//   - any -> provisioned or failed, when the controller acknowledges (see
+//     bridgeProvisioner in packages/vats/src/core/chain-behaviors.js)
+//   - any -> provisioned, when the controller has published the wallet's
+//     storage node (which is all that GetSmartWalletState needs, so a
+//     provision succeeds even without acknowledgement)
+//
+// The fee is charged exactly once for each provision, which covers all of its
+// attempts whether they time out or are acknowledged as failed. Once they are
+// exhausted, the wallet is no longer provisioned automatically (or charged
+// for it) until an explicit MsgProvision pays for a new provision.
+const (
+	// SmartWalletProvisionTimeoutBlocks is how many blocks a pending provision
+	// waits for acknowledgement before it is attempted again.
+	SmartWalletProvisionTimeoutBlocks int64 = 100
+	// MaxSmartWalletProvisionAttempts is how many times a pending provision is
+	// attempted before it fails.
+	MaxSmartWalletProvisionAttempts uint32 = 3
+)
+
+func (k Keeper) smartWalletProvisionStore(ctx sdk.Context) sdk.KVStore {
+	return prefix.NewStore(ctx.KVStore(k.storeKey), []byte(smartWalletProvisionKeyPrefix))
+}
+
+// GetSmartWalletProvision returns the recorded provision of the smart wallet
+// for an address, and whether there is one.
+func (k Keeper) GetSmartWalletProvision(ctx sdk.Context, addr sdk.AccAddress) (types.SmartWalletProvision, bool) {
+	bz := k.smartWalletProvisionStore(ctx).Get(addr)
+	if bz == nil {
+		return types.SmartWalletProvision{Address: addr, State: types.SmartWalletStateNone}, false
+	}
+	provision := types.SmartWalletProvision{}
+	k.cdc.MustUnmarshal(bz, &provision)
+	return provision, true
+}
+
+// SetSmartWalletProvision records the provision of the smart wallet for its
+// address.
+func (k Keeper) SetSmartWalletProvision(ctx sdk.Context, provision types.SmartWalletProvision) {
+	bz := k.cdc.MustMarshal(&provision)
+	k.smartWalletProvisionStore(ctx).Set(provision.Address, bz)
+}
+
+// GetSmartWalletProvisions returns every recorded smart wallet provision.
+func (k Keeper) GetSmartWalletProvisions(ctx sdk.Context) []types.SmartWalletProvision {
+	provisions := []types.SmartWalletProvision{}
+	iterator := k.smartWalletProvisionStore(ctx).Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		provision := types.SmartWalletProvision{}
+		k.cdc.MustUnmarshal(iterator.Value(), &provision)
+		provisions = append(provisions, provision)
+	}
+	return provisions
+}
+
+// StartSmartWalletProvision records that a provision action for the smart
+// wallet of an address is to be sent to the controller automatically, and
+// returns whether one should be sent: not if the wallet is provisioned, a
+// previous attempt is still pending, or the attempts are exhausted. An attempt
+// that has timed out is retried unless the attempts are exhausted, in which
+// case the provision fails.
+func (k Keeper) StartSmartWalletProvision(ctx sdk.Context, addr sdk.AccAddress) bool {
+	provision, _ := k.GetSmartWalletProvision(ctx, addr)
+	switch k.GetSmartWalletState(ctx, addr) {
+	case types.SmartWalletStateProvisioned:
+		return false
+	case types.SmartWalletStatePending:
+		if ctx.BlockHeight()-provision.PendingSinceHeight < SmartWalletProvisionTimeoutBlocks {
+			return false
+		}
+		if provision.Attempts >= MaxSmartWalletProvisionAttempts {
+			provision.State = types.SmartWalletStateFailed
+			provision.Error = fmt.Sprintf("not acknowledged after %d attempts", provision.Attempts)
+			k.SetSmartWalletProvision(ctx, provision)
+			return false
+		}
+	default:
+		// Attempts are kept across failures so that they remain bounded.
+		if provision.Attempts >= MaxSmartWalletProvisionAttempts {
+			return false
+		}
+		provision.State = types.SmartWalletStatePending
+		provision.Error = ""
+	}
+	provision.Attempts++
+	provision.PendingSinceHeight = ctx.BlockHeight()
+	k.SetSmartWalletProvision(ctx, provision)
+	return true
+}
+
+// RestartSmartWalletProvision records that an explicitly paid provision
+// action for the smart wallet of an address is to be sent to the controller,
+// starting a new provision with its own attempts, and returns false if the
+// wallet is already provisioned.
+func (k Keeper) RestartSmartWalletProvision(ctx sdk.Context, addr sdk.AccAddress) bool {
+	if k.GetSmartWalletState(ctx, addr) == types.SmartWalletStateProvisioned {
+		return false
+	}
+	provision, _ := k.GetSmartWalletProvision(ctx, addr)
+	provision.State = types.SmartWalletStatePending
+	provision.PendingSinceHeight = ctx.BlockHeight()
+	provision.Attempts = 1
+	provision.FeeCharged = true
+	provision.Error = ""
+	k.SetSmartWalletProvision(ctx, provision)
+	return true
+}
+
+// AcknowledgeSmartWalletProvision records the outcome of provisioning the
+// smart wallet of an address, as reported by the controller.
+func (k Keeper) AcknowledgeSmartWalletProvision(ctx sdk.Context, addr sdk.AccAddress, succeeded bool, reason string) {
+	provision, _ := k.GetSmartWalletProvision(ctx, addr)
+	if succeeded {
+		provision.State = types.SmartWalletStateProvisioned
+		provision.Error = ""
+	} else {
+		provision.State = types.SmartWalletStateFailed
+		provision.Error = reason
+	}
+	k.SetSmartWalletProvision(ctx, provision)
+}
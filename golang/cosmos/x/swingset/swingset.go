@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io"
 
+	sdkmath "cosmossdk.io/math"
+
 	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/vm"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/swingset/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -23,9 +26,28 @@ type swingsetMessage struct {
 }
 
 const (
-	SwingStoreUpdateExportData = "swingStoreUpdateExportData"
+	SwingStoreUpdateExportData      = "swingStoreUpdateExportData"
+	SmartWalletProvisionAcknowledge = "smartWalletProvisionAcknowledge"
+	Refund                          = "refund"
 )
 
+// smartWalletProvisionAck is the argument of a SmartWalletProvisionAcknowledge
+// message, reporting the outcome of provisioning a smart wallet.
+type smartWalletProvisionAck struct {
+	Address   string `json:"address"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error"`
+}
+
+// refundRequest is the argument of a Refund message, refunding part of the fee
+// charged to an address by the transaction of an action context.
+type refundRequest struct {
+	Context types.ActionContext `json:"context"`
+	Address string              `json:"address"`
+	Amount  sdk.Coins           `json:"amount"`
+	Beans   sdkmath.Uint        `json:"beans"`
+}
+
 // NewPortHandler returns a port handler for a swingset Keeper.
 func NewPortHandler(k Keeper) vm.PortHandler {
 	return portHandler{keeper: k}
@@ -46,6 +68,12 @@ func (ph portHandler) Receive(cctx context.Context, str string) (string, error)
 	case SwingStoreUpdateExportData:
 		return ph.handleSwingStoreUpdateExportData(ctx, msg.Args)
 
+	case SmartWalletProvisionAcknowledge:
+		return ph.handleSmartWalletProvisionAcknowledge(ctx, msg.Args)
+
+	case Refund:
+		return ph.handleRefund(ctx, msg.Args)
+
 	default:
 		return "", fmt.Errorf("unrecognized swingset method %s", msg.Method)
 	}
@@ -71,3 +99,41 @@ func (ph portHandler) handleSwingStoreUpdateExportData(ctx sdk.Context, entries
 		}
 	}
 }
+
+func (ph portHandler) handleSmartWalletProvisionAcknowledge(ctx sdk.Context, args []json.RawMessage) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s requires 1 argument, got %d", SmartWalletProvisionAcknowledge, len(args))
+	}
+	var ack smartWalletProvisionAck
+	if err := json.Unmarshal(args[0], &ack); err != nil {
+		return "", err
+	}
+	addr, err := sdk.AccAddressFromBech32(ack.Address)
+	if err != nil {
+		return "", err
+	}
+	ph.keeper.AcknowledgeSmartWalletProvision(ctx, addr, ack.Succeeded, ack.Error)
+	return "true", nil
+}
+
+func (ph portHandler) handleRefund(ctx sdk.Context, args []json.RawMessage) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s requires 1 argument, got %d", Refund, len(args))
+	}
+	req := refundRequest{Beans: sdkmath.ZeroUint()}
+	if err := json.Unmarshal(args[0], &req); err != nil {
+		return "", err
+	}
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return "", err
+	}
+	if err := req.Amount.Validate(); err != nil {
+		return "", err
+	}
+	err = ph.keeper.RefundFeeCharge(ctx, req.Context, addr, req.Amount, req.Beans)
+	if err != nil {
+		return "", err
+	}
+	return "true", nil
+}
@@ -66,6 +66,9 @@ var (
 	DefaultQueueMax = []QueueSize{
 		NewQueueSize(QueueInbound, DefaultInboundQueueMax),
 	}
+
+	// No alternative fee denoms are accepted unless governance adds them.
+	DefaultAlternativeFeeUnitPrices = []sdk.Coin{}
 )
 
 // move DefaultBeansPerUnit to a function to allow for boot overriding of the Default params
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"strconv"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// swingset module event types
+const (
+	EventTypeRefund   = "swingset_refund"
+	EventTypeFeeDebit = "swingset_fee_debit"
+
+	AttributeKeyPayer        = "payer"
+	AttributeKeyAmount       = "amount"
+	AttributeKeyBeans        = "beans"
+	AttributeKeyBlockHeight  = "block_height"
+	AttributeKeyTxHash       = "tx_hash"
+	AttributeKeyMsgIdx       = "msg_idx"
+	AttributeKeyFeeUnitPrice = "fee_unit_price"
+)
+
+// NewRefundEvent constructs a new event for a refund of a fee charged by the
+// transaction of the action context.
+func NewRefundEvent(payer sdk.AccAddress, amount sdk.Coins, beans sdkmath.Uint, actionContext ActionContext) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeRefund,
+		sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		sdk.NewAttribute(AttributeKeyPayer, payer.String()),
+		sdk.NewAttribute(AttributeKeyAmount, amount.String()),
+		sdk.NewAttribute(AttributeKeyBeans, beans.String()),
+		sdk.NewAttribute(AttributeKeyBlockHeight, strconv.FormatInt(actionContext.BlockHeight, 10)),
+		sdk.NewAttribute(AttributeKeyTxHash, actionContext.TxHash),
+		sdk.NewAttribute(AttributeKeyMsgIdx, strconv.Itoa(actionContext.MsgIdx)),
+	)
+}
+
+// NewFeeDebitEvent constructs a new event for debiting beans from a payer,
+// recording the fee unit price (and thus the denom) chosen for the debit.
+func NewFeeDebitEvent(payer sdk.AccAddress, amount sdk.Coins, beans sdkmath.Uint, feeUnitPrice sdk.Coins) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeFeeDebit,
+		sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		sdk.NewAttribute(AttributeKeyPayer, payer.String()),
+		sdk.NewAttribute(AttributeKeyAmount, amount.String()),
+		sdk.NewAttribute(AttributeKeyBeans, beans.String()),
+		sdk.NewAttribute(AttributeKeyFeeUnitPrice, feeUnitPrice.String()),
+	)
+}
@@ -13,6 +13,7 @@ const (
 	SmartWalletStateNone
 	SmartWalletStatePending
 	SmartWalletStateProvisioned
+	SmartWalletStateFailed
 )
 
 type AccountKeeper interface {
@@ -29,6 +29,7 @@ type GenesisState struct {
 	State                    State                        `protobuf:"bytes,3,opt,name=state,proto3" json:"state"`
 	SwingStoreExportData     []*SwingStoreExportDataEntry `protobuf:"bytes,4,rep,name=swing_store_export_data,json=swingStoreExportData,proto3" json:"swingStoreExportData"`
 	SwingStoreExportDataHash string                       `protobuf:"bytes,5,opt,name=swing_store_export_data_hash,json=swingStoreExportDataHash,proto3" json:"swingStoreExportDataHash"`
+	SmartWalletProvisions    []SmartWalletProvision       `protobuf:"bytes,6,rep,name=smart_wallet_provisions,json=smartWalletProvisions,proto3" json:"smartWalletProvisions"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -92,6 +93,13 @@ func (m *GenesisState) GetSwingStoreExportDataHash() string {
 	return ""
 }
 
+func (m *GenesisState) GetSmartWalletProvisions() []SmartWalletProvision {
+	if m != nil {
+		return m.SmartWalletProvisions
+	}
+	return nil
+}
+
 // A SwingStore "export data" entry.
 type SwingStoreExportDataEntry struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -153,30 +161,33 @@ func init() {
 func init() { proto.RegisterFile("agoric/swingset/genesis.proto", fileDescriptor_49b057311de9d296) }
 
 var fileDescriptor_49b057311de9d296 = []byte{
-	// 363 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcd, 0x4a, 0xc3, 0x40,
-	0x14, 0x85, 0x93, 0xfe, 0x41, 0xa7, 0x82, 0x12, 0x8a, 0x8d, 0xa5, 0x26, 0xa5, 0xab, 0x22, 0x98,
-	0x40, 0xc5, 0x8d, 0xae, 0x8c, 0x16, 0x5d, 0x4a, 0x8a, 0x1b, 0x11, 0xc2, 0xb4, 0x1d, 0x26, 0xa1,
-	0x6d, 0x26, 0xe4, 0x4e, 0xb5, 0xc5, 0x97, 0xf0, 0x11, 0x7c, 0x9c, 0x2e, 0xbb, 0x14, 0x17, 0x45,
-	0xda, 0x8d, 0xf4, 0x29, 0x24, 0x33, 0x2d, 0x42, 0x7f, 0x76, 0x27, 0xf9, 0xce, 0x39, 0x37, 0xdc,
-	0x1b, 0x74, 0x8a, 0x29, 0x8b, 0x83, 0x8e, 0x0d, 0x6f, 0x41, 0x48, 0x81, 0x70, 0x9b, 0x92, 0x90,
-	0x40, 0x00, 0x56, 0x14, 0x33, 0xce, 0xb4, 0x43, 0x89, 0xad, 0x35, 0x2e, 0x17, 0x29, 0xa3, 0x4c,
-	0x30, 0x3b, 0x51, 0xd2, 0x56, 0x36, 0x36, 0x5b, 0xd6, 0x42, 0xf2, 0xda, 0x77, 0x0a, 0x1d, 0xdc,
-	0xcb, 0xe2, 0x16, 0xc7, 0x9c, 0x68, 0x97, 0x28, 0x17, 0xe1, 0x18, 0x0f, 0x40, 0x4f, 0x55, 0xd5,
-	0x7a, 0xa1, 0x51, 0xb2, 0x36, 0x06, 0x59, 0x8f, 0x02, 0x3b, 0x99, 0xc9, 0xcc, 0x54, 0xdc, 0x95,
-	0x59, 0x6b, 0xa0, 0x2c, 0x24, 0x79, 0x3d, 0x2d, 0x52, 0xc7, 0x5b, 0x29, 0xd1, 0xbe, 0x0a, 0x49,
-	0xab, 0xf6, 0x8e, 0x4a, 0x02, 0x7b, 0xc0, 0x59, 0x4c, 0x3c, 0x32, 0x8a, 0x58, 0xcc, 0xbd, 0x2e,
-	0xe6, 0x58, 0xcf, 0x54, 0xd3, 0xf5, 0x42, 0xe3, 0x6c, 0xbb, 0x25, 0x11, 0xad, 0xc4, 0xde, 0x14,
-	0xee, 0x3b, 0xcc, 0x71, 0x33, 0xe4, 0xf1, 0xd8, 0xd1, 0x97, 0x33, 0xb3, 0x08, 0x3b, 0xb0, 0xbb,
-	0xf3, 0xad, 0xf6, 0x82, 0x2a, 0x7b, 0x86, 0x7b, 0x3e, 0x06, 0x5f, 0xcf, 0x56, 0xd5, 0x7a, 0xde,
-	0xa9, 0x2c, 0x67, 0xa6, 0xbe, 0x2b, 0xff, 0x80, 0xc1, 0x77, 0xf7, 0x92, 0xab, 0xcc, 0xef, 0xa7,
-	0xa9, 0xd4, 0x6e, 0xd1, 0xc9, 0xde, 0x0f, 0xd6, 0x8e, 0x50, 0xba, 0x47, 0xc6, 0xba, 0x9a, 0xcc,
-	0x71, 0x13, 0xa9, 0x15, 0x51, 0xf6, 0x15, 0xf7, 0x87, 0x44, 0x6c, 0x3e, 0xef, 0xca, 0x07, 0xe7,
-	0x69, 0x32, 0x37, 0xd4, 0xe9, 0xdc, 0x50, 0x7f, 0xe6, 0x86, 0xfa, 0xb1, 0x30, 0x94, 0xe9, 0xc2,
-	0x50, 0xbe, 0x16, 0x86, 0xf2, 0x7c, 0x4d, 0x03, 0xee, 0x0f, 0xdb, 0x56, 0x87, 0x0d, 0xec, 0x1b,
-	0x79, 0x66, 0xb9, 0xaf, 0x73, 0xe8, 0xf6, 0x6c, 0xca, 0xfa, 0x38, 0xa4, 0x76, 0x87, 0xc1, 0x80,
-	0x81, 0x3d, 0xfa, 0xff, 0x03, 0xf8, 0x38, 0x22, 0xd0, 0xce, 0x89, 0xfb, 0x5f, 0xfc, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x1b, 0x95, 0x41, 0xea, 0x67, 0x02, 0x00, 0x00,
+	// 413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xdf, 0x6a, 0xdb, 0x30,
+	0x14, 0x87, 0xed, 0x39, 0x09, 0x54, 0x1d, 0x6c, 0x88, 0x6c, 0xd1, 0x4a, 0x6b, 0x87, 0xc2, 0x20,
+	0x0c, 0x66, 0x43, 0xc6, 0x6e, 0xb6, 0xab, 0x79, 0x2b, 0xdb, 0x65, 0x71, 0x19, 0x83, 0x31, 0x30,
+	0x6a, 0x2a, 0x64, 0x53, 0xdb, 0x32, 0x3a, 0xea, 0x9f, 0xb0, 0x3d, 0xc4, 0x1e, 0x61, 0x8f, 0xd3,
+	0xcb, 0x5e, 0xe6, 0xca, 0x8c, 0xe4, 0x66, 0xe4, 0x29, 0x86, 0xa4, 0x84, 0x42, 0xe2, 0xdc, 0x1d,
+	0xeb, 0xfb, 0x7e, 0x47, 0x1c, 0xf9, 0xa0, 0x23, 0xca, 0x85, 0xcc, 0x27, 0x11, 0xdc, 0xe4, 0x15,
+	0x07, 0xa6, 0x22, 0xce, 0x2a, 0x06, 0x39, 0x84, 0xb5, 0x14, 0x4a, 0xe0, 0x27, 0x16, 0x87, 0x6b,
+	0x7c, 0xd0, 0xe7, 0x82, 0x0b, 0xc3, 0x22, 0x5d, 0x59, 0xed, 0xc0, 0xdf, 0xec, 0xb2, 0x2e, 0x2c,
+	0x3f, 0x9e, 0x79, 0xe8, 0xf1, 0x67, 0xdb, 0xf8, 0x4c, 0x51, 0xc5, 0xf0, 0x5b, 0xd4, 0xab, 0xa9,
+	0xa4, 0x25, 0x90, 0x47, 0x43, 0x77, 0xb4, 0x3f, 0x1e, 0x84, 0x1b, 0x17, 0x85, 0xa7, 0x06, 0xc7,
+	0x9d, 0xbb, 0x26, 0x70, 0x92, 0x95, 0x8c, 0xc7, 0xa8, 0x0b, 0x3a, 0x4f, 0x3c, 0x93, 0x7a, 0xbe,
+	0x95, 0x32, 0xdd, 0x57, 0x21, 0xab, 0xe2, 0x9f, 0x68, 0x60, 0x70, 0x0a, 0x4a, 0x48, 0x96, 0xb2,
+	0xdb, 0x5a, 0x48, 0x95, 0x5e, 0x50, 0x45, 0x49, 0x67, 0xe8, 0x8d, 0xf6, 0xc7, 0xaf, 0xb6, 0xbb,
+	0xe8, 0xe2, 0x4c, 0xeb, 0x27, 0xc6, 0xfe, 0x44, 0x15, 0x3d, 0xa9, 0x94, 0x9c, 0xc6, 0x64, 0xd9,
+	0x04, 0x7d, 0x68, 0xc1, 0x49, 0xeb, 0x29, 0xfe, 0x81, 0x0e, 0x77, 0x5c, 0x9e, 0x66, 0x14, 0x32,
+	0xd2, 0x1d, 0xba, 0xa3, 0xbd, 0xf8, 0x70, 0xd9, 0x04, 0xa4, 0x2d, 0xff, 0x85, 0x42, 0x96, 0xec,
+	0x24, 0xf8, 0x17, 0x1a, 0x40, 0x49, 0xa5, 0x4a, 0x6f, 0x68, 0x51, 0x30, 0x95, 0xd6, 0x52, 0x5c,
+	0xe7, 0x90, 0x8b, 0x0a, 0x48, 0xcf, 0x8c, 0xf6, 0x72, 0x7b, 0x34, 0xed, 0x7f, 0x33, 0xfa, 0xe9,
+	0xda, 0x8e, 0x8f, 0xf4, 0x7b, 0x2d, 0x9b, 0xe0, 0x19, 0xb4, 0x50, 0x48, 0xda, 0x8f, 0xdf, 0x75,
+	0xfe, 0xfd, 0x09, 0x9c, 0xe3, 0x8f, 0xe8, 0xc5, 0xce, 0xe7, 0xc2, 0x4f, 0x91, 0x77, 0xc9, 0xa6,
+	0xc4, 0xd5, 0x53, 0x26, 0xba, 0xc4, 0x7d, 0xd4, 0xbd, 0xa6, 0xc5, 0x15, 0x33, 0xff, 0x7d, 0x2f,
+	0xb1, 0x1f, 0xf1, 0xd7, 0xbb, 0xb9, 0xef, 0xde, 0xcf, 0x7d, 0xf7, 0xef, 0xdc, 0x77, 0x7f, 0x2f,
+	0x7c, 0xe7, 0x7e, 0xe1, 0x3b, 0xb3, 0x85, 0xef, 0x7c, 0x7f, 0xcf, 0x73, 0x95, 0x5d, 0x9d, 0x87,
+	0x13, 0x51, 0x46, 0x1f, 0xec, 0x92, 0xd9, 0x91, 0x5e, 0xc3, 0xc5, 0x65, 0xc4, 0x45, 0x41, 0x2b,
+	0x1e, 0x4d, 0x04, 0x94, 0x02, 0xa2, 0xdb, 0x87, 0xfd, 0x53, 0xd3, 0x9a, 0xc1, 0x79, 0xcf, 0x6c,
+	0xdf, 0x9b, 0xff, 0x03, 0x00, 0xb0, 0x7e, 0xa4, 0xcb, 0xe5, 0x02, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -199,6 +210,20 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.SmartWalletProvisions) > 0 {
+		for iNdEx := len(m.SmartWalletProvisions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SmartWalletProvisions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.SwingStoreExportDataHash) > 0 {
 		i -= len(m.SwingStoreExportDataHash)
 		copy(dAtA[i:], m.SwingStoreExportDataHash)
@@ -311,6 +336,12 @@ func (m *GenesisState) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovGenesis(uint64(l))
 	}
+	if len(m.SmartWalletProvisions) > 0 {
+		for _, e := range m.SmartWalletProvisions {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -498,6 +529,40 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			}
 			m.SwingStoreExportDataHash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SmartWalletProvisions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SmartWalletProvisions = append(m.SmartWalletProvisions, SmartWalletProvision{})
+			if err := m.SmartWalletProvisions[len(m.SmartWalletProvisions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -6,4 +6,8 @@ const (
 
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
+
+	// RefundPoolName is the module account that holds the fees charged by
+	// transactions for as long as they may be refunded.
+	RefundPoolName = "swingset/refund"
 )
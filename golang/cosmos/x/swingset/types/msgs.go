@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	sdkioerrors "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/vm"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -54,18 +55,51 @@ const (
 	bundleUncompressedSizeLimit int64 = 10 * 1024 * 1024 // 10MB
 )
 
-// Charge an account address for the beans associated with given messages and storage.
+// chargeAdmission charges for admitting msg as described by AdmissionCharge,
+// first checking that a smart wallet is provisioned (or charging for
+// provisioning it) if the message needs one.
 // See list of bean charges in default-params.go
-func chargeAdmission(ctx sdk.Context, keeper SwingSetKeeper, addr sdk.AccAddress, msgs []string, storageLen uint64) error {
-	beansPerUnit := keeper.GetBeansPerUnit(ctx)
+func chargeAdmission(ctx sdk.Context, keeper SwingSetKeeper, msg sdk.Msg) error {
+	addr, beans, needsSmartWallet, err := AdmissionCharge(keeper.GetBeansPerUnit(ctx), msg)
+	if err != nil {
+		return err
+	}
+	if needsSmartWallet {
+		if err := checkSmartWalletProvisioned(ctx, keeper, addr); err != nil {
+			return err
+		}
+	}
+	return keeper.ChargeBeans(ctx, addr, beans)
+}
+
+// AdmissionBeans returns the beans charged for admitting the given messages
+// and storage.
+func AdmissionBeans(beansPerUnit map[string]sdkmath.Uint, msgs []string, storageLen uint64) sdkmath.Uint {
 	beans := beansPerUnit[BeansPerInboundTx]
 	beans = beans.Add(beansPerUnit[BeansPerMessage].MulUint64((uint64(len(msgs)))))
 	for _, msg := range msgs {
 		beans = beans.Add(beansPerUnit[BeansPerMessageByte].MulUint64(uint64(len(msg))))
 	}
 	beans = beans.Add(beansPerUnit[BeansPerStorageByte].MulUint64(storageLen))
-
-	return keeper.ChargeBeans(ctx, addr, beans)
+	return beans
+}
+
+// AdmissionCharge returns what CheckAdmissibility charges for a message (and
+// EstimateFee estimates): the address to charge, the beans for the message,
+// and whether a smart wallet must also be provisioned for the address.
+func AdmissionCharge(beansPerUnit map[string]sdkmath.Uint, msg sdk.Msg) (addr sdk.AccAddress, beans sdkmath.Uint, needsSmartWallet bool, err error) {
+	switch m := msg.(type) {
+	case *MsgDeliverInbound:
+		return m.Submitter, AdmissionBeans(beansPerUnit, m.Messages, 0), false, nil
+	case *MsgWalletAction:
+		return m.Owner, AdmissionBeans(beansPerUnit, []string{m.Action}, 0), true, nil
+	case *MsgWalletSpendAction:
+		return m.Owner, AdmissionBeans(beansPerUnit, []string{m.SpendAction}, 0), true, nil
+	case *MsgInstallBundle:
+		return m.Submitter, AdmissionBeans(beansPerUnit, []string{m.Bundle}, m.ExpectedUncompressedSize()), false, nil
+	default:
+		return nil, sdkmath.ZeroUint(), false, sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no admission fee for %T", msg)
+	}
 }
 
 // checkSmartWalletProvisioned verifies if a smart wallet message (MsgWalletAction
@@ -87,10 +121,9 @@ func checkSmartWalletProvisioned(ctx sdk.Context, keeper SwingSetKeeper, addr sd
 		// transaction, a previous message may have provisioned the wallet.
 		return nil
 	default:
-		// Charge for the smart wallet.
+		// Charge for the smart wallet, unless a previous (e.g. failed) provision
+		// has already been charged.
 		// This is a separate charge from the smart wallet action which triggered the check
-		// TODO: Currently this call does not mark the smart wallet provisioning as
-		// pending, resulting in multiple provisioning charges for the owner.
 		return keeper.ChargeForSmartWallet(ctx, addr)
 	}
 }
@@ -118,7 +151,7 @@ func (msg MsgDeliverInbound) CheckAdmissibility(ctx sdk.Context, data interface{
 		}
 	*/
 
-	return chargeAdmission(ctx, keeper, msg.Submitter, msg.Messages, 0)
+	return chargeAdmission(ctx, keeper, &msg)
 }
 
 // GetInboundMsgCount implements InboundMsgCarrier.
@@ -184,12 +217,7 @@ func (msg MsgWalletAction) CheckAdmissibility(ctx sdk.Context, data interface{})
 		return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "data must be a SwingSetKeeper, not a %T", data)
 	}
 
-	err := checkSmartWalletProvisioned(ctx, keeper, msg.Owner)
-	if err != nil {
-		return err
-	}
-
-	return chargeAdmission(ctx, keeper, msg.Owner, []string{msg.Action}, 0)
+	return chargeAdmission(ctx, keeper, &msg)
 }
 
 // GetInboundMsgCount implements InboundMsgCarrier.
@@ -256,12 +284,7 @@ func (msg MsgWalletSpendAction) CheckAdmissibility(ctx sdk.Context, data interfa
 		return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "data must be a SwingSetKeeper, not a %T", data)
 	}
 
-	err := checkSmartWalletProvisioned(ctx, keeper, msg.Owner)
-	if err != nil {
-		return err
-	}
-
-	return chargeAdmission(ctx, keeper, msg.Owner, []string{msg.SpendAction}, 0)
+	return chargeAdmission(ctx, keeper, &msg)
 }
 
 // GetInboundMsgCount implements InboundMsgCarrier.
@@ -328,9 +351,26 @@ func (msg MsgProvision) ValidateBasic() error {
 
 // CheckAdmissibility implements the vm.ControllerAdmissionMsg interface.
 func (msg MsgProvision) CheckAdmissibility(ctx sdk.Context, data interface{}) error {
-	// TODO: consider disallowing a provision message for a smart wallet if the
-	// smart wallet is already provisioned or pending provisioning. However we
-	// currently do not track whether a smart wallet is pending provisioning.
+	keeper, ok := data.(SwingSetKeeper)
+	if !ok {
+		return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "data must be a SwingSetKeeper, not a %T", data)
+	}
+
+	// Do not pay for provisioning a smart wallet that is already provisioned
+	// or pending provisioning (which is either acknowledged by the controller
+	// or retried).
+	for _, powerFlag := range msg.PowerFlags {
+		if powerFlag != PowerFlagSmartWallet {
+			continue
+		}
+		switch keeper.GetSmartWalletState(ctx, msg.Address) {
+		case SmartWalletStateProvisioned:
+			return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "smart wallet for %s is already provisioned", msg.Address)
+		case SmartWalletStatePending:
+			return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "smart wallet for %s is pending provisioning", msg.Address)
+		}
+		break
+	}
 
 	// For explicitly provisioning, swingset will take care of charging,
 	// so we skip admission fees.
@@ -373,7 +413,7 @@ func (msg MsgInstallBundle) CheckAdmissibility(ctx sdk.Context, data interface{}
 	if !ok {
 		return sdkioerrors.Wrapf(sdkerrors.ErrInvalidRequest, "data must be a SwingSetKeeper, not a %T", data)
 	}
-	return chargeAdmission(ctx, keeper, msg.Submitter, []string{msg.Bundle}, msg.ExpectedUncompressedSize())
+	return chargeAdmission(ctx, keeper, &msg)
 }
 
 // GetInboundMsgCount implements InboundMsgCarrier.
@@ -17,6 +17,8 @@ var (
 	ParamStoreKeyFeeUnitPrice       = []byte("fee_unit_price")
 	ParamStoreKeyPowerFlagFees      = []byte("power_flag_fees")
 	ParamStoreKeyQueueMax           = []byte("queue_max")
+
+	ParamStoreKeyAlternativeFeeUnitPrices = []byte("alternative_fee_unit_prices")
 )
 
 func NewStringBeans(key string, beans sdkmath.Uint) StringBeans {
@@ -48,11 +50,12 @@ func ParamKeyTable() paramtypes.KeyTable {
 // DefaultParams returns default swingset parameters
 func DefaultParams() Params {
 	return Params{
-		BeansPerUnit:       DefaultBeansPerUnit(),
-		BootstrapVatConfig: DefaultBootstrapVatConfig,
-		FeeUnitPrice:       DefaultFeeUnitPrice,
-		PowerFlagFees:      DefaultPowerFlagFees,
-		QueueMax:           DefaultQueueMax,
+		BeansPerUnit:             DefaultBeansPerUnit(),
+		BootstrapVatConfig:       DefaultBootstrapVatConfig,
+		FeeUnitPrice:             DefaultFeeUnitPrice,
+		PowerFlagFees:            DefaultPowerFlagFees,
+		QueueMax:                 DefaultQueueMax,
+		AlternativeFeeUnitPrices: DefaultAlternativeFeeUnitPrices,
 	}
 }
 
@@ -69,6 +72,7 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(ParamStoreKeyBootstrapVatConfig, &p.BootstrapVatConfig, validateBootstrapVatConfig),
 		paramtypes.NewParamSetPair(ParamStoreKeyPowerFlagFees, &p.PowerFlagFees, validatePowerFlagFees),
 		paramtypes.NewParamSetPair(ParamStoreKeyQueueMax, &p.QueueMax, validateQueueMax),
+		paramtypes.NewParamSetPair(ParamStoreKeyAlternativeFeeUnitPrices, &p.AlternativeFeeUnitPrices, validateAlternativeFeeUnitPrices),
 	}
 }
 
@@ -89,6 +93,14 @@ func (p Params) ValidateBasic() error {
 	if err := validateQueueMax(p.QueueMax); err != nil {
 		return err
 	}
+	if err := validateAlternativeFeeUnitPrices(p.AlternativeFeeUnitPrices); err != nil {
+		return err
+	}
+	for _, price := range p.AlternativeFeeUnitPrices {
+		if p.FeeUnitPrice.AmountOf(price.Denom).IsPositive() {
+			return fmt.Errorf("alternative fee unit price denom %s must not be in the fee unit price", price.Denom)
+		}
+	}
 
 	return nil
 }
@@ -126,6 +138,29 @@ func validateFeeUnitPrice(i interface{}) error {
 	return nil
 }
 
+func validateAlternativeFeeUnitPrices(i interface{}) error {
+	v, ok := i.([]sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(v))
+	for _, price := range v {
+		if err := sdk.ValidateDenom(price.Denom); err != nil {
+			return fmt.Errorf("alternative fee unit price denom %s must be valid: %w", price.Denom, err)
+		}
+		if !price.Amount.IsPositive() {
+			return fmt.Errorf("alternative fee unit price %s must be positive: %s", price.Denom, price.Amount)
+		}
+		if seen[price.Denom] {
+			return fmt.Errorf("alternative fee unit price denom %s must not be repeated", price.Denom)
+		}
+		seen[price.Denom] = true
+	}
+
+	return nil
+}
+
 func validateBootstrapVatConfig(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
@@ -186,6 +221,9 @@ func UpdateParams(params Params) (Params, error) {
 	params.BeansPerUnit = newBpu
 	params.PowerFlagFees = newPff
 	params.QueueMax = newQm
+	if params.AlternativeFeeUnitPrices == nil {
+		params.AlternativeFeeUnitPrices = DefaultAlternativeFeeUnitPrices
+	}
 	return params, nil
 }
 
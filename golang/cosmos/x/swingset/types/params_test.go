@@ -105,6 +105,8 @@ func TestUpdateParams(t *testing.T) {
 		FeeUnitPrice:       sdk.NewCoins(sdk.NewInt64Coin("denom", 789)),
 		PowerFlagFees:      DefaultPowerFlagFees,
 		QueueMax:           DefaultQueueMax,
+
+		AlternativeFeeUnitPrices: DefaultAlternativeFeeUnitPrices,
 	}
 	got, err := UpdateParams(in)
 	if err != nil {
@@ -113,4 +115,55 @@ func TestUpdateParams(t *testing.T) {
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("got %v, want %v", got, want)
 	}
+
+	// Alternative fee unit prices that are already set are kept.
+	in.AlternativeFeeUnitPrices = []sdk.Coin{sdk.NewInt64Coin("ubld", 5_000_000)}
+	got, err = UpdateParams(in)
+	if err != nil {
+		t.Fatalf("UpdateParam error %v", err)
+	}
+	if !reflect.DeepEqual(got.AlternativeFeeUnitPrices, in.AlternativeFeeUnitPrices) {
+		t.Errorf("got alternative fee unit prices %v, want %v", got.AlternativeFeeUnitPrices, in.AlternativeFeeUnitPrices)
+	}
+}
+
+func TestValidateAlternativeFeeUnitPrices(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		prices  []sdk.Coin
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			prices: nil,
+		},
+		{
+			name:   "ordered_alternatives",
+			prices: []sdk.Coin{sdk.NewInt64Coin("ubld", 5_000_000), sdk.NewInt64Coin("ibc/usdc", 1_000_000)},
+		},
+		{
+			name:    "zero_price",
+			prices:  []sdk.Coin{sdk.NewInt64Coin("ubld", 0)},
+			wantErr: true,
+		},
+		{
+			name:    "repeated_denom",
+			prices:  []sdk.Coin{sdk.NewInt64Coin("ubld", 1), sdk.NewInt64Coin("ubld", 2)},
+			wantErr: true,
+		},
+		{
+			name:    "fee_unit_price_denom",
+			prices:  []sdk.Coin{sdk.NewInt64Coin("uist", 1_000_000)},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := DefaultParams()
+			params.AlternativeFeeUnitPrices = tt.prices
+			err := params.ValidateBasic()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
 }
@@ -0,0 +1,15 @@
+package types
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ codectypes.UnpackInterfacesMessage = &QueryEstimateFeeRequest{}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, so that the
+// message to estimate is unpacked with the request.
+func (req *QueryEstimateFeeRequest) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var msg sdk.Msg
+	return unpacker.UnpackAny(req.Msg, &msg)
+}
@@ -161,6 +161,15 @@ type Params struct {
 	// nodes must all serialize and deserialize the existing order without
 	// permuting it.
 	QueueMax []QueueSize `protobuf:"bytes,5,rep,name=queue_max,json=queueMax,proto3" json:"queue_max"`
+	// Prices per the unit named "fee" in alternative denoms, each the
+	// conversion ratio from beans to that denom:
+	//
+	// cost = beans_used * alternative_fee_unit_price / beans_per_unit["fee"]
+	//
+	// A payer whose spendable balance does not cover the cost at
+	// fee_unit_price is charged in the first of these denoms (in this order of
+	// preference) whose cost their balance covers.
+	AlternativeFeeUnitPrices []types.Coin `protobuf:"bytes,6,rep,name=alternative_fee_unit_prices,json=alternativeFeeUnitPrices,proto3" json:"alternative_fee_unit_prices"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -230,6 +239,13 @@ func (m *Params) GetQueueMax() []QueueSize {
 	return nil
 }
 
+func (m *Params) GetAlternativeFeeUnitPrices() []types.Coin {
+	if m != nil {
+		return m.AlternativeFeeUnitPrices
+	}
+	return nil
+}
+
 // The current state of the module.
 type State struct {
 	// The allowed number of items to add to queues, as determined by SwingSet.
@@ -495,6 +511,187 @@ func (m *Egress) GetPowerFlags() []string {
 	return nil
 }
 
+// SmartWalletProvision is the progress of provisioning the smart wallet of an
+// address.
+type SmartWalletProvision struct {
+	Address github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,1,opt,name=address,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"address" yaml:"address"`
+	// The SmartWalletState of the provision (none, pending, provisioned, or
+	// failed).
+	State SmartWalletState `protobuf:"varint,2,opt,name=state,proto3,casttype=SmartWalletState" json:"state" yaml:"state"`
+	// The block height at which the latest attempt became pending.
+	PendingSinceHeight int64 `protobuf:"varint,3,opt,name=pending_since_height,json=pendingSinceHeight,proto3" json:"pendingSinceHeight" yaml:"pending_since_height"`
+	// The number of attempts covered by the charged fee, which are kept across
+	// failures and reset only by an explicit provision.
+	Attempts uint32 `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts" yaml:"attempts"`
+	// Whether the provisioning fee has been charged.
+	FeeCharged bool `protobuf:"varint,5,opt,name=fee_charged,json=feeCharged,proto3" json:"feeCharged" yaml:"fee_charged"`
+	// Why the provision failed, if it did.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error" yaml:"error"`
+}
+
+func (m *SmartWalletProvision) Reset()         { *m = SmartWalletProvision{} }
+func (m *SmartWalletProvision) String() string { return proto.CompactTextString(m) }
+func (*SmartWalletProvision) ProtoMessage()    {}
+func (*SmartWalletProvision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9c341e0de15f8b, []int{8}
+}
+func (m *SmartWalletProvision) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SmartWalletProvision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SmartWalletProvision.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SmartWalletProvision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SmartWalletProvision.Merge(m, src)
+}
+func (m *SmartWalletProvision) XXX_Size() int {
+	return m.Size()
+}
+func (m *SmartWalletProvision) XXX_DiscardUnknown() {
+	xxx_messageInfo_SmartWalletProvision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SmartWalletProvision proto.InternalMessageInfo
+
+func (m *SmartWalletProvision) GetAddress() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *SmartWalletProvision) GetState() SmartWalletState {
+	if m != nil {
+		return m.State
+	}
+	return 0
+}
+
+func (m *SmartWalletProvision) GetPendingSinceHeight() int64 {
+	if m != nil {
+		return m.PendingSinceHeight
+	}
+	return 0
+}
+
+func (m *SmartWalletProvision) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *SmartWalletProvision) GetFeeCharged() bool {
+	if m != nil {
+		return m.FeeCharged
+	}
+	return false
+}
+
+func (m *SmartWalletProvision) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// FeeCharge is what a payer was charged by a transaction, and how much of it
+// has since been refunded.
+type FeeCharge struct {
+	// The block height of the transaction.
+	BlockHeight int64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"blockHeight" yaml:"block_height"`
+	// The hash of the transaction.
+	TxHash string                                        `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"txHash" yaml:"tx_hash"`
+	Payer  github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,3,opt,name=payer,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"payer" yaml:"payer"`
+	// The coins debited from the payer.
+	Coins github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,4,rep,name=coins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"coins" yaml:"coins"`
+	// The beans charged to the payer (whether debited or added to beans owing).
+	Beans github_com_cosmos_cosmos_sdk_types.Uint `protobuf:"bytes,5,opt,name=beans,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Uint" json:"beans" yaml:"beans"`
+	// The coins refunded to the payer.
+	RefundedCoins github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,6,rep,name=refunded_coins,json=refundedCoins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"refundedCoins" yaml:"refunded_coins"`
+	// The beans cancelled from the beans owing by the payer.
+	CancelledBeans github_com_cosmos_cosmos_sdk_types.Uint `protobuf:"bytes,7,opt,name=cancelled_beans,json=cancelledBeans,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Uint" json:"cancelledBeans" yaml:"cancelled_beans"`
+	// rather than debited, which bound the beans that can be cancelled.
+	OwingBeans github_com_cosmos_cosmos_sdk_types.Uint `protobuf:"bytes,8,opt,name=owing_beans,json=owingBeans,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Uint" json:"owingBeans" yaml:"owing_beans"`
+}
+
+func (m *FeeCharge) Reset()         { *m = FeeCharge{} }
+func (m *FeeCharge) String() string { return proto.CompactTextString(m) }
+func (*FeeCharge) ProtoMessage()    {}
+func (*FeeCharge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9c341e0de15f8b, []int{9}
+}
+func (m *FeeCharge) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeeCharge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeeCharge.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FeeCharge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeCharge.Merge(m, src)
+}
+func (m *FeeCharge) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeeCharge) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeCharge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeCharge proto.InternalMessageInfo
+
+func (m *FeeCharge) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *FeeCharge) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *FeeCharge) GetPayer() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Payer
+	}
+	return nil
+}
+
+func (m *FeeCharge) GetCoins() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+func (m *FeeCharge) GetRefundedCoins() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.RefundedCoins
+	}
+	return nil
+}
+
 // SwingStoreArtifact encodes an artifact of a swing-store export.
 // Artifacts may be stored or transmitted in any order. Most handlers do
 // maintain the artifact order from their original source as an effect of how
@@ -508,7 +705,7 @@ func (m *SwingStoreArtifact) Reset()         { *m = SwingStoreArtifact{} }
 func (m *SwingStoreArtifact) String() string { return proto.CompactTextString(m) }
 func (*SwingStoreArtifact) ProtoMessage()    {}
 func (*SwingStoreArtifact) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ff9c341e0de15f8b, []int{8}
+	return fileDescriptor_ff9c341e0de15f8b, []int{10}
 }
 func (m *SwingStoreArtifact) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -560,66 +757,97 @@ func init() {
 	proto.RegisterType((*PowerFlagFee)(nil), "agoric.swingset.PowerFlagFee")
 	proto.RegisterType((*QueueSize)(nil), "agoric.swingset.QueueSize")
 	proto.RegisterType((*Egress)(nil), "agoric.swingset.Egress")
+	proto.RegisterType((*SmartWalletProvision)(nil), "agoric.swingset.SmartWalletProvision")
+	proto.RegisterType((*FeeCharge)(nil), "agoric.swingset.FeeCharge")
 	proto.RegisterType((*SwingStoreArtifact)(nil), "agoric.swingset.SwingStoreArtifact")
 }
 
 func init() { proto.RegisterFile("agoric/swingset/swingset.proto", fileDescriptor_ff9c341e0de15f8b) }
 
 var fileDescriptor_ff9c341e0de15f8b = []byte{
-	// 842 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x14, 0x8e, 0x49, 0x52, 0x9a, 0x97, 0x6c, 0xbb, 0x0c, 0x95, 0x36, 0x54, 0x6c, 0xa6, 0xf2, 0x85,
-	0x4a, 0xab, 0x8d, 0xb7, 0x20, 0x84, 0x94, 0x15, 0x87, 0xb8, 0xea, 0x6a, 0x25, 0x04, 0x0a, 0x8e,
-	0xca, 0x01, 0x81, 0xac, 0x89, 0x33, 0x31, 0xd3, 0x3a, 0x1e, 0xef, 0xcc, 0xf4, 0xd7, 0xfe, 0x03,
-	0x70, 0x41, 0x42, 0x9c, 0x38, 0xf6, 0xcc, 0x5f, 0xb2, 0xc7, 0x3d, 0x22, 0x0e, 0x06, 0xb5, 0x17,
-	0xd4, 0x63, 0x8e, 0x48, 0x48, 0x68, 0x66, 0x1c, 0xc7, 0xa2, 0x48, 0xf4, 0xc2, 0x29, 0xf3, 0x7e,
-	0x7d, 0xef, 0x7d, 0xdf, 0x1b, 0x4f, 0xa0, 0x47, 0x62, 0x2e, 0x58, 0xe4, 0xc9, 0x33, 0x96, 0xc6,
-	0x92, 0xaa, 0xf2, 0xd0, 0xcf, 0x04, 0x57, 0x1c, 0x6d, 0xda, 0x78, 0x7f, 0xe9, 0xde, 0xde, 0x8a,
-	0x79, 0xcc, 0x4d, 0xcc, 0xd3, 0x27, 0x9b, 0xb6, 0xdd, 0x8b, 0xb8, 0x9c, 0x73, 0xe9, 0x4d, 0x88,
-	0xa4, 0xde, 0xe9, 0xde, 0x84, 0x2a, 0xb2, 0xe7, 0x45, 0x9c, 0xa5, 0x36, 0xee, 0x7e, 0xeb, 0xc0,
-	0xfd, 0x7d, 0x2e, 0xe8, 0xc1, 0x29, 0x49, 0x46, 0x82, 0x67, 0x5c, 0x92, 0x04, 0x6d, 0x41, 0x53,
-	0x31, 0x95, 0xd0, 0xae, 0xb3, 0xe3, 0xec, 0xb6, 0x02, 0x6b, 0xa0, 0x1d, 0x68, 0x4f, 0xa9, 0x8c,
-	0x04, 0xcb, 0x14, 0xe3, 0x69, 0xf7, 0x0d, 0x13, 0xab, 0xba, 0xd0, 0x87, 0xd0, 0xa4, 0xa7, 0x24,
-	0x91, 0xdd, 0xfa, 0x4e, 0x7d, 0xb7, 0xfd, 0xfe, 0x3b, 0xfd, 0x7f, 0xcc, 0xd8, 0x5f, 0x76, 0xf2,
-	0x1b, 0xaf, 0x72, 0x5c, 0x0b, 0x6c, 0xf6, 0xa0, 0xf1, 0xdd, 0x25, 0xae, 0xb9, 0x12, 0xd6, 0x97,
-	0x61, 0x34, 0x80, 0xce, 0x91, 0xe4, 0x69, 0x98, 0x51, 0x31, 0x67, 0x4a, 0xda, 0x39, 0xfc, 0x07,
-	0x8b, 0x1c, 0xbf, 0x7d, 0x41, 0xe6, 0xc9, 0xc0, 0xad, 0x46, 0xdd, 0xa0, 0xad, 0xcd, 0x91, 0xb5,
-	0xd0, 0x23, 0x78, 0xf3, 0x48, 0x86, 0x11, 0x9f, 0x52, 0x3b, 0xa2, 0x8f, 0x16, 0x39, 0xde, 0x58,
-	0x96, 0x99, 0x80, 0x1b, 0xac, 0x1d, 0xc9, 0x7d, 0x7d, 0xf8, 0xbe, 0x0e, 0x6b, 0x23, 0x22, 0xc8,
-	0x5c, 0xa2, 0xe7, 0xb0, 0x31, 0xa1, 0x24, 0x95, 0x1a, 0x36, 0x3c, 0x49, 0x99, 0xea, 0x3a, 0x86,
-	0xc5, 0xbb, 0xb7, 0x58, 0x8c, 0x95, 0x60, 0x69, 0xec, 0xeb, 0xe4, 0x82, 0x48, 0xc7, 0x54, 0x8e,
-	0xa8, 0x38, 0x4c, 0x99, 0x42, 0x2f, 0x60, 0x63, 0x46, 0xa9, 0xc1, 0x08, 0x33, 0xc1, 0x22, 0x3d,
-	0x88, 0xd5, 0xc3, 0x2e, 0xa3, 0xaf, 0x97, 0xd1, 0x2f, 0x96, 0xd1, 0xdf, 0xe7, 0x2c, 0xf5, 0x9f,
-	0x68, 0x98, 0x9f, 0x7f, 0xc3, 0xbb, 0x31, 0x53, 0xdf, 0x9c, 0x4c, 0xfa, 0x11, 0x9f, 0x7b, 0xc5,
-	0xe6, 0xec, 0xcf, 0x63, 0x39, 0x3d, 0xf6, 0xd4, 0x45, 0x46, 0xa5, 0x29, 0x90, 0x41, 0x67, 0x46,
-	0xa9, 0xee, 0x36, 0xd2, 0x0d, 0xd0, 0x13, 0xd8, 0x9a, 0x70, 0xae, 0xa4, 0x12, 0x24, 0x0b, 0x4f,
-	0x89, 0x0a, 0x23, 0x9e, 0xce, 0x58, 0xdc, 0xad, 0x9b, 0x25, 0xa1, 0x32, 0xf6, 0x05, 0x51, 0xfb,
-	0x26, 0x82, 0x3e, 0x81, 0xcd, 0x8c, 0x9f, 0x51, 0x11, 0xce, 0x12, 0x12, 0x87, 0x33, 0x4a, 0x65,
-	0xb7, 0x61, 0xa6, 0x7c, 0x78, 0x8b, 0xef, 0x48, 0xe7, 0x3d, 0x4b, 0x48, 0xfc, 0x8c, 0xd2, 0x82,
-	0xf0, 0xbd, 0xac, 0xe2, 0x93, 0xe8, 0x63, 0x68, 0xbd, 0x38, 0xa1, 0x27, 0x34, 0x9c, 0x93, 0xf3,
-	0x6e, 0xd3, 0xc0, 0x6c, 0xdf, 0x82, 0xf9, 0x5c, 0x67, 0x8c, 0xd9, 0xcb, 0x25, 0xc6, 0xba, 0x29,
-	0xf9, 0x94, 0x9c, 0x0f, 0xd6, 0x7f, 0xba, 0xc4, 0xb5, 0x3f, 0x2e, 0xb1, 0xe3, 0x7e, 0x06, 0xcd,
-	0xb1, 0x22, 0x8a, 0xa2, 0x03, 0xb8, 0x67, 0x11, 0x49, 0x92, 0xf0, 0x33, 0x3a, 0x2d, 0x96, 0xf1,
-	0xdf, 0xa8, 0x1d, 0x53, 0x36, 0xb4, 0x55, 0x6e, 0x02, 0xed, 0xca, 0xb6, 0xd0, 0x7d, 0xa8, 0x1f,
-	0xd3, 0x8b, 0xe2, 0x5a, 0xeb, 0x23, 0x3a, 0x80, 0xa6, 0xd9, 0x5d, 0x71, 0x57, 0x3c, 0x8d, 0xf1,
-	0x6b, 0x8e, 0xdf, 0xbb, 0xc3, 0x1e, 0x0e, 0x59, 0xaa, 0x02, 0x5b, 0x3d, 0x68, 0x98, 0xe9, 0x7f,
-	0x74, 0xa0, 0x53, 0x15, 0x0b, 0x3d, 0x04, 0x58, 0x89, 0x5c, 0xb4, 0x6d, 0x95, 0xd2, 0xa1, 0xaf,
-	0xa1, 0x3e, 0xa3, 0xff, 0xcb, 0xed, 0xd0, 0xb8, 0xc5, 0x50, 0x1f, 0x41, 0xab, 0xd4, 0xe8, 0x5f,
-	0x04, 0x40, 0xd0, 0x90, 0xec, 0xa5, 0xfd, 0x56, 0x9a, 0x81, 0x39, 0x17, 0x85, 0x7f, 0x39, 0xb0,
-	0x76, 0x10, 0x0b, 0x2a, 0x25, 0x7a, 0x0a, 0xeb, 0x29, 0x8b, 0x8e, 0x53, 0x32, 0x2f, 0xde, 0x04,
-	0x1f, 0xdf, 0xe4, 0xb8, 0xf4, 0x2d, 0x72, 0xbc, 0x69, 0x3f, 0xb0, 0xa5, 0xc7, 0x0d, 0xca, 0x20,
-	0xfa, 0x0a, 0x1a, 0x19, 0xa5, 0xc2, 0x74, 0xe8, 0xf8, 0xcf, 0x6f, 0x72, 0x6c, 0xec, 0x45, 0x8e,
-	0xdb, 0xb6, 0x48, 0x5b, 0xee, 0x9f, 0x39, 0x7e, 0x7c, 0x07, 0x7a, 0xc3, 0x28, 0x1a, 0x4e, 0xa7,
-	0x7a, 0xa8, 0xc0, 0xa0, 0xa0, 0x00, 0xda, 0x2b, 0x89, 0xed, 0xcb, 0xd3, 0xf2, 0xf7, 0xae, 0x72,
-	0x0c, 0xe5, 0x26, 0xe4, 0x4d, 0x8e, 0xa1, 0x54, 0x5d, 0x2e, 0x72, 0xfc, 0x56, 0xd1, 0xb8, 0xf4,
-	0xb9, 0x41, 0x25, 0xc1, 0xf0, 0xaf, 0xb9, 0x0a, 0xd0, 0x58, 0xdf, 0xb2, 0xb1, 0xe2, 0x82, 0x0e,
-	0x85, 0x62, 0x33, 0x12, 0x29, 0xf4, 0x08, 0x1a, 0x15, 0x19, 0x1e, 0x68, 0x36, 0x85, 0x04, 0x05,
-	0x1b, 0x4b, 0xdf, 0x38, 0x75, 0xf2, 0x94, 0x28, 0x52, 0x50, 0x37, 0xc9, 0xda, 0x5e, 0x25, 0x6b,
-	0xcb, 0x0d, 0x8c, 0xd3, 0x76, 0xf5, 0x0f, 0x5f, 0x5d, 0xf5, 0x9c, 0xd7, 0x57, 0x3d, 0xe7, 0xf7,
-	0xab, 0x9e, 0xf3, 0xc3, 0x75, 0xaf, 0xf6, 0xfa, 0xba, 0x57, 0xfb, 0xe5, 0xba, 0x57, 0xfb, 0xf2,
-	0x69, 0x45, 0x9e, 0xa1, 0xfd, 0x73, 0xb0, 0x1f, 0x83, 0x91, 0x27, 0xe6, 0x09, 0x49, 0xe3, 0xa5,
-	0x6e, 0xe7, 0xab, 0xff, 0x0d, 0xa3, 0xdb, 0x64, 0xcd, 0x3c, 0xf7, 0x1f, 0xfc, 0x1d, 0x00, 0x00,
-	0xff, 0xff, 0xee, 0x34, 0x5f, 0xf6, 0x57, 0x06, 0x00, 0x00,
+	// 1308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcf, 0x6f, 0x1b, 0xc5,
+	0x17, 0xf7, 0x7e, 0xfd, 0x23, 0xc9, 0xd8, 0x49, 0xfa, 0x1d, 0x02, 0x75, 0x7f, 0x79, 0xa2, 0x15,
+	0x82, 0x48, 0x55, 0xed, 0x16, 0x54, 0x21, 0xa5, 0xe2, 0x90, 0xb5, 0x12, 0xa5, 0x20, 0x2a, 0xb3,
+	0x56, 0x41, 0x2a, 0xd0, 0x65, 0xbc, 0x1e, 0xaf, 0xb7, 0x59, 0xef, 0x6c, 0x67, 0x26, 0x6e, 0xd2,
+	0x33, 0x12, 0x1c, 0x11, 0x27, 0x0e, 0x1c, 0x7a, 0xe6, 0x2f, 0xe9, 0xb1, 0x47, 0xc4, 0x61, 0x41,
+	0xe9, 0x05, 0x2c, 0xf5, 0xe2, 0x23, 0x12, 0x12, 0x9a, 0x1f, 0x6b, 0x6f, 0x9a, 0x8a, 0x36, 0x95,
+	0x38, 0xd9, 0xef, 0xd7, 0xe7, 0xbd, 0xf7, 0x79, 0x33, 0x6f, 0x16, 0x34, 0x70, 0x40, 0x59, 0xe8,
+	0xb7, 0xf8, 0x83, 0x30, 0x0e, 0x38, 0x11, 0xb3, 0x3f, 0xcd, 0x84, 0x51, 0x41, 0xe1, 0xaa, 0xb6,
+	0x37, 0x33, 0xf5, 0xf9, 0xb5, 0x80, 0x06, 0x54, 0xd9, 0x5a, 0xf2, 0x9f, 0x76, 0x3b, 0xdf, 0xf0,
+	0x29, 0x1f, 0x51, 0xde, 0xea, 0x61, 0x4e, 0x5a, 0xe3, 0x6b, 0x3d, 0x22, 0xf0, 0xb5, 0x96, 0x4f,
+	0xc3, 0x58, 0xdb, 0xed, 0x6f, 0x2d, 0x70, 0xa6, 0x4d, 0x19, 0xd9, 0x1e, 0xe3, 0xa8, 0xc3, 0x68,
+	0x42, 0x39, 0x8e, 0xe0, 0x1a, 0x28, 0x8b, 0x50, 0x44, 0xa4, 0x6e, 0xad, 0x5b, 0x1b, 0x4b, 0xae,
+	0x16, 0xe0, 0x3a, 0xa8, 0xf6, 0x09, 0xf7, 0x59, 0x98, 0x88, 0x90, 0xc6, 0xf5, 0xff, 0x29, 0x5b,
+	0x5e, 0x05, 0xaf, 0x83, 0x32, 0x19, 0xe3, 0x88, 0xd7, 0x8b, 0xeb, 0xc5, 0x8d, 0xea, 0x7b, 0xe7,
+	0x9a, 0xcf, 0xd5, 0xd8, 0xcc, 0x32, 0x39, 0xa5, 0xc7, 0x29, 0x2a, 0xb8, 0xda, 0x7b, 0xb3, 0xf4,
+	0xdd, 0x23, 0x54, 0xb0, 0x39, 0x58, 0xcc, 0xcc, 0x70, 0x13, 0xd4, 0xee, 0x71, 0x1a, 0x7b, 0x09,
+	0x61, 0xa3, 0x50, 0x70, 0x5d, 0x87, 0x73, 0x76, 0x9a, 0xa2, 0x37, 0x0e, 0xf1, 0x28, 0xda, 0xb4,
+	0xf3, 0x56, 0xdb, 0xad, 0x4a, 0xb1, 0xa3, 0x25, 0x78, 0x19, 0x2c, 0xdc, 0xe3, 0x9e, 0x4f, 0xfb,
+	0x44, 0x97, 0xe8, 0xc0, 0x69, 0x8a, 0x56, 0xb2, 0x30, 0x65, 0xb0, 0xdd, 0xca, 0x3d, 0xde, 0x96,
+	0x7f, 0x9e, 0x15, 0x41, 0xa5, 0x83, 0x19, 0x1e, 0x71, 0xb8, 0x0b, 0x56, 0x7a, 0x04, 0xc7, 0x5c,
+	0xc2, 0x7a, 0xfb, 0x71, 0x28, 0xea, 0x96, 0xea, 0xe2, 0xe2, 0x89, 0x2e, 0xba, 0x82, 0x85, 0x71,
+	0xe0, 0x48, 0x67, 0xd3, 0x48, 0x4d, 0x45, 0x76, 0x08, 0xbb, 0x1d, 0x87, 0x02, 0xde, 0x07, 0x2b,
+	0x03, 0x42, 0x14, 0x86, 0x97, 0xb0, 0xd0, 0x97, 0x85, 0x68, 0x3e, 0xf4, 0x30, 0x9a, 0x72, 0x18,
+	0x4d, 0x33, 0x8c, 0x66, 0x9b, 0x86, 0xb1, 0x73, 0x55, 0xc2, 0xfc, 0xfc, 0x1b, 0xda, 0x08, 0x42,
+	0x31, 0xdc, 0xef, 0x35, 0x7d, 0x3a, 0x6a, 0x99, 0xc9, 0xe9, 0x9f, 0x2b, 0xbc, 0xbf, 0xd7, 0x12,
+	0x87, 0x09, 0xe1, 0x2a, 0x80, 0xbb, 0xb5, 0x01, 0x21, 0x32, 0x5b, 0x47, 0x26, 0x80, 0x57, 0xc1,
+	0x5a, 0x8f, 0x52, 0xc1, 0x05, 0xc3, 0x89, 0x37, 0xc6, 0xc2, 0xf3, 0x69, 0x3c, 0x08, 0x83, 0x7a,
+	0x51, 0x0d, 0x09, 0xce, 0x6c, 0x9f, 0x61, 0xd1, 0x56, 0x16, 0xf8, 0x31, 0x58, 0x4d, 0xe8, 0x03,
+	0xc2, 0xbc, 0x41, 0x84, 0x03, 0x6f, 0x40, 0x08, 0xaf, 0x97, 0x54, 0x95, 0x97, 0x4e, 0xf4, 0xdb,
+	0x91, 0x7e, 0x3b, 0x11, 0x0e, 0x76, 0x08, 0x31, 0x0d, 0x2f, 0x27, 0x39, 0x1d, 0x87, 0x1f, 0x82,
+	0xa5, 0xfb, 0xfb, 0x64, 0x9f, 0x78, 0x23, 0x7c, 0x50, 0x2f, 0x2b, 0x98, 0xf3, 0x27, 0x60, 0x3e,
+	0x95, 0x1e, 0xdd, 0xf0, 0x61, 0x86, 0xb1, 0xa8, 0x42, 0x3e, 0xc1, 0x07, 0xf0, 0x2e, 0xb8, 0x80,
+	0x23, 0x41, 0x58, 0x8c, 0x45, 0x38, 0x26, 0xde, 0x71, 0xf2, 0x78, 0xbd, 0xf2, 0x32, 0xf6, 0x34,
+	0x5e, 0x3d, 0x87, 0xb1, 0x93, 0x23, 0x87, 0x6f, 0x2e, 0xfe, 0xf8, 0x08, 0x15, 0xfe, 0x78, 0x84,
+	0x2c, 0xfb, 0x16, 0x28, 0x77, 0x05, 0x16, 0x04, 0x6e, 0x83, 0x65, 0x5d, 0x31, 0x8e, 0x22, 0xfa,
+	0x80, 0xf4, 0xeb, 0xd6, 0x2b, 0x56, 0x5d, 0x53, 0x61, 0x5b, 0x3a, 0xca, 0x8e, 0x40, 0x35, 0x77,
+	0x1a, 0xe0, 0x19, 0x50, 0xdc, 0x23, 0x87, 0xe6, 0xda, 0xc8, 0xbf, 0x70, 0x1b, 0x94, 0xd5, 0xd9,
+	0x30, 0x67, 0xb1, 0x25, 0x31, 0x7e, 0x4d, 0xd1, 0xbb, 0xaf, 0x30, 0xe7, 0xdb, 0x61, 0x2c, 0x5c,
+	0x1d, 0xbd, 0x59, 0x52, 0xd5, 0xff, 0x60, 0x81, 0x5a, 0x7e, 0x18, 0xf0, 0x12, 0x00, 0xf3, 0x21,
+	0x9a, 0xb4, 0x4b, 0xb3, 0xd1, 0xc0, 0xaf, 0x40, 0x71, 0x40, 0xfe, 0x93, 0xd3, 0x27, 0x71, 0x4d,
+	0x51, 0x1f, 0x80, 0xa5, 0x19, 0x47, 0x2f, 0x20, 0x00, 0x82, 0x12, 0x0f, 0x1f, 0xea, 0xbb, 0x58,
+	0x76, 0xd5, 0x7f, 0x13, 0xf8, 0xb7, 0x05, 0x2a, 0xdb, 0x01, 0x23, 0x9c, 0xc3, 0x1b, 0x60, 0x31,
+	0x0e, 0xfd, 0xbd, 0x18, 0x8f, 0xcc, 0xce, 0x71, 0xd0, 0x24, 0x45, 0x33, 0xdd, 0x34, 0x45, 0xab,
+	0xfa, 0x02, 0x67, 0x1a, 0xdb, 0x9d, 0x19, 0xe1, 0x97, 0xa0, 0x94, 0x10, 0xc2, 0x54, 0x86, 0x9a,
+	0xb3, 0x3b, 0x49, 0x91, 0x92, 0xa7, 0x29, 0xaa, 0xea, 0x20, 0x29, 0xd9, 0x7f, 0xa5, 0xe8, 0xca,
+	0x2b, 0xb4, 0xb7, 0xe5, 0xfb, 0x5b, 0xfd, 0xbe, 0x2c, 0xca, 0x55, 0x28, 0xd0, 0x05, 0xd5, 0x39,
+	0xc5, 0x7a, 0xb3, 0x2d, 0x39, 0xd7, 0x8e, 0x52, 0x04, 0x66, 0x93, 0xe0, 0x93, 0x14, 0x81, 0x19,
+	0xeb, 0x7c, 0x9a, 0xa2, 0xff, 0x9b, 0xc4, 0x33, 0x9d, 0xed, 0xe6, 0x1c, 0x54, 0xff, 0x05, 0xfb,
+	0xcf, 0x22, 0x58, 0xeb, 0x8e, 0x30, 0x13, 0x9f, 0xe3, 0x28, 0x22, 0xa2, 0xc3, 0xe8, 0x38, 0xe4,
+	0x72, 0x8d, 0x0e, 0xc1, 0x02, 0xd6, 0x35, 0x28, 0x32, 0x6a, 0xce, 0xad, 0x49, 0x8a, 0x32, 0xd5,
+	0x7c, 0x99, 0x19, 0xc5, 0x6b, 0x74, 0x96, 0x61, 0xc1, 0x36, 0x28, 0x73, 0x79, 0x1d, 0x14, 0x77,
+	0xcb, 0xce, 0x95, 0x49, 0x8a, 0xb4, 0x62, 0x9a, 0xa2, 0x9a, 0xce, 0xa2, 0x44, 0x99, 0xe3, 0x4c,
+	0xae, 0x56, 0x75, 0x87, 0x5c, 0xed, 0x0a, 0x03, 0xb0, 0x96, 0x90, 0xb8, 0x1f, 0xc6, 0x81, 0xc7,
+	0xc3, 0xd8, 0x27, 0xde, 0x90, 0x84, 0xc1, 0x50, 0xa8, 0xdd, 0x53, 0x74, 0xae, 0x4f, 0x52, 0x04,
+	0x8d, 0xbd, 0x2b, 0xcd, 0xbb, 0xca, 0x3a, 0x4d, 0xd1, 0x85, 0x6c, 0x3a, 0x27, 0x63, 0x6d, 0xf7,
+	0x05, 0x21, 0xf2, 0x94, 0x60, 0x21, 0xc8, 0x28, 0x11, 0x72, 0x57, 0xc9, 0x82, 0xd5, 0x29, 0xc9,
+	0x74, 0xf3, 0x53, 0x92, 0x69, 0x6c, 0x77, 0x66, 0x84, 0xdb, 0xa0, 0x2a, 0xf7, 0x8a, 0x3f, 0xc4,
+	0x2c, 0x20, 0xfd, 0x7a, 0x79, 0xdd, 0xda, 0x58, 0x74, 0xde, 0x96, 0x93, 0x1b, 0x10, 0xd2, 0xd6,
+	0xda, 0x69, 0x8a, 0xa0, 0x46, 0xc8, 0xb9, 0xda, 0x6e, 0xce, 0x03, 0xb6, 0x40, 0x99, 0x30, 0x46,
+	0x59, 0xbd, 0xa2, 0x8e, 0xe9, 0x39, 0xc9, 0x98, 0x52, 0xcc, 0x19, 0x53, 0xa2, 0xed, 0x6a, 0xb5,
+	0x99, 0xf5, 0xb3, 0x0a, 0x58, 0xda, 0xc9, 0x50, 0xe0, 0x4d, 0x50, 0xeb, 0x45, 0xd4, 0xdf, 0xcb,
+	0x98, 0xb2, 0x14, 0x53, 0xef, 0x4c, 0x52, 0x54, 0x55, 0xfa, 0x19, 0x45, 0xe6, 0xb5, 0xcb, 0x3b,
+	0xdb, 0x6e, 0xde, 0x07, 0x5e, 0x07, 0x0b, 0xe2, 0xc0, 0x1b, 0x62, 0x3e, 0x34, 0x1b, 0xe6, 0xe2,
+	0x24, 0x45, 0x15, 0x71, 0xb0, 0x8b, 0xf9, 0x70, 0x7e, 0x54, 0x8c, 0x8b, 0xed, 0x1a, 0x0b, 0xfc,
+	0x1a, 0x94, 0x13, 0x7c, 0x48, 0x98, 0x1a, 0x52, 0xcd, 0xf9, 0x48, 0xb6, 0xa1, 0x14, 0xf3, 0x36,
+	0x94, 0xf8, 0x1a, 0x87, 0x4b, 0xe3, 0xc0, 0x87, 0xa0, 0x2c, 0x3f, 0x33, 0xb2, 0x57, 0xe5, 0x5f,
+	0xb6, 0xcf, 0x4d, 0xb9, 0x7d, 0x64, 0x01, 0xca, 0x7f, 0x5e, 0x80, 0x12, 0xed, 0x53, 0xad, 0x25,
+	0x0d, 0x01, 0xef, 0x66, 0x4b, 0xb7, 0xac, 0x28, 0xd9, 0x3d, 0xe5, 0xd2, 0x95, 0xb5, 0xa8, 0xf8,
+	0x79, 0x2d, 0x4a, 0xb4, 0xcd, 0x36, 0x86, 0x3f, 0x59, 0x60, 0x85, 0x91, 0xc1, 0x7e, 0xdc, 0x27,
+	0x7d, 0x4f, 0x77, 0xf9, 0xd2, 0x37, 0xea, 0x8e, 0xe9, 0x72, 0x39, 0x0b, 0x6c, 0x9b, 0x6e, 0xdf,
+	0xd4, 0x19, 0x8e, 0xe3, 0x9d, 0xae, 0xed, 0xe3, 0x98, 0xf0, 0x1b, 0x0b, 0xac, 0xfa, 0x38, 0xf6,
+	0x49, 0x14, 0x91, 0xbe, 0xa7, 0x99, 0x58, 0x50, 0x4c, 0x7c, 0x71, 0x7a, 0x26, 0x56, 0x66, 0x48,
+	0x8e, 0xa1, 0xe4, 0x2d, 0x33, 0x9e, 0xe3, 0x19, 0x6c, 0xf7, 0x39, 0x4f, 0x28, 0x40, 0x95, 0xca,
+	0x57, 0xd4, 0x54, 0xb0, 0xa8, 0x2a, 0xe8, 0x9e, 0xbe, 0x02, 0xa0, 0x50, 0xb2, 0xec, 0xe6, 0x82,
+	0xe6, 0x90, 0x6d, 0x37, 0xe7, 0x61, 0xee, 0x9b, 0x00, 0xb0, 0x2b, 0x75, 0x5d, 0x41, 0x19, 0xd9,
+	0x62, 0x22, 0x1c, 0x60, 0x5f, 0xc0, 0xcb, 0xa0, 0x94, 0x7b, 0x62, 0xce, 0xca, 0x97, 0xc2, 0x3c,
+	0x2f, 0xe6, 0xa5, 0xd0, 0x4f, 0x8b, 0x52, 0x4a, 0xe7, 0x3e, 0x16, 0xd8, 0x3c, 0x2b, 0xca, 0x59,
+	0xca, 0x73, 0x67, 0x29, 0xd9, 0xae, 0x52, 0xea, 0xac, 0xce, 0xed, 0xc7, 0x47, 0x0d, 0xeb, 0xc9,
+	0x51, 0xc3, 0xfa, 0xfd, 0xa8, 0x61, 0x7d, 0xff, 0xb4, 0x51, 0x78, 0xf2, 0xb4, 0x51, 0xf8, 0xe5,
+	0x69, 0xa3, 0x70, 0xe7, 0x46, 0xae, 0xdd, 0x2d, 0xfd, 0x61, 0xaf, 0x3f, 0x34, 0x54, 0xbb, 0x01,
+	0x8d, 0x70, 0x1c, 0x64, 0x3c, 0x1c, 0xcc, 0xbf, 0xf9, 0x15, 0x0f, 0xbd, 0x8a, 0xfa, 0x54, 0x7f,
+	0xff, 0x9f, 0x01, 0x00, 0xfc, 0x3a, 0xaa, 0x71, 0x13, 0x0c, 0x00, 0x00,
 }
 
 func (this *Params) Equal(that interface{}) bool {
@@ -676,6 +904,14 @@ func (this *Params) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.AlternativeFeeUnitPrices) != len(that1.AlternativeFeeUnitPrices) {
+		return false
+	}
+	for i := range this.AlternativeFeeUnitPrices {
+		if !this.AlternativeFeeUnitPrices[i].Equal(&that1.AlternativeFeeUnitPrices[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *StringBeans) Equal(that interface{}) bool {
@@ -872,6 +1108,20 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AlternativeFeeUnitPrices) > 0 {
+		for iNdEx := len(m.AlternativeFeeUnitPrices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AlternativeFeeUnitPrices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSwingset(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.QueueMax) > 0 {
 		for iNdEx := len(m.QueueMax) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1140,6 +1390,168 @@ func (m *Egress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SmartWalletProvision) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SmartWalletProvision) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SmartWalletProvision) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintSwingset(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.FeeCharged {
+		i--
+		if m.FeeCharged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Attempts != 0 {
+		i = encodeVarintSwingset(dAtA, i, uint64(m.Attempts))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PendingSinceHeight != 0 {
+		i = encodeVarintSwingset(dAtA, i, uint64(m.PendingSinceHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.State != 0 {
+		i = encodeVarintSwingset(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSwingset(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeeCharge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeeCharge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeeCharge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.OwingBeans.Size()
+		i -= size
+		if _, err := m.OwingBeans.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSwingset(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	{
+		size := m.CancelledBeans.Size()
+		i -= size
+		if _, err := m.CancelledBeans.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSwingset(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	if len(m.RefundedCoins) > 0 {
+		for iNdEx := len(m.RefundedCoins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RefundedCoins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSwingset(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	{
+		size := m.Beans.Size()
+		i -= size
+		if _, err := m.Beans.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSwingset(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Coins) > 0 {
+		for iNdEx := len(m.Coins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Coins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSwingset(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Payer) > 0 {
+		i -= len(m.Payer)
+		copy(dAtA[i:], m.Payer)
+		i = encodeVarintSwingset(dAtA, i, uint64(len(m.Payer)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintSwingset(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintSwingset(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SwingStoreArtifact) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1262,6 +1674,12 @@ func (m *Params) Size() (n int) {
 			n += 1 + l + sovSwingset(uint64(l))
 		}
 	}
+	if len(m.AlternativeFeeUnitPrices) > 0 {
+		for _, e := range m.AlternativeFeeUnitPrices {
+			l = e.Size()
+			n += 1 + l + sovSwingset(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1353,44 +1771,111 @@ func (m *Egress) Size() (n int) {
 	return n
 }
 
-func (m *SwingStoreArtifact) Size() (n int) {
+func (m *SmartWalletProvision) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Name)
+	l = len(m.Address)
 	if l > 0 {
 		n += 1 + l + sovSwingset(uint64(l))
 	}
-	l = len(m.Data)
+	if m.State != 0 {
+		n += 1 + sovSwingset(uint64(m.State))
+	}
+	if m.PendingSinceHeight != 0 {
+		n += 1 + sovSwingset(uint64(m.PendingSinceHeight))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovSwingset(uint64(m.Attempts))
+	}
+	if m.FeeCharged {
+		n += 2
+	}
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovSwingset(uint64(l))
 	}
 	return n
 }
 
-func sovSwingset(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozSwingset(x uint64) (n int) {
-	return sovSwingset(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *CoreEvalProposal) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSwingset
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
+func (m *FeeCharge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovSwingset(uint64(m.BlockHeight))
+	}
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovSwingset(uint64(l))
+	}
+	l = len(m.Payer)
+	if l > 0 {
+		n += 1 + l + sovSwingset(uint64(l))
+	}
+	if len(m.Coins) > 0 {
+		for _, e := range m.Coins {
+			l = e.Size()
+			n += 1 + l + sovSwingset(uint64(l))
+		}
+	}
+	l = m.Beans.Size()
+	n += 1 + l + sovSwingset(uint64(l))
+	if len(m.RefundedCoins) > 0 {
+		for _, e := range m.RefundedCoins {
+			l = e.Size()
+			n += 1 + l + sovSwingset(uint64(l))
+		}
+	}
+	l = m.CancelledBeans.Size()
+	n += 1 + l + sovSwingset(uint64(l))
+	l = m.OwingBeans.Size()
+	n += 1 + l + sovSwingset(uint64(l))
+	return n
+}
+
+func (m *SwingStoreArtifact) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSwingset(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovSwingset(uint64(l))
+	}
+	return n
+}
+
+func sovSwingset(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSwingset(x uint64) (n int) {
+	return sovSwingset(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *CoreEvalProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSwingset
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
@@ -1835,6 +2320,40 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AlternativeFeeUnitPrices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AlternativeFeeUnitPrices = append(m.AlternativeFeeUnitPrices, types.Coin{})
+			if err := m.AlternativeFeeUnitPrices[len(m.AlternativeFeeUnitPrices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSwingset(dAtA[iNdEx:])
@@ -2421,6 +2940,504 @@ func (m *Egress) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SmartWalletProvision) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSwingset
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SmartWalletProvision: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SmartWalletProvision: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= SmartWalletState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingSinceHeight", wireType)
+			}
+			m.PendingSinceHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PendingSinceHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeCharged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FeeCharged = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSwingset(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeeCharge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSwingset
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeeCharge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeeCharge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payer = append(m.Payer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payer == nil {
+				m.Payer = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Coins = append(m.Coins, types.Coin{})
+			if err := m.Coins[len(m.Coins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Beans", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Beans.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefundedCoins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefundedCoins = append(m.RefundedCoins, types.Coin{})
+			if err := m.RefundedCoins[len(m.RefundedCoins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelledBeans", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CancelledBeans.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwingBeans", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwingset
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OwingBeans.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSwingset(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSwingset
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SwingStoreArtifact) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
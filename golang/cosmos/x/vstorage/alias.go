@@ -6,18 +6,29 @@ import (
 )
 
 const (
-	ModuleName = types.ModuleName
-	StoreKey   = types.StoreKey
+	ModuleName    = types.ModuleName
+	StoreKey      = types.StoreKey
+	UsageStoreKey = types.UsageStoreKey
+
+	DefaultMaxPatternMatches = keeper.DefaultMaxPatternMatches
+	DefaultMaxPatternVisits  = keeper.DefaultMaxPatternVisits
 )
 
 var (
-	NewKeeper   = keeper.NewKeeper
-	NewQuerier  = keeper.NewQuerier
-	NewStorage  = types.NewData
-	NewChildren = types.NewChildren
+	NewKeeper              = keeper.NewKeeper
+	NewQuerier             = keeper.NewQuerier
+	NewStorage             = types.NewData
+	NewChildren            = types.NewChildren
+	WithChangeManager      = keeper.WithChangeManager
+	NewSinkChangeManager   = keeper.NewSinkChangeManager
+	NewFileChangeSink      = keeper.NewFileChangeSink
+	NewMemoryChangeManager = keeper.NewMemoryChangeManager
 )
 
 type (
-	Keeper = keeper.Keeper
-	Data   = types.Data
+	Keeper        = keeper.Keeper
+	KeeperOption  = keeper.KeeperOption
+	ChangeManager = keeper.ChangeManager
+	ChangeSink    = keeper.ChangeSink
+	Data          = types.Data
 )
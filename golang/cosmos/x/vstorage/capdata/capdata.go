@@ -42,6 +42,41 @@ type CapdataRemotable struct {
 	Representation interface{}
 }
 
+// CapdataUndefined represents the JavaScript value `undefined`.
+type CapdataUndefined struct{}
+
+// CapdataSpecialNumber represents a JavaScript number that has no JSON
+// representation, identified by Name "NaN", "Infinity", or "-Infinity".
+type CapdataSpecialNumber struct {
+	Name string
+}
+
+// CapdataSymbol represents a passable JavaScript symbol by name.
+type CapdataSymbol struct {
+	Name string
+}
+
+// CapdataPromise represents a promise by its id from `slots`.
+type CapdataPromise struct {
+	Id interface{}
+}
+
+// CapdataTagged represents a tagged value such as a copySet or copyBag.
+type CapdataTagged struct {
+	Tag     string
+	Payload interface{}
+}
+
+// CapdataError represents a passable error, including any properties beyond
+// its name and message (such as "errorId") in Extra.
+type CapdataError struct {
+	Name    string
+	Message string
+	Extra   map[string]interface{}
+}
+
+var specialNumberNames = map[string]bool{"NaN": true, "Infinity": true, "-Infinity": true}
+
 func NewCapdataBigint(str string) *CapdataBigint {
 	if !validBigint.MatchString(str) {
 		return nil
@@ -54,9 +89,22 @@ func (r *CapdataRemotable) MarshalJSON() ([]byte, error) {
 	return JsonMarshal(r.Representation)
 }
 
+// CapdataValueTransformations specify how to represent decoded values that
+// otherwise hinder interchange. Decoding fails upon encountering a bigint or
+// Remotable with no transformation, and likewise reports any other kind of
+// value with no transformation as not implemented.
 type CapdataValueTransformations struct {
-	Bigint    func(*CapdataBigint) interface{}
-	Remotable func(*CapdataRemotable) interface{}
+	Bigint        func(*CapdataBigint) interface{}
+	Remotable     func(*CapdataRemotable) interface{}
+	Undefined     func(*CapdataUndefined) interface{}
+	SpecialNumber func(*CapdataSpecialNumber) interface{}
+	Symbol        func(*CapdataSymbol) interface{}
+	Promise       func(*CapdataPromise) interface{}
+	Tagged        func(*CapdataTagged) interface{}
+	Error         func(*CapdataError) interface{}
+	// HilbertRecord receives a decoded record that has its own "@qclass"
+	// property, which is ambiguous in legacy representations.
+	HilbertRecord func(map[string]interface{}) interface{}
 }
 
 // upsertCapdataRemotable either adds a new CapdataRemotable to `remotables` at the specified
@@ -131,22 +179,95 @@ func decodeCapdataLegacyValue(
 					return nil, fmt.Errorf("invalid slot iface: %q", ifaceVal)
 				}
 				return upsertCapdataRemotable(remotables, slotIndex, slots[slotIndex], iface)
-			case "hilbert":
-				fallthrough
 			case "undefined":
-				fallthrough
-			case "NaN":
-				fallthrough
-			case "Infinity":
-				fallthrough
+				if transformations.Undefined == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				return transformations.Undefined(&CapdataUndefined{}), nil
+			case "NaN", "Infinity", "-Infinity":
+				if transformations.SpecialNumber == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				return transformations.SpecialNumber(&CapdataSpecialNumber{qclass}), nil
 			case "symbol":
-				fallthrough
+				if transformations.Symbol == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				name, ok := obj["name"].(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid symbol name: %q", obj["name"])
+				}
+				return transformations.Symbol(&CapdataSymbol{name}), nil
 			case "tagged":
-				fallthrough
+				if transformations.Tagged == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				tag, ok := obj["tag"].(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid tag: %q", obj["tag"])
+				}
+				payload, err := decodeCapdataLegacyValue(obj["payload"], slots, remotables, transformations)
+				if err != nil {
+					return nil, err
+				}
+				return transformations.Tagged(&CapdataTagged{tag, payload}), nil
 			case "error":
-				fallthrough
-			case "-Infinity":
-				return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				if transformations.Error == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				capdataError := &CapdataError{Extra: map[string]interface{}{}}
+				for k, v := range obj {
+					switch k {
+					case "@qclass":
+					case "name", "message":
+						str, ok := v.(string)
+						if !ok {
+							return nil, fmt.Errorf("invalid error %s: %q", k, v)
+						}
+						if k == "name" {
+							capdataError.Name = str
+						} else {
+							capdataError.Message = str
+						}
+					default:
+						decoded, err := decodeCapdataLegacyValue(v, slots, remotables, transformations)
+						if err != nil {
+							return nil, err
+						}
+						capdataError.Extra[k] = decoded
+					}
+				}
+				return transformations.Error(capdataError), nil
+			case "hilbert":
+				// A record that has its own "@qclass" property.
+				if transformations.HilbertRecord == nil {
+					return nil, fmt.Errorf("not implemented: @qclass %q", qclass)
+				}
+				decodedObj := map[string]interface{}{}
+				if rest, ok := obj["rest"]; ok {
+					restObj, ok := rest.(map[string]interface{})
+					if !ok {
+						return nil, fmt.Errorf("invalid hilbert rest: %q", rest)
+					}
+					if _, ok := restObj["@qclass"]; ok {
+						return nil, fmt.Errorf("invalid hilbert rest with @qclass")
+					}
+					decodedRest, err := decodeCapdataLegacyValue(restObj, slots, remotables, transformations)
+					if err != nil {
+						return nil, err
+					}
+					decodedObj = decodedRest.(map[string]interface{})
+				}
+				original, ok := obj["original"]
+				if !ok {
+					return nil, fmt.Errorf("missing hilbert original")
+				}
+				decodedOriginal, err := decodeCapdataLegacyValue(original, slots, remotables, transformations)
+				if err != nil {
+					return nil, err
+				}
+				decodedObj["@qclass"] = decodedOriginal
+				return transformations.HilbertRecord(decodedObj), nil
 			default:
 				return nil, fmt.Errorf("unrecognized @qclass: %q", qclass)
 			}
@@ -182,11 +303,60 @@ func decodeCapdataSmallcapsValue(
 		}
 		return arr, nil
 	} else if encodedObj, ok := encoded.(map[string]interface{}); ok {
-		if _, ok := encodedObj["#tag"]; ok {
-			return nil, fmt.Errorf("not implemented: #tag")
+		if tagVal, ok := encodedObj["#tag"]; ok {
+			if transformations.Tagged == nil {
+				return nil, fmt.Errorf("not implemented: #tag")
+			}
+			payloadVal, ok := encodedObj["payload"]
+			if !ok || len(encodedObj) != 2 {
+				return nil, fmt.Errorf("invalid tagged record")
+			}
+			tag, err := decodeCapdataSmallcapsValue(tagVal, slots, remotables, CapdataValueTransformations{})
+			tagStr, ok := tag.(string)
+			if err != nil || !ok {
+				return nil, fmt.Errorf("invalid tag: %q", tagVal)
+			}
+			payload, err := decodeCapdataSmallcapsValue(payloadVal, slots, remotables, transformations)
+			if err != nil {
+				return nil, err
+			}
+			return transformations.Tagged(&CapdataTagged{tagStr, payload}), nil
 		}
 		if _, ok := encodedObj["#error"]; ok {
-			return nil, fmt.Errorf("not implemented: #error")
+			if transformations.Error == nil {
+				return nil, fmt.Errorf("not implemented: #error")
+			}
+			capdataError := &CapdataError{Extra: map[string]interface{}{}}
+			for encodedK, v := range encodedObj {
+				var decoded interface{}
+				var err error
+				if encodedK == "#error" || encodedK == "name" {
+					decoded, err = decodeCapdataSmallcapsValue(v, slots, remotables, CapdataValueTransformations{})
+				} else {
+					decoded, err = decodeCapdataSmallcapsValue(v, slots, remotables, transformations)
+				}
+				if err != nil {
+					return nil, err
+				}
+				switch encodedK {
+				case "#error", "name":
+					str, ok := decoded.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid error %s: %q", encodedK, v)
+					}
+					if encodedK == "name" {
+						capdataError.Name = str
+					} else {
+						capdataError.Message = str
+					}
+				default:
+					if strings.HasPrefix(encodedK, "#") {
+						return nil, fmt.Errorf("unrecognized error property: %q", encodedK)
+					}
+					capdataError.Extra[encodedK] = decoded
+				}
+			}
+			return transformations.Error(capdataError), nil
 		}
 		// We need a distinct output map to avoid reprocessing already-decoded keys.
 		decodedObj := make(map[string]interface{}, len(encodedObj))
@@ -246,11 +416,33 @@ func decodeCapdataSmallcapsValue(
 			}
 			return r, nil
 		case '#':
-			fallthrough
+			switch {
+			case str == "#undefined":
+				if transformations.Undefined == nil {
+					return nil, fmt.Errorf("not implemented: %q", str)
+				}
+				return transformations.Undefined(&CapdataUndefined{}), nil
+			case specialNumberNames[str[1:]]:
+				if transformations.SpecialNumber == nil {
+					return nil, fmt.Errorf("not implemented: %q", str)
+				}
+				return transformations.SpecialNumber(&CapdataSpecialNumber{str[1:]}), nil
+			}
+			return nil, fmt.Errorf("unrecognized special value: %q", str)
 		case '%':
-			fallthrough
+			if transformations.Symbol == nil {
+				return nil, fmt.Errorf("not implemented: %q", str)
+			}
+			return transformations.Symbol(&CapdataSymbol{str[1:]}), nil
 		case '&':
-			return nil, fmt.Errorf("not implemented: %q", str)
+			if transformations.Promise == nil {
+				return nil, fmt.Errorf("not implemented: %q", str)
+			}
+			slotIndex, err := strconv.ParseUint(str[1:], 10, 0)
+			if err != nil || slotIndex >= uint64(len(slots)) {
+				return nil, fmt.Errorf("invalid slot index: %q", str)
+			}
+			return transformations.Promise(&CapdataPromise{slots[slotIndex]}), nil
 		default:
 			if str[0] >= '!' && str[0] <= '-' {
 				return nil, fmt.Errorf("invalid smallcaps encoding prefix: %q", str[:1])
@@ -117,6 +117,13 @@ func Test_DecodeSerializedCapdata(t *testing.T) {
 			body:     `"!#escaped"`,
 			expected: `"#escaped"`,
 		},
+		{format: "legacy", label: "record with @qclass",
+			body:     `{"@qclass":"hilbert","original":"foo","rest":{"bar":{"@qclass":"hilbert","original":1}}}`,
+			expected: `{"@qclass":"foo","bar":{"@qclass":1}}`,
+			transformations: CapdataValueTransformations{
+				HilbertRecord: func(record map[string]interface{}) interface{} { return record },
+			},
+		},
 
 		// unimplemented
 		{format: "smallcaps",
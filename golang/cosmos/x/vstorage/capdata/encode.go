@@ -0,0 +1,240 @@
+package capdata
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxSafeInteger is the largest integer n such that n and n+1 are both
+// exactly representable as a JavaScript number (Number.MAX_SAFE_INTEGER).
+const maxSafeInteger = 1<<53 - 1
+
+// SlotTable assigns slot indexes to the ids of Remotables and promises,
+// in order of first appearance unless preassigned by NewSlotTable.
+// A table may be shared by multiple encodings that should agree upon slot
+// indexes, in which case each result includes every slot in the table.
+type SlotTable struct {
+	ids     []interface{}
+	indexes map[string]int
+}
+
+// NewSlotTable returns a SlotTable that assigns indexes to the provided ids
+// in order.
+func NewSlotTable(ids ...interface{}) (*SlotTable, error) {
+	table := &SlotTable{indexes: map[string]int{}}
+	for _, id := range ids {
+		key, err := table.key(id)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := table.indexes[key]; ok {
+			return nil, fmt.Errorf("duplicate slot id: %s", key)
+		}
+		table.indexes[key] = len(table.ids)
+		table.ids = append(table.ids, id)
+	}
+	return table, nil
+}
+
+// key returns the JSON text of a slot id, which distinguishes e.g. "1" from 1.
+func (table *SlotTable) key(id interface{}) (string, error) {
+	idJson, err := JsonMarshal(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid slot id: %w", err)
+	}
+	return string(idJson), nil
+}
+
+// Index returns the slot index for an id, assigning the next one if necessary.
+func (table *SlotTable) Index(id interface{}) (int, error) {
+	key, err := table.key(id)
+	if err != nil {
+		return 0, err
+	}
+	index, ok := table.indexes[key]
+	if !ok {
+		index = len(table.ids)
+		table.indexes[key] = index
+		table.ids = append(table.ids, id)
+	}
+	return index, nil
+}
+
+// Slots returns a copy of the ids in slot index order.
+func (table *SlotTable) Slots() []interface{} {
+	return append([]interface{}{}, table.ids...)
+}
+
+// encodeSmallcapsString escapes a string that would otherwise be confused
+// with an encoding of a non-string value.
+func encodeSmallcapsString(str string) string {
+	if len(str) > 0 && str[0] >= '!' && str[0] <= '-' {
+		return "!" + str
+	}
+	return str
+}
+
+// smallcapsEncoder encodes values for a single CapData body.
+type smallcapsEncoder struct {
+	slotTable *SlotTable
+	// ifaces tracks the iface (if any) already included in a reference to each
+	// slot, which is omitted from subsequent references.
+	ifaces map[int]*string
+}
+
+func (enc *smallcapsEncoder) encodeNumber(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "#NaN"
+	case math.IsInf(f, 1):
+		return "#Infinity"
+	case math.IsInf(f, -1):
+		return "#-Infinity"
+	case f == 0:
+		// Normalize -0 to 0.
+		return float64(0)
+	}
+	return f
+}
+
+func (enc *smallcapsEncoder) encodeBigint(digits string) interface{} {
+	if digits == "-0" {
+		digits = "0"
+	}
+	if digits[0] == '-' {
+		return digits
+	}
+	return "+" + digits
+}
+
+func (enc *smallcapsEncoder) encodeRemotable(r *CapdataRemotable) (interface{}, error) {
+	index, err := enc.slotTable.Index(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	encoded := "$" + strconv.Itoa(index)
+	if r.Iface == nil {
+		return encoded, nil
+	}
+	if iface, ok := enc.ifaces[index]; ok {
+		if *iface != *r.Iface {
+			return nil, fmt.Errorf("slot iface mismatch: %q", *r.Iface)
+		}
+		return encoded, nil
+	}
+	enc.ifaces[index] = r.Iface
+	return encoded + "." + *r.Iface, nil
+}
+
+func (enc *smallcapsEncoder) encode(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool:
+		return v, nil
+	case string:
+		return encodeSmallcapsString(v), nil
+	case float64:
+		return enc.encodeNumber(v), nil
+	case int:
+		return enc.encode(int64(v))
+	case int64:
+		if v > maxSafeInteger || v < -maxSafeInteger {
+			return nil, fmt.Errorf("unsafe integer %d must be a bigint", v)
+		}
+		return enc.encodeNumber(float64(v)), nil
+	case *big.Int:
+		return enc.encodeBigint(v.String()), nil
+	case *CapdataBigint:
+		if !validBigint.MatchString(v.Normalized) {
+			return nil, fmt.Errorf("invalid bigint: %q", v.Normalized)
+		}
+		return enc.encodeBigint(v.Normalized), nil
+	case []interface{}:
+		encoded := make([]interface{}, len(v))
+		for i, item := range v {
+			encodedItem, err := enc.encode(item)
+			if err != nil {
+				return nil, err
+			}
+			encoded[i] = encodedItem
+		}
+		return encoded, nil
+	case map[string]interface{}:
+		encoded := make(map[string]interface{}, len(v))
+		for _, k := range sortedKeys(v) {
+			encodedItem, err := enc.encode(v[k])
+			if err != nil {
+				return nil, err
+			}
+			encoded[encodeSmallcapsString(k)] = encodedItem
+		}
+		return encoded, nil
+	case *CapdataRemotable:
+		return enc.encodeRemotable(v)
+	case *CapdataPromise:
+		index, err := enc.slotTable.Index(v.Id)
+		if err != nil {
+			return nil, err
+		}
+		return "&" + strconv.Itoa(index), nil
+	case *CapdataUndefined:
+		return "#undefined", nil
+	case *CapdataSpecialNumber:
+		if !specialNumberNames[v.Name] {
+			return nil, fmt.Errorf("invalid special number: %q", v.Name)
+		}
+		return "#" + v.Name, nil
+	case *CapdataSymbol:
+		return "%" + v.Name, nil
+	case *CapdataTagged:
+		payload, err := enc.encode(v.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"#tag": encodeSmallcapsString(v.Tag), "payload": payload}, nil
+	case *CapdataError:
+		encoded := map[string]interface{}{
+			"#error": encodeSmallcapsString(v.Message),
+			"name":   encodeSmallcapsString(v.Name),
+		}
+		for _, k := range sortedKeys(v.Extra) {
+			if k == "name" || strings.HasPrefix(k, "#") {
+				return nil, fmt.Errorf("invalid error property: %q", k)
+			}
+			encodedItem, err := enc.encode(v.Extra[k])
+			if err != nil {
+				return nil, err
+			}
+			encoded[k] = encodedItem
+		}
+		return encoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// Encode encodes a value as canonical "smallcaps" CapData, with record keys
+// in sorted order and slot indexes assigned by slotTable (or by a new
+// SlotTable if nil).
+// In addition to JSON-compatible values (with float64 numbers), it accepts
+// Go integers within the JavaScript safe range, *big.Int and *CapdataBigint
+// bigints, and the other typed values produced by decoding with
+// PreservingTransformations.
+// cf. https://github.com/endojs/endo/blob/master/packages/marshal/src/encodeToSmallcaps.js
+func Encode(value interface{}, slotTable *SlotTable) (*Capdata, error) {
+	if slotTable == nil {
+		slotTable, _ = NewSlotTable()
+	}
+	enc := smallcapsEncoder{slotTable: slotTable, ifaces: map[int]*string{}}
+	encoded, err := enc.encode(value)
+	if err != nil {
+		return nil, err
+	}
+	bodyJson, err := JsonMarshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return &Capdata{Body: "#" + string(bodyJson), Slots: slotTable.Slots()}, nil
+}
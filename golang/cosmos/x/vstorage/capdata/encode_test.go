@@ -0,0 +1,174 @@
+package capdata
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Encode(t *testing.T) {
+	type testCase struct {
+		label string
+		value interface{}
+		body  string
+		slots []interface{}
+		// decoded is the expected result of decoding when it differs from value
+		// (e.g., for Go integers that are decoded as float64).
+		decoded     interface{}
+		errContains *string
+	}
+	foo, bar := "Alleged: Foo", "Alleged: Bar"
+	testCases := []testCase{
+		// JSON-compatible values
+		{label: "null", value: nil, body: `#null`},
+		{label: "boolean", value: true, body: `#true`},
+		{label: "number", value: 1.5, body: `#1.5`},
+		{label: "negative zero", value: math.Copysign(0, -1), body: `#0`, decoded: float64(0)},
+		{label: "int", value: 42, body: `#42`, decoded: float64(42)},
+		{label: "max safe int64", value: int64(maxSafeInteger), body: `#9007199254740991`, decoded: float64(maxSafeInteger)},
+		{label: "string", value: "foo", body: `#"foo"`},
+		{label: "empty string", value: "", body: `#""`},
+		{label: "escaped strings",
+			value: []interface{}{"!", "#undefined", "$0", "+1", "-", ".", " "},
+			body:  `#["!!","!#undefined","!$0","!+1","!-","."," "]`,
+		},
+		{label: "array", value: []interface{}{1.0, []interface{}{}, "x"}, body: `#[1,[],"x"]`},
+		{label: "record with sorted keys",
+			value: map[string]interface{}{"b": 1.0, "a": map[string]interface{}{"d": true, "c": nil}},
+			body:  `#{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{label: "record with escaped keys",
+			value: map[string]interface{}{"#tag": "x", "$": 1.0, "@qclass": "y"},
+			body:  `#{"!#tag":"x","!$":1,"@qclass":"y"}`,
+		},
+
+		// non-JSON values
+		{label: "bigint", value: NewCapdataBigint("98765432101234567890"), body: `#"+98765432101234567890"`},
+		{label: "negative bigint", value: NewCapdataBigint("-1"), body: `#"-1"`},
+		{label: "negative zero bigint", value: NewCapdataBigint("-0"), body: `#"+0"`, decoded: NewCapdataBigint("0")},
+		{label: "big.Int", value: big.NewInt(-7), body: `#"-7"`, decoded: NewCapdataBigint("-7")},
+		{label: "undefined", value: &CapdataUndefined{}, body: `#"#undefined"`},
+		{label: "NaN", value: math.NaN(), body: `#"#NaN"`, decoded: &CapdataSpecialNumber{"NaN"}},
+		{label: "Infinity", value: math.Inf(1), body: `#"#Infinity"`, decoded: &CapdataSpecialNumber{"Infinity"}},
+		{label: "special numbers",
+			value: []interface{}{&CapdataSpecialNumber{"NaN"}, &CapdataSpecialNumber{"Infinity"}, &CapdataSpecialNumber{"-Infinity"}},
+			body:  `#["#NaN","#Infinity","#-Infinity"]`,
+		},
+		{label: "symbol", value: &CapdataSymbol{"@@asyncIterator"}, body: `#"%@@asyncIterator"`},
+		{label: "remotables",
+			value: map[string]interface{}{
+				"b": &CapdataRemotable{Id: "board01", Iface: &foo},
+				"a": []interface{}{
+					&CapdataRemotable{Id: "board02"},
+					&CapdataRemotable{Id: "board01", Iface: &foo},
+					&CapdataRemotable{Id: "board03", Iface: &bar},
+				},
+			},
+			body:  `#{"a":["$0","$1.Alleged: Foo","$2.Alleged: Bar"],"b":"$1"}`,
+			slots: []interface{}{"board02", "board01", "board03"},
+		},
+		{label: "promise",
+			value: []interface{}{&CapdataPromise{"p-1"}, &CapdataRemotable{Id: "p-1"}},
+			body:  `#["&0","$0"]`,
+			slots: []interface{}{"p-1"},
+		},
+		{label: "tagged",
+			value: &CapdataTagged{"copySet", []interface{}{NewCapdataBigint("1"), "+x"}},
+			body:  `#{"#tag":"copySet","payload":["+1","!+x"]}`,
+		},
+		{label: "error",
+			value: &CapdataError{Name: "TypeError", Message: "-oops", Extra: map[string]interface{}{"errorId": "error:1"}},
+			body:  `#{"#error":"!-oops","errorId":"error:1","name":"TypeError"}`,
+		},
+
+		// invalid values
+		{label: "unsafe integer", value: int64(maxSafeInteger + 1), errContains: ptr("unsafe integer")},
+		{label: "unsupported type", value: uint8(1), errContains: ptr("unsupported value type")},
+		{label: "invalid bigint", value: &CapdataBigint{"01"}, errContains: ptr("invalid bigint")},
+		{label: "invalid special number", value: &CapdataSpecialNumber{"Zero"}, errContains: ptr("invalid special number")},
+		{label: "iface mismatch",
+			value:       []interface{}{&CapdataRemotable{Id: "a", Iface: &foo}, &CapdataRemotable{Id: "a", Iface: &bar}},
+			errContains: ptr("iface mismatch"),
+		},
+		{label: "invalid error property",
+			value:       &CapdataError{Name: "Error", Extra: map[string]interface{}{"#foo": 1.0}},
+			errContains: ptr("invalid error property"),
+		},
+	}
+	for _, desc := range testCases {
+		capdata, err := Encode(desc.value, nil)
+		if desc.errContains != nil {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		expectedSlots := desc.slots
+		if expectedSlots == nil {
+			expectedSlots = []interface{}{}
+		}
+		if capdata.Body != desc.body {
+			t.Errorf("%s: wrong body: %s", desc.label, capdata.Body)
+		}
+		if !reflect.DeepEqual(capdata.Slots, expectedSlots) {
+			t.Errorf("%s: wrong slots: %#v", desc.label, capdata.Slots)
+		}
+
+		// Verify the round trip.
+		decoded, err := DecodeSerializedCapdata(mustJsonMarshal(capdata), PreservingTransformations)
+		if err != nil {
+			t.Errorf("%s: got unexpected decoding error %v", desc.label, err)
+			continue
+		}
+		expectedDecoded := desc.decoded
+		if expectedDecoded == nil {
+			expectedDecoded = desc.value
+		}
+		expected, err := ToQclass(expectedDecoded)
+		if err != nil {
+			t.Errorf("%s: got unexpected ToQclass error %v", desc.label, err)
+			continue
+		}
+		got, err := ToQclass(decoded)
+		if err != nil {
+			t.Errorf("%s: got unexpected ToQclass error %v", desc.label, err)
+			continue
+		}
+		if mustJsonMarshal(got) != mustJsonMarshal(expected) {
+			t.Errorf("%s: wrong round-trip result: got %s, want %s", desc.label, mustJsonMarshal(got), mustJsonMarshal(expected))
+		}
+	}
+}
+
+func Test_SlotTable(t *testing.T) {
+	if _, err := NewSlotTable("a", "a"); err == nil || !strings.Contains(err.Error(), "duplicate slot id") {
+		t.Errorf("duplicate ids: got error %v", err)
+	}
+
+	// Preassigned and shared slot indexes.
+	table, err := NewSlotTable("b", 1.0)
+	if err != nil {
+		t.Fatalf("got unexpected error %v", err)
+	}
+	first, err := Encode([]interface{}{&CapdataRemotable{Id: "a"}, &CapdataRemotable{Id: "1"}}, table)
+	if err != nil {
+		t.Fatalf("got unexpected error %v", err)
+	}
+	second, err := EncodeLegacy(&CapdataRemotable{Id: 1.0}, table)
+	if err != nil {
+		t.Fatalf("got unexpected error %v", err)
+	}
+	if got, want := mustJsonMarshal(first), `{"body":"#[\"$2\",\"$3\"]","slots":["b",1,"a","1"]}`; got != want {
+		t.Errorf("wrong first encoding: got %s, want %s", got, want)
+	}
+	if got, want := mustJsonMarshal(second), `{"body":"{\"@qclass\":\"slot\",\"index\":1}","slots":["b",1,"a","1"]}`; got != want {
+		t.Errorf("wrong second encoding: got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,340 @@
+package capdata
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// The "qclass" representation of a passable value is the JSON body of its
+// legacy (non-smallcaps) CapData encoding, except that each reference to a
+// Remotable or promise includes its id from `slots` rather than an index
+// into them (as `{ "@qclass": "slot", "id": ..., "iface"?: ... }` or
+// `{ "@qclass": "promise", "id": ... }`).
+// It is lossless, unlike the representations that replace Remotables with
+// strings or objects lacking "@qclass", and so can be re-encoded as CapData.
+// cf. https://github.com/endojs/endo/blob/master/packages/marshal/src/encodeToCapData.js
+
+// PreservingTransformations retain each decoded value that otherwise hinders
+// interchange as its typed representation (e.g., *CapdataBigint or
+// *CapdataTagged), as expected by ToQclass and EncodeLegacy.
+var PreservingTransformations = CapdataValueTransformations{
+	Bigint: func(bigint *CapdataBigint) interface{} { return bigint },
+	Remotable: func(r *CapdataRemotable) interface{} {
+		repr, _ := ToQclass(r)
+		return repr
+	},
+	Undefined:     func(u *CapdataUndefined) interface{} { return u },
+	SpecialNumber: func(n *CapdataSpecialNumber) interface{} { return n },
+	Symbol:        func(s *CapdataSymbol) interface{} { return s },
+	Promise:       func(p *CapdataPromise) interface{} { return p },
+	Tagged:        func(t *CapdataTagged) interface{} { return t },
+	Error:         func(e *CapdataError) interface{} { return e },
+	HilbertRecord: func(record map[string]interface{}) interface{} { return record },
+}
+
+// sortedKeys returns the keys of a record in the order used for encoding.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// encodeQclassValue converts a value decoded with PreservingTransformations
+// into qclass form, using slotRef to represent the slot of each Remotable or
+// promise as a property of its "@qclass" record.
+// Records are visited in sorted key order so that slotRef is called in the
+// same order as the slots appear in the encoded body.
+func encodeQclassValue(value interface{}, slotRef func(id interface{}) (string, interface{}, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, string:
+		return v, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("non-finite number %v must be a CapdataSpecialNumber", v)
+		}
+		if v == 0 {
+			// Normalize -0 to 0.
+			return float64(0), nil
+		}
+		return v, nil
+	case []interface{}:
+		encoded := make([]interface{}, len(v))
+		for i, item := range v {
+			encodedItem, err := encodeQclassValue(item, slotRef)
+			if err != nil {
+				return nil, err
+			}
+			encoded[i] = encodedItem
+		}
+		return encoded, nil
+	case map[string]interface{}:
+		encoded := make(map[string]interface{}, len(v))
+		for _, k := range sortedKeys(v) {
+			if k == "@qclass" {
+				continue
+			}
+			encodedItem, err := encodeQclassValue(v[k], slotRef)
+			if err != nil {
+				return nil, err
+			}
+			encoded[k] = encodedItem
+		}
+		original, hasQclass := v["@qclass"]
+		if !hasQclass {
+			return encoded, nil
+		}
+		// Represent a record with its own "@qclass" property as a hilbert record.
+		encodedOriginal, err := encodeQclassValue(original, slotRef)
+		if err != nil {
+			return nil, err
+		}
+		hilbert := map[string]interface{}{"@qclass": "hilbert", "original": encodedOriginal}
+		if len(encoded) > 0 {
+			hilbert["rest"] = encoded
+		}
+		return hilbert, nil
+	case *CapdataBigint:
+		return map[string]interface{}{"@qclass": "bigint", "digits": v.Normalized}, nil
+	case *CapdataRemotable:
+		refKey, ref, err := slotRef(v.Id)
+		if err != nil {
+			return nil, err
+		}
+		encoded := map[string]interface{}{"@qclass": "slot", refKey: ref}
+		if v.Iface != nil {
+			encoded["iface"] = *v.Iface
+		}
+		return encoded, nil
+	case *CapdataPromise:
+		refKey, ref, err := slotRef(v.Id)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"@qclass": "promise", refKey: ref}, nil
+	case *CapdataUndefined:
+		return map[string]interface{}{"@qclass": "undefined"}, nil
+	case *CapdataSpecialNumber:
+		if !specialNumberNames[v.Name] {
+			return nil, fmt.Errorf("invalid special number: %q", v.Name)
+		}
+		return map[string]interface{}{"@qclass": v.Name}, nil
+	case *CapdataSymbol:
+		return map[string]interface{}{"@qclass": "symbol", "name": v.Name}, nil
+	case *CapdataTagged:
+		payload, err := encodeQclassValue(v.Payload, slotRef)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"@qclass": "tagged", "tag": v.Tag, "payload": payload}, nil
+	case *CapdataError:
+		encoded := map[string]interface{}{"@qclass": "error", "name": v.Name, "message": v.Message}
+		for _, k := range sortedKeys(v.Extra) {
+			if _, reserved := encoded[k]; reserved {
+				return nil, fmt.Errorf("invalid error property: %q", k)
+			}
+			encodedItem, err := encodeQclassValue(v.Extra[k], slotRef)
+			if err != nil {
+				return nil, err
+			}
+			encoded[k] = encodedItem
+		}
+		return encoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// ToQclass converts a value decoded with PreservingTransformations into its
+// qclass representation.
+func ToQclass(value interface{}) (interface{}, error) {
+	return encodeQclassValue(value, func(id interface{}) (string, interface{}, error) {
+		return "id", id, nil
+	})
+}
+
+// FromQclass converts a qclass representation (such as a response item from
+// a "qclass" CapData query) back into the typed form produced by decoding
+// with PreservingTransformations.
+func FromQclass(qclass interface{}) (interface{}, error) {
+	switch v := qclass.(type) {
+	case []interface{}:
+		decoded := make([]interface{}, len(v))
+		for i, item := range v {
+			decodedItem, err := FromQclass(item)
+			if err != nil {
+				return nil, err
+			}
+			decoded[i] = decodedItem
+		}
+		return decoded, nil
+	case map[string]interface{}:
+		qclassVal, hasQclass := v["@qclass"]
+		if !hasQclass {
+			decoded := make(map[string]interface{}, len(v))
+			for k, item := range v {
+				decodedItem, err := FromQclass(item)
+				if err != nil {
+					return nil, err
+				}
+				decoded[k] = decodedItem
+			}
+			return decoded, nil
+		}
+		qclass, ok := qclassVal.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid @qclass: %q", qclassVal)
+		}
+		switch qclass {
+		case "bigint":
+			digits, _ := v["digits"].(string)
+			bigint := NewCapdataBigint(digits)
+			if bigint == nil {
+				return nil, fmt.Errorf("invalid bigint: %q", v["digits"])
+			}
+			return bigint, nil
+		case "slot":
+			id, ok := v["id"]
+			if !ok {
+				return nil, fmt.Errorf("missing slot id")
+			}
+			r := &CapdataRemotable{Id: id}
+			if ifaceVal, ok := v["iface"]; ok {
+				iface, ok := ifaceVal.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid slot iface: %q", ifaceVal)
+				}
+				r.Iface = &iface
+			}
+			r.Representation = PreservingTransformations.Remotable(r)
+			return r, nil
+		case "promise":
+			id, ok := v["id"]
+			if !ok {
+				return nil, fmt.Errorf("missing promise id")
+			}
+			return &CapdataPromise{id}, nil
+		case "undefined":
+			return &CapdataUndefined{}, nil
+		case "NaN", "Infinity", "-Infinity":
+			return &CapdataSpecialNumber{qclass}, nil
+		case "symbol":
+			name, ok := v["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid symbol name: %q", v["name"])
+			}
+			return &CapdataSymbol{name}, nil
+		case "tagged":
+			tag, ok := v["tag"].(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid tag: %q", v["tag"])
+			}
+			payload, err := FromQclass(v["payload"])
+			if err != nil {
+				return nil, err
+			}
+			return &CapdataTagged{tag, payload}, nil
+		case "error":
+			capdataError := &CapdataError{Extra: map[string]interface{}{}}
+			for k, item := range v {
+				switch k {
+				case "@qclass":
+				case "name", "message":
+					str, ok := item.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid error %s: %q", k, item)
+					}
+					if k == "name" {
+						capdataError.Name = str
+					} else {
+						capdataError.Message = str
+					}
+				default:
+					decodedItem, err := FromQclass(item)
+					if err != nil {
+						return nil, err
+					}
+					capdataError.Extra[k] = decodedItem
+				}
+			}
+			return capdataError, nil
+		case "hilbert":
+			decoded := map[string]interface{}{}
+			if rest, ok := v["rest"]; ok {
+				restObj, ok := rest.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("invalid hilbert rest: %q", rest)
+				}
+				if _, ok := restObj["@qclass"]; ok {
+					return nil, fmt.Errorf("invalid hilbert rest with @qclass")
+				}
+				decodedRest, err := FromQclass(restObj)
+				if err != nil {
+					return nil, err
+				}
+				decoded = decodedRest.(map[string]interface{})
+			}
+			original, ok := v["original"]
+			if !ok {
+				return nil, fmt.Errorf("missing hilbert original")
+			}
+			decodedOriginal, err := FromQclass(original)
+			if err != nil {
+				return nil, err
+			}
+			decoded["@qclass"] = decodedOriginal
+			return decoded, nil
+		default:
+			return nil, fmt.Errorf("unrecognized @qclass: %q", qclass)
+		}
+	default:
+		return qclass, nil
+	}
+}
+
+// EncodeLegacy encodes a value in the typed form produced by decoding with
+// PreservingTransformations as legacy (non-smallcaps) CapData, with slot
+// indexes assigned by slotTable (or by a new SlotTable if nil).
+// Promises are indistinguishable from Remotables in the legacy encoding, so
+// each is encoded as a slot without an iface.
+func EncodeLegacy(value interface{}, slotTable *SlotTable) (*Capdata, error) {
+	if slotTable == nil {
+		slotTable, _ = NewSlotTable()
+	}
+	body, err := encodeQclassValue(value, func(id interface{}) (string, interface{}, error) {
+		index, err := slotTable.Index(id)
+		return "index", index, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Legacy encoding has no promise records.
+	body = replacePromiseRecords(body)
+	bodyJson, err := JsonMarshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Capdata{Body: string(bodyJson), Slots: slotTable.Slots()}, nil
+}
+
+// replacePromiseRecords replaces each qclass promise record with a slot record.
+func replacePromiseRecords(encoded interface{}) interface{} {
+	switch v := encoded.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = replacePromiseRecords(item)
+		}
+	case map[string]interface{}:
+		if v["@qclass"] == "promise" {
+			v["@qclass"] = "slot"
+			return v
+		}
+		for k, item := range v {
+			v[k] = replacePromiseRecords(item)
+		}
+	}
+	return encoded
+}
@@ -0,0 +1,149 @@
+package capdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Qclass(t *testing.T) {
+	type testCase struct {
+		label    string
+		body     string
+		slots    []interface{}
+		expected string
+	}
+	testCases := []testCase{
+		{label: "JSON",
+			body:     `#{"a":[1,true,null,"foo"],"b":{"c":-0}}`,
+			expected: `{"a":[1,true,null,"foo"],"b":{"c":0}}`,
+		},
+		{label: "bigint",
+			body:     `#"-98765432101234567890"`,
+			expected: `{"@qclass":"bigint","digits":"-98765432101234567890"}`,
+		},
+		{label: "remotables",
+			body:     `#["$0.Foo","$1","$0"]`,
+			slots:    []interface{}{"a", "b"},
+			expected: `[{"@qclass":"slot","id":"a","iface":"Foo"},{"@qclass":"slot","id":"b"},{"@qclass":"slot","id":"a","iface":"Foo"}]`,
+		},
+		{label: "promise",
+			body:     `#"&0"`,
+			slots:    []interface{}{"p"},
+			expected: `{"@qclass":"promise","id":"p"}`,
+		},
+		{label: "special values",
+			body:     `#["#undefined","#NaN","#Infinity","#-Infinity","%foo"]`,
+			expected: `[{"@qclass":"undefined"},{"@qclass":"NaN"},{"@qclass":"Infinity"},{"@qclass":"-Infinity"},{"@qclass":"symbol","name":"foo"}]`,
+		},
+		{label: "tagged",
+			body:     `#{"#tag":"copySet","payload":["+1","$0.Brand"]}`,
+			slots:    []interface{}{"board01"},
+			expected: `{"@qclass":"tagged","payload":[{"@qclass":"bigint","digits":"1"},{"@qclass":"slot","id":"board01","iface":"Brand"}],"tag":"copySet"}`,
+		},
+		{label: "error",
+			body:     `#{"#error":"oops","name":"TypeError","errorId":"error:1"}`,
+			expected: `{"@qclass":"error","errorId":"error:1","message":"oops","name":"TypeError"}`,
+		},
+		{label: "record with @qclass",
+			body:     `{"@qclass":"hilbert","original":"foo","rest":{"bar":{"@qclass":"hilbert","original":{"@qclass":"undefined"}}}}`,
+			expected: `{"@qclass":"hilbert","original":"foo","rest":{"bar":{"@qclass":"hilbert","original":{"@qclass":"undefined"}}}}`,
+		},
+		{label: "legacy",
+			body:     `[{"@qclass":"slot","index":0,"iface":"Foo"},{"@qclass":"bigint","digits":"1"}]`,
+			slots:    []interface{}{"a"},
+			expected: `[{"@qclass":"slot","id":"a","iface":"Foo"},{"@qclass":"bigint","digits":"1"}]`,
+		},
+	}
+	for _, desc := range testCases {
+		slots := desc.slots
+		if slots == nil {
+			slots = []interface{}{}
+		}
+		serializedCapdata := mustJsonMarshal(Capdata{Body: desc.body, Slots: slots})
+		decoded, err := DecodeSerializedCapdata(serializedCapdata, PreservingTransformations)
+		if err != nil {
+			t.Errorf("%s: got unexpected decoding error %v", desc.label, err)
+			continue
+		}
+		qclass, err := ToQclass(decoded)
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		qclassJson := mustJsonMarshal(qclass)
+		if qclassJson != desc.expected {
+			t.Errorf("%s: wrong qclass result: %s", desc.label, qclassJson)
+			continue
+		}
+
+		// Round-trip through JSON and legacy CapData.
+		var parsed interface{}
+		mustJsonUnmarshal(qclassJson, &parsed)
+		typed, err := FromQclass(parsed)
+		if err != nil {
+			t.Errorf("%s: got unexpected FromQclass error %v", desc.label, err)
+			continue
+		}
+		capdata, err := EncodeLegacy(typed, nil)
+		if err != nil {
+			t.Errorf("%s: got unexpected EncodeLegacy error %v", desc.label, err)
+			continue
+		}
+		redecoded, err := DecodeSerializedCapdata(mustJsonMarshal(capdata), PreservingTransformations)
+		if err != nil {
+			t.Errorf("%s: got unexpected error decoding %s: %v", desc.label, mustJsonMarshal(capdata), err)
+			continue
+		}
+		requalified, err := ToQclass(redecoded)
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		// Legacy CapData does not distinguish promises from Remotables.
+		expected := strings.ReplaceAll(desc.expected, `"@qclass":"promise"`, `"@qclass":"slot"`)
+		if got := mustJsonMarshal(requalified); got != expected {
+			t.Errorf("%s: wrong round-trip result via %s: %s", desc.label, mustJsonMarshal(capdata), got)
+		}
+	}
+}
+
+func Test_EncodeLegacy(t *testing.T) {
+	type testCase struct {
+		label       string
+		value       interface{}
+		expected    string
+		errContains *string
+	}
+	foo := "Foo"
+	testCases := []testCase{
+		{label: "slot order",
+			value: map[string]interface{}{
+				"b": &CapdataRemotable{Id: "y"},
+				"a": []interface{}{&CapdataRemotable{Id: "x", Iface: &foo}, &CapdataRemotable{Id: "y"}},
+			},
+			expected: `{"body":"{\"a\":[{\"@qclass\":\"slot\",\"iface\":\"Foo\",\"index\":0},{\"@qclass\":\"slot\",\"index\":1}],\"b\":{\"@qclass\":\"slot\",\"index\":1}}","slots":["x","y"]}`,
+		},
+		{label: "unsupported type",
+			value:       int64(1),
+			errContains: ptr("unsupported value type"),
+		},
+		{label: "invalid special number",
+			value:       &CapdataSpecialNumber{"Zero"},
+			errContains: ptr("invalid special number"),
+		},
+	}
+	for _, desc := range testCases {
+		capdata, err := EncodeLegacy(desc.value, nil)
+		if desc.errContains == nil {
+			if err != nil {
+				t.Errorf("%s: got unexpected error %v", desc.label, err)
+			} else if got := mustJsonMarshal(capdata); got != desc.expected {
+				t.Errorf("%s: wrong result: %s", desc.label, got)
+			}
+		} else if err == nil {
+			t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+		} else if !strings.Contains(err.Error(), *desc.errContains) {
+			t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package capdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Selector projects decoded values onto a subset of their structure,
+// as specified by a comma-separated list of paths in a small subset of
+// JSONPath (https://www.rfc-editor.org/rfc/rfc9535):
+// * `$` (required at the start of each path) selects the root value.
+// * `.name` or `['name']` selects a property of a record.
+// * `[0]` selects an element of an array, counting from the end if negative.
+// * `.*` or `[*]` selects every property of a record or element of an array.
+// * `['name', 0, ...]` selects the union of its contents.
+// For example, `$.vaults[*].debt,$.updatedAt` selects the updatedAt property
+// and the debt property of every element of the vaults property.
+// Only records and arrays can be traversed; Remotables and other decoded
+// values that are not JSON-compatible are never subdivided.
+type Selector struct {
+	paths [][]selectorStep
+}
+
+// selectorStep selects some or all children of a record or array.
+type selectorStep struct {
+	wildcard bool
+	names    map[string]bool
+	indexes  []int
+}
+
+func (step selectorStep) matchesName(name string) bool {
+	return step.wildcard || step.names[name]
+}
+
+func (step selectorStep) matchesIndex(i, length int) bool {
+	if step.wildcard {
+		return true
+	}
+	for _, index := range step.indexes {
+		if index == i || index == i-length {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorParser is a cursor over selector text.
+type selectorParser struct {
+	text string
+	pos  int
+}
+
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid selector at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *selectorParser) skipSpace() {
+	for p.pos < len(p.text) && p.text[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// consume advances past the specified prefix if present.
+func (p *selectorParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.text[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func isSelectorNameByte(c byte) bool {
+	return c == '_' || c == '-' || c == '@' || c == '$' ||
+		(c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') ||
+		c >= 0x80
+}
+
+func (p *selectorParser) parseQuoted() (string, error) {
+	quote := p.text[p.pos]
+	var sb strings.Builder
+	for p.pos++; p.pos < len(p.text); p.pos++ {
+		c := p.text[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			p.pos++
+			if p.pos >= len(p.text) {
+				return "", p.errorf("unterminated escape")
+			}
+			sb.WriteByte(p.text[p.pos])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseBracket parses the contents of a bracketed step after "[".
+func (p *selectorParser) parseBracket() (selectorStep, error) {
+	step := selectorStep{names: map[string]bool{}}
+	p.skipSpace()
+	if p.consume("*") {
+		step.wildcard = true
+		p.skipSpace()
+		if !p.consume("]") {
+			return step, p.errorf(`expected "]"`)
+		}
+		return step, nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return step, p.errorf(`expected "]"`)
+		}
+		switch c := p.text[p.pos]; {
+		case c == '\'' || c == '"':
+			name, err := p.parseQuoted()
+			if err != nil {
+				return step, err
+			}
+			step.names[name] = true
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := p.pos
+			for p.pos++; p.pos < len(p.text) && p.text[p.pos] >= '0' && p.text[p.pos] <= '9'; p.pos++ {
+			}
+			index, err := strconv.Atoi(p.text[start:p.pos])
+			if err != nil {
+				return step, p.errorf("invalid index %q", p.text[start:p.pos])
+			}
+			step.indexes = append(step.indexes, index)
+		default:
+			return step, p.errorf("unexpected %q", c)
+		}
+		p.skipSpace()
+		if p.consume("]") {
+			return step, nil
+		}
+		if !p.consume(",") {
+			return step, p.errorf(`expected "," or "]"`)
+		}
+	}
+}
+
+func (p *selectorParser) parsePath() ([]selectorStep, error) {
+	p.skipSpace()
+	if !p.consume("$") {
+		return nil, p.errorf(`expected "$"`)
+	}
+	steps := []selectorStep{}
+	for p.pos < len(p.text) {
+		switch {
+		case p.consume("."):
+			if p.consume("*") {
+				steps = append(steps, selectorStep{wildcard: true})
+				continue
+			}
+			start := p.pos
+			for p.pos < len(p.text) && isSelectorNameByte(p.text[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected property name")
+			}
+			name := p.text[start:p.pos]
+			steps = append(steps, selectorStep{names: map[string]bool{name: true}})
+		case p.consume("["):
+			step, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		default:
+			return steps, nil
+		}
+	}
+	return steps, nil
+}
+
+// ParseSelector parses selector text into a Selector.
+func ParseSelector(text string) (*Selector, error) {
+	p := &selectorParser{text: text}
+	selector := &Selector{}
+	for {
+		steps, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		selector.paths = append(selector.paths, steps)
+		p.skipSpace()
+		if p.pos == len(p.text) {
+			return selector, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf(`expected ","`)
+		}
+	}
+}
+
+// project returns the projection of value onto the remaining steps of paths,
+// and whether any of them matched. If indexed is true, each projected array is
+// represented as a record keyed by the original decimal index of each selected
+// element.
+func project(value interface{}, paths [][]selectorStep, indexed bool) (interface{}, bool) {
+	for _, steps := range paths {
+		if len(steps) == 0 {
+			return value, true
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		projected := map[string]interface{}{}
+		for k, item := range v {
+			subpaths := [][]selectorStep{}
+			for _, steps := range paths {
+				if steps[0].matchesName(k) {
+					subpaths = append(subpaths, steps[1:])
+				}
+			}
+			if len(subpaths) == 0 {
+				continue
+			}
+			if projectedItem, ok := project(item, subpaths, indexed); ok {
+				projected[k] = projectedItem
+			}
+		}
+		return projected, len(projected) > 0
+	case []interface{}:
+		projected := []interface{}{}
+		projectedByIndex := map[string]interface{}{}
+		for i, item := range v {
+			subpaths := [][]selectorStep{}
+			for _, steps := range paths {
+				if steps[0].matchesIndex(i, len(v)) {
+					subpaths = append(subpaths, steps[1:])
+				}
+			}
+			if len(subpaths) == 0 {
+				continue
+			}
+			if projectedItem, ok := project(item, subpaths, indexed); ok {
+				projected = append(projected, projectedItem)
+				projectedByIndex[strconv.Itoa(i)] = projectedItem
+			}
+		}
+		if indexed {
+			return projectedByIndex, len(projectedByIndex) > 0
+		}
+		return projected, len(projected) > 0
+	}
+	return nil, false
+}
+
+// Project returns a copy of a decoded value that is limited to the selected
+// parts of its structure (preserving the relative order of selected array
+// elements), and whether the selector matched anything at all.
+func (s *Selector) Project(value interface{}) (interface{}, bool) {
+	return project(value, s.paths, false)
+}
+
+// ProjectIndexed is like Project, but represents each selected array as a
+// record keyed by the original index of each selected element (e.g.,
+// `$.vaults[-1]` on a three-element array selects `{ "2": ... }`), for
+// consumers that identify elements by index such as flattening.
+func (s *Selector) ProjectIndexed(value interface{}) (interface{}, bool) {
+	return project(value, s.paths, true)
+}
@@ -0,0 +1,94 @@
+package capdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Selector(t *testing.T) {
+	type testCase struct {
+		selector    string
+		expected    *string
+		indexed     *string
+		errContains *string
+	}
+	input := `{
+		"updatedAt": 42,
+		"vaults": [
+			{"debt": 1, "collateral": {"brand": "ATOM", "value": 10}},
+			{"debt": 2, "collateral": {"brand": "OSMO", "value": 20}},
+			{"debt": 3}
+		],
+		"odd key": true,
+		"@qclass": null
+	}`
+	var parsedInput interface{}
+	mustJsonUnmarshal(input, &parsedInput)
+	testCases := []testCase{
+		{selector: `$`, expected: ptr(mustJsonMarshal(parsedInput))},
+		{selector: `$.updatedAt`, expected: ptr(`{"updatedAt":42}`)},
+		{selector: `$['odd key', "@qclass"]`, expected: ptr(`{"@qclass":null,"odd key":true}`)},
+		{selector: `$.vaults[0].debt`, expected: ptr(`{"vaults":[{"debt":1}]}`)},
+		{selector: `$.vaults[-1]`, expected: ptr(`{"vaults":[{"debt":3}]}`),
+			indexed: ptr(`{"vaults":{"2":{"debt":3}}}`),
+		},
+		{selector: `$.vaults[2, 0].debt`, expected: ptr(`{"vaults":[{"debt":1},{"debt":3}]}`),
+			indexed: ptr(`{"vaults":{"0":{"debt":1},"2":{"debt":3}}}`),
+		},
+		{selector: `$.vaults[*].collateral.value`,
+			expected: ptr(`{"vaults":[{"collateral":{"value":10}},{"collateral":{"value":20}}]}`),
+		},
+		{selector: `$.vaults.*.debt, $.updatedAt`,
+			expected: ptr(`{"updatedAt":42,"vaults":[{"debt":1},{"debt":2},{"debt":3}]}`),
+		},
+		{selector: `$.vaults[0].collateral,$.vaults[0].collateral.brand`,
+			expected: ptr(`{"vaults":[{"collateral":{"brand":"ATOM","value":10}}]}`),
+		},
+		{selector: `$.missing`},
+		{selector: `$.updatedAt.value`},
+		{selector: `$.vaults[3]`},
+
+		{selector: ``, errContains: ptr(`expected "$"`)},
+		{selector: `updatedAt`, errContains: ptr(`expected "$"`)},
+		{selector: `$.`, errContains: ptr("expected property name")},
+		{selector: `$.a b`, errContains: ptr(`expected ","`)},
+		{selector: `$[0`, errContains: ptr(`expected "," or "]"`)},
+		{selector: `$['a`, errContains: ptr("unterminated string")},
+		{selector: `$[a]`, errContains: ptr("unexpected")},
+		{selector: `$[-]`, errContains: ptr("invalid index")},
+	}
+	for _, desc := range testCases {
+		label := desc.selector
+		selector, err := ParseSelector(desc.selector)
+		if desc.errContains != nil {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", label, *desc.errContains)
+			} else if !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", label, err, *desc.errContains)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: got unexpected error %v", label, err)
+			continue
+		}
+		var value interface{}
+		mustJsonUnmarshal(input, &value)
+		projected, ok := selector.Project(value)
+		if desc.expected == nil {
+			if ok {
+				t.Errorf("%s: got unexpected match %s", label, mustJsonMarshal(projected))
+			}
+		} else if !ok {
+			t.Errorf("%s: got no match", label)
+		} else if got := mustJsonMarshal(projected); got != *desc.expected {
+			t.Errorf("%s: wrong result: %s", label, got)
+		}
+		if desc.indexed != nil {
+			mustJsonUnmarshal(input, &value)
+			projected, _ := selector.ProjectIndexed(value)
+			if got := mustJsonMarshal(projected); got != *desc.indexed {
+				t.Errorf("%s: wrong indexed result: %s", label, got)
+			}
+		}
+	}
+}
@@ -1,13 +1,26 @@
 package cli
 
 import (
+	"fmt"
+	"io"
+	"strconv"
+
+	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/gogo/protobuf/proto"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagMaxDepth   = "max-depth"
+	FlagLimit      = "limit"
+	FlagValues     = "values"
+	FlagStartIndex = "start-index"
+)
+
 func GetQueryCmd(storeKey string) *cobra.Command {
 	swingsetQueryCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -18,8 +31,15 @@ func GetQueryCmd(storeKey string) *cobra.Command {
 	}
 	swingsetQueryCmd.AddCommand(
 		GetCmdGetData(storeKey),
+		GetCmdGetDataWithProof(storeKey),
+		GetCmdGetDataMany(storeKey),
 		GetCmdGetChildren(storeKey),
 		GetCmdGetPath(storeKey),
+		GetCmdExport(storeKey),
+		GetCmdMatch(storeKey),
+		GetCmdDiff(storeKey),
+		GetCmdUsage(storeKey),
+		GetCmdQueue(storeKey),
 	)
 
 	return swingsetQueryCmd
@@ -55,6 +75,68 @@ func GetCmdGetData(queryRoute string) *cobra.Command {
 	return cmd
 }
 
+// GetCmdGetDataWithProof queries storage along with a proof against the app hash
+func GetCmdGetDataWithProof(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "data-with-proof <path>",
+		Short: "get data for vstorage path with a proof against the app hash",
+		Long: `get data for vstorage path with an ICS-23 proof of its store entry (or of its
+absence), which can be verified against the app hash in the header of the block
+after the reported height.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.DataWithProof(cmd.Context(), &types.QueryDataWithProofRequest{
+				Path: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdGetDataMany queries storage at many paths
+func GetCmdGetDataMany(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "data-many <path>...",
+		Short: "get data for many vstorage paths",
+		Long: `get data for many vstorage paths, all read at the same block height.
+Each entry of the result corresponds with a path in the same order and
+indicates whether data was found there.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.DataMany(cmd.Context(), &types.QueryDataManyRequest{
+				Paths: args,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetChildren queries vstorage children
 func GetCmdGetChildren(queryRoute string) *cobra.Command {
 	cmd := &cobra.Command{
@@ -64,7 +146,9 @@ func GetCmdGetChildren(queryRoute string) *cobra.Command {
 		Long: `get child path segments under vstorage path.
 When absent, path defaults to the empty root path.
 Path segments are dot-separated, so a child "baz" under path "foo.bar" has path
-"foo.bar.baz".`,
+"foo.bar.baz".
+Results are paginated, and the base64-decoded "pagination.next_key" of a
+response may be supplied as --page-key to continue where that response ended.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -78,8 +162,14 @@ Path segments are dot-separated, so a child "baz" under path "foo.bar" has path
 				path = args[0]
 			}
 
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
 			res, err := queryClient.Children(cmd.Context(), &types.QueryChildrenRequest{
-				Path: path,
+				Path:       path,
+				Pagination: pageReq,
 			})
 			if err != nil {
 				return err
@@ -90,6 +180,7 @@ Path segments are dot-separated, so a child "baz" under path "foo.bar" has path
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "children")
 	return cmd
 }
 
@@ -133,3 +224,265 @@ func GetCmdGetPath(queryRoute string) *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
+
+// GetCmdExport queries vstorage data entries under a path
+func GetCmdExport(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [path]",
+		Short: "get data entries for all descendants of vstorage path",
+		Long: `get data entries for all descendants of vstorage path.
+When absent, path defaults to the empty root path.
+Entry paths are relative to path, so an entry at path "foo.bar.baz" is exported
+from path "foo" as "bar.baz".
+Results are paginated, and the base64-decoded "pagination.next_key" of a
+response may be supplied as --page-key to continue where that response ended.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			maxDepth, err := cmd.Flags().GetUint32(FlagMaxDepth)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.Export(cmd.Context(), &types.QueryExportRequest{
+				Path:       path,
+				MaxDepth:   maxDepth,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Uint32(FlagMaxDepth, 0, "maximum number of path segments below path to include, or 0 for unlimited")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "export")
+	return cmd
+}
+
+// GetCmdMatch queries the vstorage paths that match a path pattern
+func GetCmdMatch(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "match <pattern>",
+		Short: "get the vstorage paths that match a path pattern",
+		Long: `get the vstorage paths that match a path pattern, in which each
+dot-separated segment is either a path segment, "*" to match any single
+segment, or "**" to match zero or more segments
+(e.g., "published.vaultFactory.managers.*.vaults.*").
+The number of paths per page is limited by the node.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			includeValues, err := cmd.Flags().GetBool(FlagValues)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.Match(cmd.Context(), &types.QueryMatchRequest{
+				Pattern:       args[0],
+				IncludeValues: includeValues,
+				Pagination:    pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Bool(FlagValues, false, "include the data of each matching path")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "match")
+	return cmd
+}
+
+// GetCmdDiff queries the differences in vstorage data between two heights
+func GetCmdDiff(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <from-height> <to-height> [path]",
+		Short: "get the differences in vstorage data between two block heights",
+		Long: `get the differences in vstorage data between two block heights (the later of
+which may be 0 for the latest), limited to those at or below path if present.
+The differences are written as JSON Lines in order of path depth, each being a
+["path", "value"] array for data that was added or changed, or a ["path"]
+array for data that was removed. Pages are requested until there are no more
+differences, and their size is limited by --limit and by the node.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			fromHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid from-height: %w", err)
+			}
+			toHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid to-height: %w", err)
+			}
+			path := ""
+			if len(args) > 2 {
+				path = args[2]
+			}
+			limit, err := cmd.Flags().GetUint64(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+
+			pageReq := &query.PageRequest{Limit: limit}
+			for {
+				res, err := queryClient.Diff(cmd.Context(), &types.QueryDiffRequest{
+					FromHeight: fromHeight,
+					ToHeight:   toHeight,
+					Path:       path,
+					Pagination: pageReq,
+				})
+				if err != nil {
+					return err
+				}
+				if err := writeDataDiffsJsonl(cmd.OutOrStdout(), res.Diffs); err != nil {
+					return err
+				}
+				if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+					return nil
+				}
+				pageReq.Key = res.Pagination.NextKey
+			}
+		},
+	}
+
+	cmd.Flags().Uint64(flags.FlagLimit, 0, "maximum number of differences per page, or 0 for the default")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// writeDataDiffsJsonl writes diffs as JSON Lines of ["path", "value"] for
+// added or changed data and ["path"] for removed data.
+func writeDataDiffsJsonl(w io.Writer, diffs []types.DataDiff) error {
+	return agoric.EncodeKVEntryReaderToJsonl(agoric.NewVstorageDataDiffsReader(diffs), w)
+}
+
+// GetCmdUsage queries the byte usage of accounted vstorage paths
+func GetCmdUsage(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage [path]",
+		Short: "get byte usage of vstorage paths accounted by storage quotas",
+		Long: `get byte usage of vstorage paths accounted by storage quotas.
+When present, path limits results to accounted paths at or below it.
+The usage of a path is the sum of the path length plus the value length of
+every entry with data at or below it.
+Results are paginated in path order, and the base64-decoded "pagination.next_key"
+of a response may be supplied as --page-key to continue where that response
+ended.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.Usage(cmd.Context(), &types.QueryUsageRequest{
+				Path:       path,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "usage")
+	return cmd
+}
+
+// GetCmdQueue queries the items waiting in a vstorage queue
+func GetCmdQueue(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue <path>",
+		Short: "get the items waiting in a vstorage queue",
+		Long: `get the head and tail indexes and the items waiting in a vstorage queue
+(e.g., "actionQueue" or "highPriorityQueue"), in order from the head, along with
+a description of any violation of the queue invariants.
+A nonempty "nextIndex" of a response may be supplied as --start-index to
+continue where that response ended.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			limit, err := cmd.Flags().GetUint32(FlagLimit)
+			if err != nil {
+				return err
+			}
+
+			startIndex, err := cmd.Flags().GetString(FlagStartIndex)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.Queue(cmd.Context(), &types.QueryQueueRequest{
+				Path:       args[0],
+				Limit:      limit,
+				StartIndex: startIndex,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Uint32(FlagLimit, 0, "maximum number of items to include (at most 1000), or 0 for the default")
+	cmd.Flags().String(FlagStartIndex, "", "index of the first item to include, or empty for the head")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
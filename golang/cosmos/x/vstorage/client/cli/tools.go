@@ -0,0 +1,395 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/keeper"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// GetToolsCmd returns the root command for offline vstorage tools, which
+// operate on files rather than querying a node.
+func GetToolsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Offline tools for vstorage data",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		GetCmdRepairGenesis(),
+		GetCmdDiffApplicationDB(),
+		GetCmdDump(),
+		GetCmdRestore(),
+	)
+	return cmd
+}
+
+// genesisFile is a genesis document with its decoded vstorage app state.
+type genesisFile struct {
+	doc      *tmtypes.GenesisDoc
+	appState map[string]json.RawMessage
+	state    types.GenesisState
+}
+
+func readGenesisFile(clientCtx client.Context, file string) (*genesisFile, error) {
+	genDoc, err := tmtypes.GenesisDocFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	genesis := &genesisFile{doc: genDoc}
+	if err := json.Unmarshal(genDoc.AppState, &genesis.appState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal app state: %w", err)
+	}
+	rawState, ok := genesis.appState[types.ModuleName]
+	if !ok {
+		return nil, fmt.Errorf("genesis file has no %s app state", types.ModuleName)
+	}
+	if err := clientCtx.Codec.UnmarshalJSON(rawState, &genesis.state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s app state: %w", types.ModuleName, err)
+	}
+	return genesis, nil
+}
+
+// write writes the genesis document with its updated vstorage app state to
+// the file of --output-document, or to stdout if that is empty.
+func (genesis *genesisFile) write(cmd *cobra.Command, clientCtx client.Context) error {
+	var err error
+	if genesis.appState[types.ModuleName], err = clientCtx.Codec.MarshalJSON(&genesis.state); err != nil {
+		return err
+	}
+	if genesis.doc.AppState, err = json.Marshal(genesis.appState); err != nil {
+		return err
+	}
+	bz, err := tmjson.Marshal(genesis.doc)
+	if err != nil {
+		return err
+	}
+	if bz, err = sdk.SortJSON(bz); err != nil {
+		return err
+	}
+
+	outputDocument, _ := cmd.Flags().GetString(flags.FlagOutputDocument)
+	if outputDocument == "" {
+		cmd.Println(string(bz))
+		return nil
+	}
+	return os.WriteFile(outputDocument, append(bz, '\n'), 0o644)
+}
+
+// GetCmdRepairGenesis repairs the vstorage state of an exported genesis file
+func GetCmdRepairGenesis() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repair-genesis <genesis-file>",
+		Short: "repair the vstorage state of an exported genesis file",
+		Long: `repair the vstorage state of an exported genesis file, such that importing it
+yields a store satisfying the vstorage invariants.
+Data entries with invalid or duplicate paths are dropped (preserving the last of
+any duplicates, as import would), data entries are sorted by path, and invalid
+storage quotas are dropped. Each repair is described on stderr, and the
+repaired genesis is written to stdout unless --output-document is specified.
+Store problems that genesis cannot represent (placeholders without children,
+missing ancestors, and values lacking the data prefix, which prevent export)
+must instead be repaired on chain, e.g. by RepairStoreStructure in an upgrade.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			genesis, err := readGenesisFile(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			repairs := types.RepairGenesisState(&genesis.state)
+			for _, repair := range repairs {
+				cmd.PrintErrln(repair)
+			}
+			cmd.PrintErrf("made %d repairs\n", len(repairs))
+
+			return genesis.write(cmd, clientCtx)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagOutputDocument, "", "write the repaired genesis to the given file instead of stdout")
+	return cmd
+}
+
+// applicationDB provides access to the versioned vstorage store in the
+// application database of a stopped node.
+type applicationDB struct {
+	db         dbm.DB
+	multiStore *rootmulti.Store
+	storeKey   storetypes.StoreKey
+}
+
+// openApplicationDB opens the application database in the home directory of
+// the command's server context for reading only and loads its latest version.
+// Only the goleveldb backend supports opening a database read-only.
+func openApplicationDB(cmd *cobra.Command) (*applicationDB, error) {
+	serverCtx := server.GetServerContextFromCmd(cmd)
+	dataDir := filepath.Join(serverCtx.Config.RootDir, "data")
+	if backend := server.GetAppDBBackend(serverCtx.Viper); backend != dbm.GoLevelDBBackend {
+		return nil, fmt.Errorf("cannot open %s application database read-only; only %s is supported", backend, dbm.GoLevelDBBackend)
+	}
+	db, err := dbm.NewGoLevelDBWithOpts("application", dataDir, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open application database in %s: %w", dataDir, err)
+	}
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	multiStore := rootmulti.NewStore(db, log.NewNopLogger())
+	// Loading must not upgrade the IAVL fast node index, which would write.
+	multiStore.SetIAVLDisableFastNode(true)
+	// Other stores can remain unmounted because they are not accessed.
+	multiStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	if err := multiStore.LoadLatestVersion(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &applicationDB{db: db, multiStore: multiStore, storeKey: storeKey}, nil
+}
+
+// contextAtHeight returns a context for reading the vstorage store as of
+// height, or as of the latest version if height is zero. Writes through the
+// context are discarded.
+func (adb *applicationDB) contextAtHeight(height int64) (sdk.Context, error) {
+	if height == 0 {
+		height = adb.multiStore.LastCommitID().Version
+	}
+	cms, err := adb.multiStore.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, fmt.Errorf("vstorage at height %d is not available: %w", height, err)
+	}
+	return sdk.NewContext(cms, tmproto.Header{Height: height}, false, log.NewNopLogger()), nil
+}
+
+// storeAtHeight returns a read-only view of the vstorage store as of height,
+// or as of the latest version if height is zero.
+func (adb *applicationDB) storeAtHeight(height int64) (sdk.KVStore, error) {
+	ctx, err := adb.contextAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.KVStore(adb.storeKey), nil
+}
+
+func (adb *applicationDB) Close() error {
+	return adb.db.Close()
+}
+
+// GetCmdDiffApplicationDB writes the differences in vstorage data between two
+// heights of a local application database
+func GetCmdDiffApplicationDB() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <from-height> <to-height> [path]",
+		Short: "write the differences in vstorage data between two block heights of the application database",
+		Long: `write the differences in vstorage data between two block heights (the later of
+which may be 0 for the latest) by reading the versioned vstorage store from the
+application database of the node at --home, which must not be running.
+Differences are limited to those at or below path if present, and are written
+as JSON Lines in order of path depth, each being a ["path", "value"] array for
+data that was added or changed, or a ["path"] array for data that was removed.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid from-height: %w", err)
+			}
+			toHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid to-height: %w", err)
+			}
+			path := ""
+			if len(args) > 2 {
+				path = args[2]
+			}
+			if err := types.ValidatePath(path); err != nil {
+				return err
+			}
+
+			adb, err := openApplicationDB(cmd)
+			if err != nil {
+				return err
+			}
+			defer adb.Close()
+			from, err := adb.storeAtHeight(fromHeight)
+			if err != nil {
+				return err
+			}
+			to, err := adb.storeAtHeight(toHeight)
+			if err != nil {
+				return err
+			}
+
+			// Write the differences in batches.
+			const batchSize = 1000
+			diffs := make([]types.DataDiff, 0, batchSize)
+			var writeErr error
+			_, err = keeper.DiffStoreData(from, to, path, "", 0, func(diff types.DataDiff) bool {
+				diffs = append(diffs, diff)
+				if len(diffs) < batchSize {
+					return false
+				}
+				writeErr = writeDataDiffsJsonl(cmd.OutOrStdout(), diffs)
+				diffs = diffs[:0]
+				return writeErr != nil
+			})
+			if err != nil {
+				return err
+			}
+			if writeErr != nil {
+				return writeErr
+			}
+			return writeDataDiffsJsonl(cmd.OutOrStdout(), diffs)
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdDump writes the vstorage data of a local application database
+func GetCmdDump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump [height] [path]",
+		Short: "write the vstorage data of the application database as JSON Lines",
+		Long: `write the vstorage data of the application database as JSON Lines, without
+starting the node or its controller. The versioned vstorage store is read as of
+height (or the latest if it is absent or 0) from the application database of
+the node at --home, which must not be running. Each line is a ["path", "value"]
+array of a data entry, limited to those at or below path if present, and lines
+are written to stdout unless --output-document is specified.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height := int64(0)
+			if len(args) > 0 {
+				var err error
+				if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+					return fmt.Errorf("invalid height: %w", err)
+				}
+			}
+			path := ""
+			if len(args) > 1 {
+				path = args[1]
+			}
+			if err := types.ValidatePath(path); err != nil {
+				return err
+			}
+
+			adb, err := openApplicationDB(cmd)
+			if err != nil {
+				return err
+			}
+			defer adb.Close()
+			ctx, err := adb.contextAtHeight(height)
+			if err != nil {
+				return err
+			}
+
+			// Exported paths are relative to path, so restore them.
+			entries := keeper.NewKeeper(adb.storeKey).ExportStorageFromPrefix(ctx, path)
+			if len(path) > 0 {
+				for _, entry := range entries {
+					entry.Path = path + types.PathSeparator + entry.Path
+				}
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if outputDocument, _ := cmd.Flags().GetString(flags.FlagOutputDocument); outputDocument != "" {
+				file, err := os.Create(outputDocument)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+			reader := agoric.NewVstorageDataEntriesReader(entries)
+			defer reader.Close()
+			if err := agoric.EncodeKVEntryReaderToJsonl(reader, w); err != nil {
+				return err
+			}
+			cmd.PrintErrf("dumped %d entries at height %d\n", len(entries), ctx.BlockHeight())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagOutputDocument, "", "write the data to the given file instead of stdout")
+	return cmd
+}
+
+// GetCmdRestore replaces the vstorage data of a genesis file
+func GetCmdRestore() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <dump-file> <genesis-file>",
+		Short: "replace the vstorage data of a genesis file with the contents of a dump",
+		Long: `replace the vstorage data of a genesis file with the JSON Lines of a dump
+(as written by the dump command), each of which must be a ["path", "value"]
+array with a distinct valid path. The updated genesis is written to stdout
+unless --output-document is specified.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			genesis, err := readGenesisFile(clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			reader := agoric.NewJsonlKVEntryDecoderReader(file)
+			defer reader.Close()
+			entries := []*types.DataEntry{}
+			seen := map[string]bool{}
+			for {
+				entry, err := reader.Read()
+				if errors.Is(err, io.EOF) {
+					break
+				} else if err != nil {
+					return fmt.Errorf("failed to read entry %d: %w", len(entries), err)
+				}
+				path := entry.Key()
+				if err := types.ValidatePath(path); err != nil {
+					return fmt.Errorf("entry %d: %w", len(entries), err)
+				}
+				if !entry.HasValue() {
+					return fmt.Errorf("entry %d at path %q has no value", len(entries), path)
+				}
+				if seen[path] {
+					return fmt.Errorf("entry %d has duplicate path %q", len(entries), path)
+				}
+				seen[path] = true
+				entries = append(entries, &types.DataEntry{Path: path, Value: entry.StringValue()})
+			}
+			genesis.state.Data = entries
+			cmd.PrintErrf("restored %d entries\n", len(entries))
+
+			return genesis.write(cmd, clientCtx)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagOutputDocument, "", "write the updated genesis to the given file instead of stdout")
+	return cmd
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/keeper"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// makeApplicationDB creates the application database of a node home
+// directory, committing one version of the vstorage store per block of
+// changes (in which a nil value removes data).
+func makeApplicationDB(t *testing.T, blocks []map[string]*string) string {
+	home := t.TempDir()
+	db, err := dbm.NewGoLevelDB("application", filepath.Join(home, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ms := rootmulti.NewStore(db, log.NewNopLogger())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	k := keeper.NewKeeper(storeKey)
+	for i, block := range blocks {
+		ctx := sdk.NewContext(ms, tmproto.Header{Height: int64(i + 1)}, false, log.NewNopLogger())
+		for path, value := range block {
+			if value == nil {
+				k.SetStorage(ctx, agoric.NewKVEntryWithNoValue(path))
+			} else {
+				k.SetStorage(ctx, agoric.NewKVEntry(path, *value))
+			}
+		}
+		ms.Commit()
+	}
+	return home
+}
+
+// executeToolCmd runs cmd with args against the node at home, returning its
+// stdout.
+func executeToolCmd(t *testing.T, home string, cmd *cobra.Command, args ...string) (string, error) {
+	serverCtx := server.NewDefaultContext()
+	serverCtx.Config.SetRoot(home)
+	clientCtx := client.Context{}.WithCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()))
+	ctx := context.WithValue(context.Background(), server.ServerContextKey, serverCtx)
+	ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestDiffApplicationDB(t *testing.T) {
+	home := makeApplicationDB(t, []map[string]*string{
+		{"a.b": ptr("ab"), "a.c": ptr("ac"), "d": ptr(""), "e.f.g": ptr("efg")},
+		{"a.b": ptr("ab2"), "a.c": nil, "a": ptr("a"), "d": nil, "e.h": ptr("eh")},
+		{"e.f.g": nil},
+	})
+
+	testCases := []struct {
+		label    string
+		args     []string
+		expected string
+		errors   bool
+	}{
+		{label: "one block",
+			args: []string{"1", "2"},
+			expected: `["a","a"]
+["d"]
+["a.b","ab2"]
+["a.c"]
+["e.h","eh"]
+`,
+		},
+		{label: "latest with path",
+			args: []string{"1", "0", "e"},
+			expected: `["e.h","eh"]
+["e.f.g"]
+`,
+		},
+		{label: "no differences",
+			args:     []string{"3", "3"},
+			expected: "",
+		},
+		{label: "unavailable height",
+			args:   []string{"1", "9"},
+			errors: true,
+		},
+		{label: "invalid path",
+			args:   []string{"1", "2", "a..b"},
+			errors: true,
+		},
+	}
+	for _, desc := range testCases {
+		out, err := executeToolCmd(t, home, GetCmdDiffApplicationDB(), desc.args...)
+		if desc.errors {
+			if err == nil {
+				t.Errorf("%s: got no error, want an error", desc.label)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if out != desc.expected {
+			t.Errorf("%s: got output %q, want %q", desc.label, out, desc.expected)
+		}
+	}
+}
+
+func TestDumpAndRestore(t *testing.T) {
+	home := makeApplicationDB(t, []map[string]*string{
+		{"a.b": ptr("ab"), "c": ptr("c"), "e.f.g": ptr("efg")},
+		{"a.b": ptr("ab2"), "a": ptr("a"), "c": nil},
+	})
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	// Start from a genesis with stale vstorage data.
+	stale, err := cdc.MarshalJSON(&types.GenesisState{
+		Data: []*types.DataEntry{{Path: "stale", Value: "stale"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appState, err := json.Marshal(map[string]json.RawMessage{types.ModuleName: stale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisFile := filepath.Join(t.TempDir(), "genesis.json")
+	genDoc := &tmtypes.GenesisDoc{ChainID: "test", AppState: appState}
+	if err := genDoc.SaveAs(genesisFile); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		label    string
+		args     []string
+		expected []*types.DataEntry
+	}{
+		{label: "latest",
+			args: []string{},
+			expected: []*types.DataEntry{
+				{Path: "a", Value: "a"},
+				{Path: "a.b", Value: "ab2"},
+				{Path: "e.f.g", Value: "efg"},
+			},
+		},
+		{label: "height and path",
+			args: []string{"1", "a"},
+			expected: []*types.DataEntry{
+				{Path: "a.b", Value: "ab"},
+			},
+		},
+		{label: "earlier height",
+			args: []string{"1"},
+			expected: []*types.DataEntry{
+				{Path: "c", Value: "c"},
+				{Path: "a.b", Value: "ab"},
+				{Path: "e.f.g", Value: "efg"},
+			},
+		},
+	}
+	for _, desc := range testCases {
+		dir := t.TempDir()
+		dumpFile := filepath.Join(dir, "dump.jsonl")
+		args := append(desc.args, "--"+flags.FlagOutputDocument, dumpFile)
+		if _, err := executeToolCmd(t, home, GetCmdDump(), args...); err != nil {
+			t.Errorf("%s: dump got unexpected error %v", desc.label, err)
+			continue
+		}
+
+		restoredFile := filepath.Join(dir, "genesis.json")
+		_, err := executeToolCmd(t, home, GetCmdRestore(), dumpFile, genesisFile, "--"+flags.FlagOutputDocument, restoredFile)
+		if err != nil {
+			t.Errorf("%s: restore got unexpected error %v", desc.label, err)
+			continue
+		}
+		genesis, err := readGenesisFile(client.Context{}.WithCodec(cdc), restoredFile)
+		if err != nil {
+			t.Errorf("%s: got unexpected error reading restored genesis %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(genesis.state.Data, desc.expected) {
+			t.Errorf("%s: got restored data %v, want %v", desc.label, genesis.state.Data, desc.expected)
+		}
+	}
+}
@@ -10,7 +10,8 @@ import (
 
 func NewGenesisState() *types.GenesisState {
 	return &types.GenesisState{
-		Data: []*types.DataEntry{},
+		Data:   []*types.DataEntry{},
+		Params: types.DefaultParams(),
 	}
 }
 
@@ -18,6 +19,9 @@ func ValidateGenesis(data *types.GenesisState) error {
 	if data == nil {
 		return nil
 	}
+	if err := data.Params.ValidateBasic(); err != nil {
+		return fmt.Errorf("genesis vstorage.params are invalid: %s", err)
+	}
 	for _, entry := range data.Data {
 		if err := types.ValidatePath(entry.Path); err != nil {
 			return fmt.Errorf("genesis vstorage.data entry %q has invalid path format: %s", entry.Path, err)
@@ -28,11 +32,13 @@ func ValidateGenesis(data *types.GenesisState) error {
 
 func DefaultGenesisState() *types.GenesisState {
 	return &types.GenesisState{
-		Data: []*types.DataEntry{},
+		Data:   []*types.DataEntry{},
+		Params: types.DefaultParams(),
 	}
 }
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data *types.GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
 	keeper.ImportStorage(ctx, data.Data)
 	return []abci.ValidatorUpdate{}
 }
@@ -40,5 +46,6 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data *types.GenesisState) []abc
 func ExportGenesis(ctx sdk.Context, keeper Keeper) *types.GenesisState {
 	gs := NewGenesisState()
 	gs.Data = keeper.ExportStorage(ctx)
+	gs.Params = keeper.GetParams(ctx)
 	return gs
 }
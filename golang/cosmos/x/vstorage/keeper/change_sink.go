@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FlushedChange is a change reported at the end of a block, in a form suitable
+// for indexers. It either sets the data at Path to Value, removes the data at
+// Path (Deleted), or removes the data at Path and all of its descendants
+// (Deleted and Subtree). In JSON, an empty Value and false flags are omitted.
+type FlushedChange struct {
+	BlockHeight int64  `json:"blockHeight"`
+	Path        string `json:"path"`
+	Value       string `json:"value,omitempty"`
+	Deleted     bool   `json:"deleted,omitempty"`
+	Subtree     bool   `json:"subtree,omitempty"`
+}
+
+func newFlushedChange(blockHeight int64, change *ProposedChange) FlushedChange {
+	flushed := FlushedChange{
+		BlockHeight: blockHeight,
+		Path:        change.Path,
+		Deleted:     change.Deleted,
+		Subtree:     change.Subtree,
+	}
+	if !change.Deleted {
+		flushed.Value = change.NewValue
+	}
+	return flushed
+}
+
+// ChangeSink receives the changes of each block as they are flushed, before
+// the block is committed. Because a block can be flushed again after a
+// restart (e.g., if the node stops before committing it), a sink should let
+// the changes of a later flush at the same height supersede those of an
+// earlier one.
+type ChangeSink interface {
+	WriteChanges(changes []FlushedChange) error
+}
+
+// SinkChangeManager is a BatchingChangeManager that also writes the changes it
+// reports to a ChangeSink. Failure to write is logged rather than failing the
+// block.
+type SinkChangeManager struct {
+	*BatchingChangeManager
+	sink ChangeSink
+}
+
+var _ ChangeManager = (*SinkChangeManager)(nil)
+
+func NewSinkChangeManager(sink ChangeSink) *SinkChangeManager {
+	return &SinkChangeManager{
+		BatchingChangeManager: NewBatchingChangeManager(),
+		sink:                  sink,
+	}
+}
+
+// EmitEvents emits events for the changes as BatchingChangeManager does, and
+// writes them to the sink.
+func (scm *SinkChangeManager) EmitEvents(ctx sdk.Context, k Keeper) {
+	changes := scm.FlushedChanges()
+	if len(changes) == 0 {
+		return
+	}
+	flushed := make([]FlushedChange, 0, len(changes))
+	for _, change := range changes {
+		k.EmitChange(ctx, change)
+		flushed = append(flushed, newFlushedChange(ctx.BlockHeight(), change))
+	}
+	if err := scm.sink.WriteChanges(flushed); err != nil {
+		ctx.Logger().Error("failed to write vstorage changes", "height", ctx.BlockHeight(), "err", err)
+	}
+}
+
+// FileChangeSink appends each change as a line of JSON to a local file.
+type FileChangeSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var _ ChangeSink = (*FileChangeSink)(nil)
+
+// NewFileChangeSink opens the file at filePath for appending, creating it if
+// necessary.
+func NewFileChangeSink(filePath string) (*FileChangeSink, error) {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileChangeSink{file: file}, nil
+}
+
+// WriteChanges appends the changes to the file, each on its own line, in a
+// single write.
+func (fcs *FileChangeSink) WriteChanges(changes []FlushedChange) error {
+	var buf []byte
+	for _, change := range changes {
+		bz, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, bz...), '\n')
+	}
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	if fcs.file == nil {
+		return fmt.Errorf("change sink is closed")
+	}
+	_, err := fcs.file.Write(buf)
+	return err
+}
+
+// Close closes the file, after which writes fail.
+func (fcs *FileChangeSink) Close() error {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	if fcs.file == nil {
+		return nil
+	}
+	err := fcs.file.Close()
+	fcs.file = nil
+	return err
+}
+
+// MemoryChangeSink records changes in memory, for assertions in tests.
+type MemoryChangeSink struct {
+	mu      sync.Mutex
+	changes []FlushedChange
+}
+
+var _ ChangeSink = (*MemoryChangeSink)(nil)
+
+// NewMemoryChangeManager returns a ChangeManager that records the changes it
+// reports in the returned MemoryChangeSink.
+func NewMemoryChangeManager() (*SinkChangeManager, *MemoryChangeSink) {
+	sink := &MemoryChangeSink{}
+	return NewSinkChangeManager(sink), sink
+}
+
+func (mcs *MemoryChangeSink) WriteChanges(changes []FlushedChange) error {
+	mcs.mu.Lock()
+	defer mcs.mu.Unlock()
+	mcs.changes = append(mcs.changes, changes...)
+	return nil
+}
+
+// Changes returns the changes recorded so far.
+func (mcs *MemoryChangeSink) Changes() []FlushedChange {
+	mcs.mu.Lock()
+	defer mcs.mu.Unlock()
+	return append([]FlushedChange{}, mcs.changes...)
+}
+
+// Reset discards the recorded changes.
+func (mcs *MemoryChangeSink) Reset() {
+	mcs.mu.Lock()
+	defer mcs.mu.Unlock()
+	mcs.changes = nil
+}
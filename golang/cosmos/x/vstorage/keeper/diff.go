@@ -0,0 +1,179 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// MaxDiffEntries bounds the number of differences that a single Diff request
+// can return.
+const MaxDiffEntries = 1000
+
+// MaxDiffScannedKeys bounds the number of store keys that a single Diff
+// request can compare.
+const MaxDiffScannedKeys = 100000
+
+// dataDiff returns the difference between raw store values at path, if any.
+func dataDiff(path string, fromRawValue, toRawValue []byte) (types.DataDiff, bool) {
+	oldValue, hadData := bytes.CutPrefix(fromRawValue, types.EncodedDataPrefix)
+	newValue, hasData := bytes.CutPrefix(toRawValue, types.EncodedDataPrefix)
+	diff := types.DataDiff{Path: path}
+	if hadData {
+		diff.OldValue = string(oldValue)
+	}
+	if hasData {
+		diff.NewValue = string(newValue)
+	}
+	switch {
+	case hadData && hasData:
+		if bytes.Equal(oldValue, newValue) {
+			return diff, false
+		}
+		diff.Change = types.DataDiffChanged
+	case hadData:
+		diff.Change = types.DataDiffRemoved
+	case hasData:
+		diff.Change = types.DataDiffAdded
+	default:
+		return diff, false
+	}
+	return diff, true
+}
+
+func prefixHasEntries(store sdk.KVStore, prefix []byte) bool {
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	return iterator.Valid()
+}
+
+// diffRange calls cb with each difference between the data of two stores in
+// the key range [start, end), returning true if cb does or if scan does not
+// allow comparing the next key.
+func diffRange(from, to sdk.KVStore, start, end []byte, scan func(key []byte) bool, cb func(diff types.DataDiff) (stop bool)) bool {
+	fromIterator := from.Iterator(start, end)
+	defer fromIterator.Close()
+	toIterator := to.Iterator(start, end)
+	defer toIterator.Close()
+
+	for fromIterator.Valid() || toIterator.Valid() {
+		cmp := 0
+		if !fromIterator.Valid() {
+			cmp = 1
+		} else if !toIterator.Valid() {
+			cmp = -1
+		} else {
+			cmp = bytes.Compare(fromIterator.Key(), toIterator.Key())
+		}
+		next := toIterator
+		if cmp < 0 {
+			next = fromIterator
+		}
+		if !scan(next.Key()) {
+			return true
+		}
+
+		var diff types.DataDiff
+		var isDiff bool
+		switch {
+		case cmp < 0:
+			diff, isDiff = dataDiff(types.EncodedKeyToPath(fromIterator.Key()), fromIterator.Value(), nil)
+			fromIterator.Next()
+		case cmp > 0:
+			diff, isDiff = dataDiff(types.EncodedKeyToPath(toIterator.Key()), nil, toIterator.Value())
+			toIterator.Next()
+		default:
+			diff, isDiff = dataDiff(types.EncodedKeyToPath(fromIterator.Key()), fromIterator.Value(), toIterator.Value())
+			fromIterator.Next()
+			toIterator.Next()
+		}
+		if isDiff && cb(diff) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffStoreData calls cb with each difference between the data of two stores
+// at or below path, in order of depth and then encoded key starting from
+// startPath (unless it is empty), until either cb returns true or there are no
+// more differences. The stores are typically views of the same vstorage store
+// at different heights.
+// If maxScanned is positive, it also stops before comparing more than that
+// many keys, in which case it returns the path from which to resume as a new
+// startPath.
+func DiffStoreData(from, to sdk.KVStore, path, startPath string, maxScanned int, cb func(diff types.DataDiff) (stop bool)) (resumePath string, err error) {
+	if err := types.ValidatePath(path); err != nil {
+		return "", err
+	}
+	startGeneration := 0
+	var startKey []byte
+	if len(startPath) > 0 {
+		if err := types.ValidatePath(startPath); err != nil {
+			return "", err
+		}
+		if !isCoveredBySubtree(startPath, path) {
+			return "", fmt.Errorf("start path %q is not at or below path %q", startPath, path)
+		}
+		startGeneration = strings.Count(startPath, types.PathSeparator) + 1
+		if len(path) > 0 {
+			startGeneration -= strings.Count(path, types.PathSeparator) + 1
+		}
+		startKey = types.PathToEncodedKey(startPath)
+	}
+
+	scanned := 0
+	scan := func(key []byte) bool {
+		if maxScanned > 0 && scanned >= maxScanned {
+			resumePath = types.EncodedKeyToPath(key)
+			return false
+		}
+		scanned++
+		return true
+	}
+
+	for generation := startGeneration; ; generation++ {
+		var prefix, end []byte
+		if generation == 0 {
+			// The range of only the key for path itself.
+			prefix = types.PathToEncodedKey(path)
+			end = append(append([]byte{}, prefix...), 0)
+		} else {
+			prefix = types.PathToDescendantsPrefix(path, generation)
+			end = sdk.PrefixEndBytes(prefix)
+			// Every ancestor of an entry also has an entry, so the first depth
+			// without entries in either store is deeper than both subtrees.
+			if !prefixHasEntries(from, prefix) && !prefixHasEntries(to, prefix) {
+				return "", nil
+			}
+		}
+		start := prefix
+		if generation == startGeneration && startKey != nil {
+			start = startKey
+		}
+		if diffRange(from, to, start, end, scan, cb) {
+			return resumePath, nil
+		}
+	}
+}
+
+// DiffDataAtHeights calls cb with each difference between the data at or below
+// path as of fromHeight and as of toHeight (or the current state of ctx if
+// toHeight is zero), as described by DiffStoreData.
+func (k Keeper) DiffDataAtHeights(ctx sdk.Context, fromHeight, toHeight int64, path, startPath string, maxScanned int, cb func(diff types.DataDiff) (stop bool)) (resumePath string, err error) {
+	from, err := k.GetStoreAtHeight(fromHeight)
+	if err != nil {
+		return "", err
+	}
+	to := ctx.KVStore(k.storeKey)
+	if toHeight != 0 {
+		if to, err = k.GetStoreAtHeight(toHeight); err != nil {
+			return "", err
+		}
+	}
+	return DiffStoreData(from, to, path, startPath, maxScanned, cb)
+}
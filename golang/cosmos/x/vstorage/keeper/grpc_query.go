@@ -1,17 +1,23 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	sdkmath "cosmossdk.io/math"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/capdata"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
 // Querier is used as Keeper will have duplicate methods if used directly, and gRPC names take precedence over keeper
@@ -39,13 +45,88 @@ func (k Querier) Data(c context.Context, req *types.QueryDataRequest) (*types.Qu
 	}, nil
 }
 
+// ===================================================================
+// /agoric.vstorage.Query/DataWithProof
+// ===================================================================
+
+// /agoric.vstorage.Query/DataWithProof returns data for a specified path as
+// of the query height, along with a proof of its store entry (or absence).
+func (k Querier) DataWithProof(c context.Context, req *types.QueryDataWithProofRequest) (*types.QueryDataWithProofResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	storeValue, proof, height, err := k.GetStoreValueWithProof(req.Path, ctx.BlockHeight())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	value, hasData := bytes.CutPrefix(storeValue, types.EncodedDataPrefix)
+	if !hasData {
+		value = nil
+	}
+
+	return &types.QueryDataWithProofResponse{
+		Value:      string(value),
+		Key:        types.PathToEncodedKey(req.Path),
+		StoreValue: storeValue,
+		ProofOps:   types.NewProofOps(proof),
+		Height:     height,
+	}, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/DataMany
+// ===================================================================
+
+// MaxDataManyPaths bounds the number of paths that a single DataMany request
+// can read.
+const MaxDataManyPaths = 1000
+
+// /agoric.vstorage.Query/DataMany returns data for each of specified paths.
+func (k Querier) DataMany(c context.Context, req *types.QueryDataManyRequest) (*types.QueryDataManyResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if len(req.Paths) > MaxDataManyPaths {
+		return nil, status.Errorf(codes.InvalidArgument, "too many paths: %d > %d", len(req.Paths), MaxDataManyPaths)
+	}
+	for i, path := range req.Paths {
+		if err := types.ValidatePath(path); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "paths[%d]: %s", i, err)
+		}
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries := make([]types.DataManyEntry, len(req.Paths))
+	for i, path := range req.Paths {
+		entry := k.GetEntry(ctx, path)
+		entries[i] = types.DataManyEntry{
+			Path:  path,
+			Value: entry.StringValue(),
+			Found: entry.HasValue(),
+		}
+	}
+
+	return &types.QueryDataManyResponse{
+		BlockHeight: strconv.FormatInt(ctx.BlockHeight(), 10),
+		Entries:     entries,
+	}, nil
+}
+
 // ===================================================================
 // /agoric.vstorage.Query/CapData
 // ===================================================================
 
 const (
 	// Media types.
-	JSONLines = "JSON Lines"
+	JSONLines             = "JSON Lines"
+	JSONLinesWithMetadata = "JSON Lines with metadata"
+	JSONArray             = "application/json"
+	CSV                   = "text/csv"
 
 	// CapData transformation formats.
 	FormatCapDataFlat = "flat"
@@ -53,10 +134,18 @@ const (
 	// CapData remotable value formats.
 	FormatRemotableAsObject = "object"
 	FormatRemotableAsString = "string"
+	FormatRemotableAsQclass = "qclass"
+
+	// MaxCapDataHistoryLimit bounds the number of previous StreamCells that a
+	// single CapData request can read.
+	MaxCapDataHistoryLimit = 100
 )
 
 var capDataResponseMediaTypes = map[string]string{
-	JSONLines: JSONLines,
+	JSONLines:             JSONLines,
+	JSONLinesWithMetadata: JSONLinesWithMetadata,
+	JSONArray:             JSONArray,
+	CSV:                   CSV,
 	// Default to JSON Lines.
 	"": JSONLines,
 }
@@ -68,7 +157,9 @@ var capDataTransformationFormats = map[string]string{
 var capDataRemotableValueFormats = map[string]string{
 	FormatRemotableAsObject: FormatRemotableAsObject,
 	FormatRemotableAsString: FormatRemotableAsString,
-	// No default because both formats are lossy.
+	FormatRemotableAsQclass: FormatRemotableAsQclass,
+	// No default because the choice between lossy formats and the verbose
+	// lossless one depends upon the consumer.
 }
 
 // flatten converts data into a flat structure in which each deep leaf entry is replaced with
@@ -145,7 +236,7 @@ func capdataBigintToDigits(bigint *capdata.CapdataBigint) interface{} {
 // (e.g., "[Alleged: IST brand <board007>]").
 func capdataRemotableToString(r *capdata.CapdataRemotable) interface{} {
 	iface := "Remotable"
-	if r.Iface != nil || *r.Iface != "" {
+	if r.Iface != nil && *r.Iface != "" {
 		iface = *r.Iface
 	}
 	return fmt.Sprintf("[%s <%s>]", iface, r.Id)
@@ -156,16 +247,129 @@ func capdataRemotableToString(r *capdata.CapdataRemotable) interface{} {
 // (e.g., `{ "id": "board007", "allegedName": "IST brand" }`).
 func capdataRemotableToObject(r *capdata.CapdataRemotable) interface{} {
 	iface := "Remotable"
-	if r.Iface != nil || *r.Iface != "" {
+	if r.Iface != nil && *r.Iface != "" {
 		iface = *r.Iface
 		iface, _ = strings.CutPrefix(iface, "Alleged: ")
 	}
 	return map[string]interface{}{"id": r.Id, "allegedName": iface}
 }
 
+// capDataItemWithMetadata wraps an item for JSONLinesWithMetadata.
+type capDataItemWithMetadata struct {
+	BlockHeight string      `json:"blockHeight"`
+	Index       int         `json:"index"`
+	Value       interface{} `json:"value"`
+}
+
+// csvCell represents a flattened leaf value as CSV field text,
+// leaving strings unquoted and representing other values as JSON.
+func csvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	jsonText, err := capdata.JsonMarshal(value)
+	return string(jsonText), err
+}
+
+// csvRow represents a flattened item as a map from CSV column key to value.
+func csvRow(item interface{}) map[string]interface{} {
+	row, ok := item.(map[string]interface{})
+	if !ok {
+		// A scalar item is treated like its flattening to `{ "": ... }`.
+		row = map[string]interface{}{"": item}
+	}
+	return row
+}
+
+// csvHeader returns all keys appearing in any flattened item of any of the
+// item lists (sorted for consistency), so that every CSV value of a response
+// shares the same columns.
+func csvHeader(itemLists ...[]interface{}) []string {
+	keySet := map[string]bool{}
+	for _, items := range itemLists {
+		for _, item := range items {
+			for k := range csvRow(item) {
+				keySet[k] = true
+			}
+		}
+	}
+	header := make([]string, 0, len(keySet))
+	for k := range keySet {
+		header = append(header, k)
+	}
+	sort.Strings(header)
+	return header
+}
+
+// formatCapDataItemsAsCsv represents flattened items as CSV rows, preceded by a
+// header row of the specified keys.
+func formatCapDataItemsAsCsv(header []string, items []interface{}) (string, error) {
+	rows := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		rows[i] = csvRow(item)
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for i, k := range header {
+			cell, err := csvCell(row[k])
+			if err != nil {
+				return "", err
+			}
+			record[i] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	// Return without a trailing line feed.
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// formatCapDataItems represents decoded items from a StreamCell at the
+// specified block height as a response value of the specified media type,
+// where indexes holds the position of each item in the StreamCell and
+// csvColumns holds the header keys for CSV.
+func formatCapDataItems(mediaType, blockHeight string, indexes []int, items []interface{}, csvColumns []string) (string, error) {
+	if mediaType == CSV {
+		return formatCapDataItemsAsCsv(csvColumns, items)
+	}
+
+	// A response Value is "<prefix><separator-joined items><suffix>".
+	prefix, separator, suffix := "", "\n", ""
+	if mediaType == JSONArray {
+		prefix, separator, suffix = "[", ",", "]"
+	}
+	responseItems := make([]string, len(items))
+	for i, item := range items {
+		if mediaType == JSONLinesWithMetadata {
+			item = capDataItemWithMetadata{BlockHeight: blockHeight, Index: indexes[i], Value: item}
+		}
+		jsonText, err := capdata.JsonMarshal(item)
+		if err != nil {
+			return "", err
+		}
+		responseItems[i] = string(jsonText)
+	}
+	return prefix + strings.Join(responseItems, separator) + suffix, nil
+}
+
 // /agoric.vstorage.Query/CapData returns data for a specified path,
 // interpreted as CapData in a StreamCell (auto-promoting isolated CapData
-// into a single-item StreamCell) and transformed as specified.
+// into a single-item StreamCell) and transformed as specified, optionally
+// along with StreamCells from previous blocks.
 func (k Querier) CapData(c context.Context, req *types.QueryCapDataRequest) (*types.QueryCapDataResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -176,9 +380,6 @@ func (k Querier) CapData(c context.Context, req *types.QueryCapDataRequest) (*ty
 		Bigint: capdataBigintToDigits,
 	}
 
-	// A response Value is "<prefix><separator-joined items><suffix>".
-	prefix, separator, suffix := "", "\n", ""
-
 	// Read options.
 	mediaType, ok := capDataResponseMediaTypes[req.MediaType]
 	if !ok {
@@ -188,13 +389,85 @@ func (k Querier) CapData(c context.Context, req *types.QueryCapDataRequest) (*ty
 	if !ok {
 		return nil, status.Error(codes.InvalidArgument, "invalid item_format")
 	}
-	switch remotableFormat, ok := capDataRemotableValueFormats[req.RemotableValueFormat]; {
+	if mediaType == CSV && transformation != FormatCapDataFlat {
+		return nil, status.Error(codes.InvalidArgument, "media_type text/csv requires item_format flat")
+	}
+	remotableFormat, ok := capDataRemotableValueFormats[req.RemotableValueFormat]
+	switch {
 	case !ok:
 		return nil, status.Error(codes.InvalidArgument, "invalid remotable_value_format")
 	case remotableFormat == FormatRemotableAsObject:
 		valueTransformations.Remotable = capdataRemotableToObject
 	case remotableFormat == FormatRemotableAsString:
 		valueTransformations.Remotable = capdataRemotableToString
+	case remotableFormat == FormatRemotableAsQclass:
+		// Preserve every value for explicit representation by capdata.ToQclass.
+		valueTransformations = capdata.PreservingTransformations
+	}
+
+	if req.HistoryLimit > MaxCapDataHistoryLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "history_limit must not exceed %d", MaxCapDataHistoryLimit)
+	}
+	var selector *capdata.Selector
+	if req.Selector != "" {
+		var err error
+		selector, err = capdata.ParseSelector(req.Selector)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	// decodedCell holds the decoded items of a StreamCell and their positions.
+	type decodedCell struct {
+		blockHeight string
+		indexes     []int
+		items       []interface{}
+	}
+
+	// decodeCell decodes and transforms each StreamCell value.
+	decodeCell := func(cell StreamCell) (decodedCell, error) {
+		decoded := decodedCell{
+			blockHeight: cell.BlockHeight,
+			indexes:     make([]int, 0, len(cell.Values)),
+			items:       make([]interface{}, 0, len(cell.Values)),
+		}
+		for i, capDataJson := range cell.Values {
+			item, err := capdata.DecodeSerializedCapdata(capDataJson, valueTransformations)
+			if err != nil {
+				return decoded, status.Error(codes.FailedPrecondition, err.Error())
+			}
+			if remotableFormat == FormatRemotableAsQclass {
+				item, err = capdata.ToQclass(item)
+				if err != nil {
+					return decoded, status.Error(codes.FailedPrecondition, err.Error())
+				}
+			}
+			if selector != nil {
+				project := selector.Project
+				if transformation == FormatCapDataFlat {
+					// Keep the original index of each array element in its key.
+					project = selector.ProjectIndexed
+				}
+				projected, ok := project(item)
+				if !ok {
+					continue
+				}
+				item = projected
+			}
+			if transformation == FormatCapDataFlat {
+				flattened := map[string]interface{}{}
+				if err := flatten(item, flattened, "", true); err != nil {
+					return decoded, status.Error(codes.Internal, err.Error())
+				}
+				// Replace the item, unless it was a scalar that "flattened" to `{ "": ... }`.
+				if _, singleton := flattened[""]; !singleton {
+					item = flattened
+				}
+			}
+			decoded.indexes = append(decoded.indexes, i)
+			decoded.items = append(decoded.items, item)
+		}
+		return decoded, nil
 	}
 
 	// Read data, auto-upgrading a standalone value to a single-value StreamCell.
@@ -209,37 +482,93 @@ func (k Querier) CapData(c context.Context, req *types.QueryCapDataRequest) (*ty
 		cell = StreamCell{Values: []string{value}}
 	}
 
-	// Format each StreamCell value.
-	responseItems := make([]string, len(cell.Values))
-	for i, capDataJson := range cell.Values {
-		item, err := capdata.DecodeSerializedCapdata(capDataJson, valueTransformations)
+	decoded, err := decodeCell(cell)
+	if err != nil {
+		return nil, err
+	}
+	decodedCells := []decodedCell{decoded}
+	if req.HistoryLimit > 0 && cell.BlockHeight != "" {
+		history, err := k.capDataHistory(ctx, req.Path, cell.BlockHeight, int(req.HistoryLimit))
 		if err != nil {
-			return nil, status.Error(codes.FailedPrecondition, err.Error())
+			return nil, err
 		}
-		if transformation == FormatCapDataFlat {
-			flattened := map[string]interface{}{}
-			if err := flatten(item, flattened, "", true); err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
-			// Replace the item, unless it was a scalar that "flattened" to `{ "": ... }`.
-			if _, singleton := flattened[""]; !singleton {
-				item = flattened
-			}
-		}
-		switch mediaType {
-		case JSONLines:
-			jsonText, err := capdata.JsonMarshal(item)
+		for _, prevCell := range history {
+			decoded, err := decodeCell(prevCell)
 			if err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
+				return nil, err
 			}
-			responseItems[i] = string(jsonText)
+			decodedCells = append(decodedCells, decoded)
 		}
 	}
 
-	return &types.QueryCapDataResponse{
-		BlockHeight: cell.BlockHeight,
-		Value:       prefix + strings.Join(responseItems, separator) + suffix,
-	}, nil
+	// Every CSV value shares one header, for concatenation.
+	var csvColumns []string
+	if mediaType == CSV {
+		itemLists := make([][]interface{}, len(decodedCells))
+		for i, decoded := range decodedCells {
+			itemLists[i] = decoded.items
+		}
+		csvColumns = csvHeader(itemLists...)
+	}
+
+	formattedCells := make([]*types.CapDataCell, len(decodedCells))
+	for i, decoded := range decodedCells {
+		formatted, err := formatCapDataItems(mediaType, decoded.blockHeight, decoded.indexes, decoded.items, csvColumns)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		formattedCells[i] = &types.CapDataCell{
+			BlockHeight: decoded.blockHeight,
+			Value:       formatted,
+		}
+	}
+
+	response := &types.QueryCapDataResponse{
+		BlockHeight: formattedCells[0].BlockHeight,
+		Value:       formattedCells[0].Value,
+	}
+	if req.HistoryLimit > 0 && cell.BlockHeight != "" {
+		response.History = formattedCells[1:]
+	}
+	return response, nil
+}
+
+// capDataHistory returns up to limit StreamCells of path that precede the one
+// at blockHeight, most recent first. It reads previous StreamCells from the
+// history ring of the path (if any), then walks back through older ones,
+// reading each from the state as of the block before its successor was
+// written.
+func (k Querier) capDataHistory(ctx sdk.Context, path, blockHeight string, limit int) ([]StreamCell, error) {
+	history := []StreamCell{}
+	successorHeight, err := strconv.ParseInt(blockHeight, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	for _, prevCell := range k.GetRetainedStreamCells(ctx, path, successorHeight) {
+		if len(history) >= limit {
+			break
+		}
+		history = append(history, prevCell)
+		// GetRetainedStreamCells guarantees a valid height.
+		successorHeight, _ = strconv.ParseInt(prevCell.BlockHeight, 10, 64)
+	}
+	for len(history) < limit && successorHeight > 1 {
+		entry, err := k.GetEntryAtHeight(path, successorHeight-1)
+		if err != nil || !entry.HasValue() {
+			// The state is unavailable or has no data.
+			break
+		}
+		var prevCell StreamCell
+		_ = json.Unmarshal([]byte(entry.StringValue()), &prevCell)
+		prevHeight, err := strconv.ParseInt(prevCell.BlockHeight, 10, 64)
+		if err != nil || prevHeight >= successorHeight {
+			// The data is not a StreamCell from an earlier block.
+			break
+		}
+		history = append(history, prevCell)
+		successorHeight = prevHeight
+	}
+	return history, nil
 }
 
 // ===================================================================
@@ -250,15 +579,342 @@ func (k Querier) CapData(c context.Context, req *types.QueryCapDataRequest) (*ty
 // that exist immediately underneath a specified path, including
 // those corresponding with "empty non-terminals" having children
 // but no data of their own.
+// Results are paginated when the request includes pagination, and otherwise
+// include every child (for compatibility with clients that predate pagination).
 func (k Querier) Children(c context.Context, req *types.QueryChildrenRequest) (*types.QueryChildrenResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	ctx := sdk.UnwrapSDKContext(c)
 
-	children := k.GetChildren(ctx, req.Path)
+	if req.Pagination == nil {
+		children := k.GetChildren(ctx, req.Path)
+		return &types.QueryChildrenResponse{
+			Children: children.Children,
+		}, nil
+	}
+
+	children, pageResponse, err := k.GetChildrenPage(ctx, req.Path, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	return &types.QueryChildrenResponse{
-		Children: children.Children,
+		Children:   children.Children,
+		Pagination: pageResponse,
+	}, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/Export
+// ===================================================================
+
+// /agoric.vstorage.Query/Export returns the data entries of all descendants
+// of a specified path (optionally limited in depth), with paths relative to
+// that path.
+func (k Querier) Export(c context.Context, req *types.QueryExportRequest) (*types.QueryExportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries, pageResponse, err := k.ExportStoragePageFromPrefix(ctx, req.Path, req.MaxDepth, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryExportResponse{
+		Entries:    entries,
+		Pagination: pageResponse,
+	}, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/Diff
+// ===================================================================
+
+// /agoric.vstorage.Query/Diff returns the differences in data at or below a
+// specified path between two block heights.
+func (k Querier) Diff(c context.Context, req *types.QueryDiffRequest) (*types.QueryDiffResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.FromHeight <= 0 || req.ToHeight < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid heights %d to %d", req.FromHeight, req.ToHeight)
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := uint64(query.DefaultLimit)
+	startPath := ""
+	if page := req.Pagination; page != nil {
+		if page.Offset > 0 || page.CountTotal || page.Reverse {
+			return nil, status.Error(codes.InvalidArgument, "pagination supports only key and limit")
+		}
+		if page.Limit > 0 {
+			limit = page.Limit
+		}
+		startPath = string(page.Key)
+		if err := types.ValidatePath(startPath); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(startPath) > 0 && !isCoveredBySubtree(startPath, req.Path) {
+			return nil, status.Errorf(codes.InvalidArgument, "pagination key %q is not at or below path %q", startPath, req.Path)
+		}
+	}
+	if limit > MaxDiffEntries {
+		limit = MaxDiffEntries
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	diffs := []types.DataDiff{}
+	var nextKey []byte
+	resumePath, err := k.DiffDataAtHeights(ctx, req.FromHeight, req.ToHeight, req.Path, startPath, MaxDiffScannedKeys, func(diff types.DataDiff) bool {
+		if uint64(len(diffs)) >= limit {
+			nextKey = []byte(diff.Path)
+			return true
+		}
+		diffs = append(diffs, diff)
+		return false
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if len(resumePath) > 0 {
+		// The comparison was cut short before finding enough differences.
+		nextKey = []byte(resumePath)
+	}
+
+	return &types.QueryDiffResponse{
+		Diffs:      diffs,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/Match
+// ===================================================================
+
+// /agoric.vstorage.Query/Match returns the paths matching a specified path
+// pattern, optionally with their data.
+func (k Querier) Match(c context.Context, req *types.QueryMatchRequest) (*types.QueryMatchResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	pattern, err := types.ParsePathPattern(req.Pattern)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := uint64(query.DefaultLimit)
+	startPath := ""
+	if page := req.Pagination; page != nil {
+		if page.Offset > 0 || page.CountTotal || page.Reverse {
+			return nil, status.Error(codes.InvalidArgument, "pagination supports only key and limit")
+		}
+		if page.Limit > 0 {
+			limit = page.Limit
+		}
+		startPath = string(page.Key)
+		if err := types.ValidatePath(startPath); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if max := uint64(k.MaxPatternMatches()); limit > max {
+		limit = max
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries := []types.DataManyEntry{}
+	var nextKey []byte
+	resumePath := k.IteratePathPatternMatches(ctx, pattern, startPath, k.MaxPatternVisits(), func(path string) bool {
+		if uint64(len(entries)) >= limit {
+			nextKey = []byte(path)
+			return true
+		}
+		entry := types.DataManyEntry{Path: path}
+		if req.IncludeValues {
+			data := k.GetEntry(ctx, path)
+			entry.Value = data.StringValue()
+			entry.Found = data.HasValue()
+		}
+		entries = append(entries, entry)
+		return false
+	})
+	if len(resumePath) > 0 {
+		// The search was cut short before finding enough matches.
+		nextKey = []byte(resumePath)
+	}
+
+	return &types.QueryMatchResponse{
+		Entries:    entries,
+		Pagination: &query.PageResponse{NextKey: nextKey},
 	}, nil
 }
+
+// ===================================================================
+// /agoric.vstorage.Query/Usage
+// ===================================================================
+
+// /agoric.vstorage.Query/Usage returns the byte usage of accounted paths at or
+// below a specified path.
+func (k Querier) Usage(c context.Context, req *types.QueryUsageRequest) (*types.QueryUsageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := uint64(query.DefaultLimit)
+	startPath := ""
+	if page := req.Pagination; page != nil {
+		if page.Offset > 0 || page.CountTotal || page.Reverse {
+			return nil, status.Error(codes.InvalidArgument, "pagination supports only key and limit")
+		}
+		if page.Limit > 0 {
+			limit = page.Limit
+		}
+		startPath = string(page.Key)
+		if err := types.ValidatePath(startPath); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if limit > MaxUsageEntries {
+		limit = MaxUsageEntries
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	usages, nextPath, err := k.GetStorageUsages(ctx, req.Path, startPath, int(limit), MaxUsageScannedEntries)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	var nextKey []byte
+	if len(nextPath) > 0 {
+		nextKey = []byte(nextPath)
+	}
+	return &types.QueryUsageResponse{
+		Usages:     usages,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/Queue
+// ===================================================================
+
+// MaxQueueItems bounds the number of items that a single Queue request can
+// return.
+const MaxQueueItems = 1000
+
+// /agoric.vstorage.Query/Queue returns the bounds and items of a queue,
+// describing rather than failing upon any violation of its invariants.
+func (k Querier) Queue(c context.Context, req *types.QueryQueueRequest) (*types.QueryQueueResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "queue path must not be empty")
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := uint32(query.DefaultLimit)
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+	if limit > MaxQueueItems {
+		limit = MaxQueueItems
+	}
+	startIndex := sdkmath.ZeroInt()
+	if req.StartIndex != "" {
+		index, ok := sdkmath.NewIntFromString(req.StartIndex)
+		if !ok || index.IsNegative() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid start index %q", req.StartIndex)
+		}
+		startIndex = index
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	head, tail, err := k.GetQueueBounds(ctx, req.Path)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	res := &types.QueryQueueResponse{
+		Head:  head.String(),
+		Tail:  tail.String(),
+		Items: []types.QueueItem{},
+	}
+	if err := k.CheckQueueInvariants(ctx, req.Path); err != nil {
+		res.InvariantViolation = err.Error()
+	}
+	// A missing item is already described as an invariant violation.
+	_ = k.IterateQueueFrom(ctx, req.Path, startIndex, func(item QueueItem) bool {
+		if uint32(len(res.Items)) >= limit {
+			res.NextIndex = item.Index.String()
+			return true
+		}
+		res.Items = append(res.Items, types.QueueItem{
+			Index: item.Index.String(),
+			Value: item.Value,
+		})
+		return false
+	})
+
+	return res, nil
+}
+
+// ===================================================================
+// /agoric.vstorage.Query/WatchPath
+// ===================================================================
+
+// /agoric.vstorage.Query/WatchPath streams the changes at or below a
+// specified path as they are flushed at the end of each block.
+// Because it is fed from memory rather than from a query context, it is only
+// served directly over gRPC (not over ABCI queries or REST).
+func (k Querier) WatchPath(req *types.QueryWatchPathRequest, stream types.Query_WatchPathServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := types.ValidatePath(req.Path); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.FromHeight < 0 {
+		return status.Error(codes.InvalidArgument, "from_height must not be negative")
+	}
+
+	sub, err := k.WatchChanges(req.Path, req.FromHeight)
+	if err != nil {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	defer k.UnwatchChanges(sub)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case block, ok := <-sub.C:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "subscriber fell behind; resume from height %d", sub.ResumeHeight())
+			}
+			for _, change := range block.Changes {
+				err := stream.Send(&types.QueryWatchPathResponse{
+					BlockHeight: block.BlockHeight,
+					Path:        change.Path,
+					OldValue:    change.ValueFromLastBlock,
+					NewValue:    change.NewValue,
+					Deleted:     change.Deleted,
+					Subtree:     change.Subtree,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// maxReportedProblems limits the size of an invariant message.
+const maxReportedProblems = 20
+
+// RegisterInvariants registers all vstorage invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "store-structure", StoreStructureInvariant(k))
+}
+
+// StoreStructureInvariant checks that the raw store is consistent with the
+// encoding of paths (see types/path_keys.go).
+func StoreStructureInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		problems, count := k.CheckStoreStructure(ctx, maxReportedProblems)
+		msg := fmt.Sprintf("found %d problems\n", count)
+		for _, problem := range problems {
+			msg += "\t" + problem + "\n"
+		}
+		if count > len(problems) {
+			msg += fmt.Sprintf("\t(and %d more)\n", count-len(problems))
+		}
+		return sdk.FormatInvariant(types.ModuleName, "store-structure", msg), count > 0
+	}
+}
+
+// CheckStoreStructure walks the entire store to find entries that violate the
+// encoding of paths, returning descriptions of up to maxProblems of them (or
+// all of them if maxProblems is zero) along with the total count:
+//   - every key must round-trip through EncodedKeyToPath and PathToEncodedKey
+//   - every value must be a placeholder (EncodedNoDataValue) or start with
+//     EncodedDataPrefix
+//   - every placeholder must have children
+//   - every entry other than the root must have a parent entry, such that all
+//     of its ancestors exist
+func (k Keeper) CheckStoreStructure(ctx sdk.Context, maxProblems int) (problems []string, count int) {
+	store := ctx.KVStore(k.storeKey)
+	problems = []string{}
+	report := func(format string, args ...interface{}) {
+		count++
+		if maxProblems == 0 || len(problems) < maxProblems {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key, rawValue := iterator.Key(), iterator.Value()
+		if !bytes.Contains(key, types.EncodedKeySeparator) {
+			report("key %q has no separator", key)
+			continue
+		}
+		path := types.EncodedKeyToPath(key)
+		if err := types.ValidatePath(path); err != nil {
+			report("key %q has invalid path: %s", key, err)
+			continue
+		}
+		if encodedKey := types.PathToEncodedKey(path); !bytes.Equal(encodedKey, key) {
+			report("key %q for path %q should be %q", key, path, encodedKey)
+			continue
+		}
+
+		if bytes.Equal(rawValue, types.EncodedNoDataValue) {
+			if !k.HasChildren(ctx, path) {
+				report("placeholder at path %q has no children", path)
+			}
+		} else if !bytes.HasPrefix(rawValue, types.EncodedDataPrefix) {
+			report("value at path %q lacks the data prefix", path)
+		}
+
+		if len(path) > 0 {
+			parent := ""
+			if i := strings.LastIndex(path, types.PathSeparator); i >= 0 {
+				parent = path[:i]
+			}
+			if !k.HasEntry(ctx, parent) {
+				report("entry at path %q has no parent entry", path)
+			}
+		}
+	}
+	return problems, count
+}
+
+// RepairStoreStructure rewrites the raw store to fix every violation reported
+// by CheckStoreStructure, and returns a description of each repair. It is
+// meant for a chain upgrade handler, because the store of a running chain can
+// only be changed in consensus (and an exported genesis cannot represent
+// these violations, or even be exported if a value lacks the data prefix):
+//   - an entry whose key does not round-trip is moved to the encoded key of
+//     its path if that is valid and unused, and is otherwise dropped
+//   - a value without the data prefix is preserved as data by adding it
+//   - a missing ancestor of an entry is added as a placeholder
+//   - a placeholder without children is dropped
+//
+// Any recorded storage usage is discarded, to be recomputed on demand.
+func (k Keeper) RepairStoreStructure(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+	repairs := []string{}
+	report := func(format string, args ...interface{}) {
+		repairs = append(repairs, fmt.Sprintf(format, args...))
+	}
+
+	// Collect entries before changing any, so as not to disturb the iteration.
+	type rawEntry struct {
+		key, value []byte
+	}
+	var entries []rawEntry
+	iterator := sdk.KVStorePrefixIterator(store, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		entries = append(entries, rawEntry{iterator.Key(), iterator.Value()})
+	}
+	iterator.Close()
+
+	// Fix keys and values.
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		key, value := entry.key, entry.value
+		if !bytes.Equal(value, types.EncodedNoDataValue) && !bytes.HasPrefix(value, types.EncodedDataPrefix) {
+			value = append(append([]byte{}, types.EncodedDataPrefix...), value...)
+			report("added the data prefix to the value of key %q", key)
+			store.Set(key, value)
+		}
+		var path string
+		if bytes.Contains(key, types.EncodedKeySeparator) {
+			path = types.EncodedKeyToPath(key)
+		}
+		if !bytes.Contains(key, types.EncodedKeySeparator) || types.ValidatePath(path) != nil {
+			report("dropped key %q without a valid path", key)
+			store.Delete(key)
+			continue
+		}
+		if encodedKey := types.PathToEncodedKey(path); !bytes.Equal(encodedKey, key) {
+			store.Delete(key)
+			if store.Has(encodedKey) {
+				report("dropped key %q for path %q, which already has an entry", key, path)
+				continue
+			}
+			report("moved key %q for path %q to %q", key, path, encodedKey)
+			store.Set(encodedKey, value)
+		}
+		paths = append(paths, path)
+	}
+
+	// Add missing ancestors.
+	for _, path := range paths {
+		for child := path; len(child) > 0; {
+			parent := ""
+			if i := strings.LastIndex(child, types.PathSeparator); i >= 0 {
+				parent = child[:i]
+			}
+			encodedKey := types.PathToEncodedKey(parent)
+			if store.Has(encodedKey) {
+				break
+			}
+			report("added a placeholder for path %q", parent)
+			store.Set(encodedKey, types.EncodedNoDataValue)
+			child = parent
+		}
+	}
+
+	// Drop placeholders without children, deepest first so that dropping
+	// one can leave its parent to be dropped too.
+	sort.SliceStable(paths, func(i, j int) bool {
+		return pathDepth(paths[i]) > pathDepth(paths[j])
+	})
+	for _, path := range paths {
+		encodedKey := types.PathToEncodedKey(path)
+		if bytes.Equal(store.Get(encodedKey), types.EncodedNoDataValue) && !k.HasChildren(ctx, path) {
+			report("dropped placeholder at path %q without children", path)
+			store.Delete(encodedKey)
+		}
+	}
+
+	if k.hasStorageQuotas() && len(repairs) > 0 {
+		k.discardRecordedUsages(ctx)
+	}
+	return repairs
+}
@@ -9,10 +9,15 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	db "github.com/tendermint/tm-db"
 
 	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
@@ -29,15 +34,26 @@ type StreamCell struct {
 	Values      []string `json:"values"`
 }
 
+// ProposedChange is the net change of a path within a block.
 type ProposedChange struct {
 	Path               string
 	ValueFromLastBlock string
 	NewValue           string
 	LegacyEvents       bool
+	// NoValueFromLastBlock and Deleted respectively indicate that the path had
+	// no data before the change or has none after it (with a corresponding empty
+	// ValueFromLastBlock or NewValue), as distinct from data that is an empty
+	// string.
+	NoValueFromLastBlock bool
+	Deleted              bool
+	// Subtree indicates a removal of the data at the path and at all of its
+	// descendants, which is reported as a single change.
+	Subtree bool
 }
 
 type ChangeManager interface {
 	Track(ctx sdk.Context, k Keeper, entry agoric.KVEntry, isLegacy bool)
+	TrackSubtreeRemoval(ctx sdk.Context, k Keeper, pathPrefix string)
 	EmitEvents(ctx sdk.Context, k Keeper)
 	Rollback(ctx sdk.Context)
 }
@@ -45,6 +61,8 @@ type ChangeManager interface {
 type BatchingChangeManager struct {
 	// Map from storage path to proposed change.
 	changes map[string]*ProposedChange
+	// Map from storage path to subtree removal.
+	removedSubtrees map[string]*ProposedChange
 }
 
 var _ ChangeManager = (*BatchingChangeManager)(nil)
@@ -52,69 +70,196 @@ var _ ChangeManager = (*BatchingChangeManager)(nil)
 // 2 ** 256 - 1
 var MaxSDKInt = sdk.NewIntFromBigInt(new(big.Int).Sub(new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil), big.NewInt(1)))
 
+// VersionedStoreGetter returns a read-only view of the vstorage KVStore as of
+// a previously committed block height.
+type VersionedStoreGetter func(height int64) (sdk.KVStore, error)
+
+// StoreProofGetter returns the raw value of a key in the vstorage KVStore as of
+// a previously committed block height (or the latest if height is zero), along
+// with a proof of that value (or of its absence) against the app hash and the
+// height of the proven state.
+type StoreProofGetter func(key []byte, height int64) (storeValue []byte, proof *tmcrypto.ProofOps, proofHeight int64, err error)
+
 // Keeper maintains the link to data storage and exposes getter/setter methods
 // for the various parts of the state machine
 type Keeper struct {
-	changeManager ChangeManager
-	storeKey      storetypes.StoreKey
+	changeManager     ChangeManager
+	changeWatcher     *ChangeWatcher
+	storeKey          storetypes.StoreKey
+	getVersionedStore VersionedStoreGetter
+	getStoreProof     StoreProofGetter
+	maxPatternMatches int
+	maxPatternVisits  int
+	paramSpace        paramtypes.Subspace
+	usageStoreKey     storetypes.StoreKey
+	// storageQuotasCache is shared by copies of the keeper.
+	storageQuotasCache *atomic.Pointer[storageQuotasCache]
 }
 
 func (bcm *BatchingChangeManager) Track(ctx sdk.Context, k Keeper, entry agoric.KVEntry, isLegacy bool) {
 	path := entry.Key()
-	// TODO: differentiate between deletion and setting empty string?
-	// Using empty string for deletion for backwards compatibility
 	value := entry.StringValue()
 	if change, ok := bcm.changes[path]; ok {
 		change.NewValue = value
+		change.Deleted = !entry.HasValue()
 		if isLegacy {
 			change.LegacyEvents = true
 		}
 		return
 	}
+	lastEntry := k.GetEntry(ctx, path)
 	bcm.changes[path] = &ProposedChange{
-		Path:               path,
-		NewValue:           value,
-		ValueFromLastBlock: k.GetEntry(ctx, path).StringValue(),
-		LegacyEvents:       isLegacy,
+		Path:                 path,
+		NewValue:             value,
+		Deleted:              !entry.HasValue(),
+		ValueFromLastBlock:   lastEntry.StringValue(),
+		NoValueFromLastBlock: !lastEntry.HasValue(),
+		LegacyEvents:         isLegacy,
+	}
+}
+
+// isCoveredBySubtree tells if a path is or descends from a subtree root.
+func isCoveredBySubtree(path, subtreePath string) bool {
+	return subtreePath == "" || path == subtreePath || strings.HasPrefix(path, subtreePath+types.PathSeparator)
+}
+
+// TrackSubtreeRemoval records the removal of the data at a path and all of its
+// descendants, replacing their previously tracked changes with tombstones
+// (which are superseded by any subsequently tracked change).
+func (bcm *BatchingChangeManager) TrackSubtreeRemoval(ctx sdk.Context, k Keeper, pathPrefix string) {
+	if !k.HasEntry(ctx, pathPrefix) {
+		// Nothing to remove.
+		return
+	}
+	for path, change := range bcm.changes {
+		if isCoveredBySubtree(path, pathPrefix) {
+			change.NewValue = ""
+			change.Deleted = true
+		}
+	}
+	if _, ok := bcm.removedSubtrees[pathPrefix]; ok {
+		return
+	}
+	bcm.removedSubtrees[pathPrefix] = &ProposedChange{
+		Path:     pathPrefix,
+		Deleted:  true,
+		Subtree:  true,
+		NewValue: "",
 	}
 }
 
 func (bcm *BatchingChangeManager) Rollback(ctx sdk.Context) {
 	bcm.changes = make(map[string]*ProposedChange)
+	bcm.removedSubtrees = make(map[string]*ProposedChange)
 }
 
-// EmitEvents emits events for all actual changes.
+// EmitEvents emits events for all subtree removals and actual changes, in
+// the order of FlushedChanges.
 // This does not clear the cache, so the caller must call Rollback() to do so.
 func (bcm *BatchingChangeManager) EmitEvents(ctx sdk.Context, k Keeper) {
-	changes := bcm.changes
-	if len(changes) == 0 {
-		return
+	for _, change := range bcm.FlushedChanges() {
+		k.EmitChange(ctx, change)
+	}
+}
+
+// FlushedChanges returns all subtree removals and actual changes, in path
+// order with each subtree removal preceding any change at its root.
+// Deletions covered by a subtree removal are not included separately.
+func (bcm *BatchingChangeManager) FlushedChanges() []*ProposedChange {
+	if len(bcm.changes) == 0 && len(bcm.removedSubtrees) == 0 {
+		return nil
 	}
 
 	// Deterministic order.
-	sortedPaths := make([]string, 0, len(changes))
-	for path := range changes {
+	subtreePaths := make([]string, 0, len(bcm.removedSubtrees))
+	for path := range bcm.removedSubtrees {
+		subtreePaths = append(subtreePaths, path)
+	}
+	sort.Strings(subtreePaths)
+	coveringSubtrees := make([]string, 0, len(subtreePaths))
+	for _, path := range subtreePaths {
+		// Sorting puts each subtree root after any subtree root that covers it.
+		if n := len(coveringSubtrees); n > 0 && isCoveredBySubtree(path, coveringSubtrees[n-1]) {
+			continue
+		}
+		coveringSubtrees = append(coveringSubtrees, path)
+	}
+	isCovered := func(path string) bool {
+		i := sort.SearchStrings(coveringSubtrees, path)
+		if i < len(coveringSubtrees) && coveringSubtrees[i] == path {
+			return true
+		}
+		return i > 0 && isCoveredBySubtree(path, coveringSubtrees[i-1])
+	}
+
+	sortedPaths := make([]string, 0, len(bcm.changes))
+	for path, change := range bcm.changes {
+		if !change.HasEffect() || (change.Deleted && isCovered(path)) {
+			continue
+		}
 		sortedPaths = append(sortedPaths, path)
 	}
 	sort.Strings(sortedPaths)
 
+	// Merge the sorted subtree removals and changes.
+	flushed := make([]*ProposedChange, 0, len(sortedPaths)+len(coveringSubtrees))
+	i := 0
 	for _, path := range sortedPaths {
-		change := bcm.changes[path]
-		k.EmitChange(ctx, change)
+		for ; i < len(coveringSubtrees) && coveringSubtrees[i] <= path; i++ {
+			flushed = append(flushed, bcm.removedSubtrees[coveringSubtrees[i]])
+		}
+		flushed = append(flushed, bcm.changes[path])
+	}
+	for ; i < len(coveringSubtrees); i++ {
+		flushed = append(flushed, bcm.removedSubtrees[coveringSubtrees[i]])
 	}
+	return flushed
 }
 
 // The BatchingChangeManager needs to be a pointer because its state is mutated.
 func NewBatchingChangeManager() *BatchingChangeManager {
-	bcm := BatchingChangeManager{changes: make(map[string]*ProposedChange)}
+	bcm := BatchingChangeManager{
+		changes:         make(map[string]*ProposedChange),
+		removedSubtrees: make(map[string]*ProposedChange),
+	}
 	return &bcm
 }
 
-func NewKeeper(storeKey storetypes.StoreKey) Keeper {
-	return Keeper{
+// KeeperOption customizes a Keeper as it is constructed by NewKeeper.
+type KeeperOption func(k *Keeper)
+
+// WithChangeManager makes the keeper track and report changes with
+// changeManager instead of a new BatchingChangeManager.
+func WithChangeManager(changeManager ChangeManager) KeeperOption {
+	return func(k *Keeper) {
+		k.changeManager = changeManager
+	}
+}
+
+func NewKeeper(storeKey storetypes.StoreKey, opts ...KeeperOption) Keeper {
+	k := Keeper{
 		storeKey:      storeKey,
 		changeManager: NewBatchingChangeManager(),
+		changeWatcher: NewChangeWatcher(DefaultWatchRetainedBlocks, DefaultWatchBufferedBlocks),
+	}
+	for _, opt := range opts {
+		opt(&k)
 	}
+	return k
+}
+
+// WithVersionedStoreGetter returns a copy of the keeper that reads historical
+// data through getVersionedStore.
+func (k Keeper) WithVersionedStoreGetter(getVersionedStore VersionedStoreGetter) Keeper {
+	k.getVersionedStore = getVersionedStore
+	return k
+}
+
+// WithStoreProofGetter returns a copy of the keeper that proves data through
+// getStoreProof.
+func (k Keeper) WithStoreProofGetter(getStoreProof StoreProofGetter) Keeper {
+	k.getStoreProof = getStoreProof
+	return k
 }
 
 // ExportStorage fetches all storage
@@ -122,49 +267,322 @@ func (k Keeper) ExportStorage(ctx sdk.Context) []*types.DataEntry {
 	return k.ExportStorageFromPrefix(ctx, "")
 }
 
+// exportedDataEntry returns the DataEntry for a raw store entry if it has data
+// at a path descending from pathPrefix (which must be empty or end with a
+// separator) and no more than maxDepth segments below it (unless maxDepth is
+// zero), and otherwise returns nil.
+func exportedDataEntry(key, rawValue []byte, pathPrefix string, maxDepth uint32) *types.DataEntry {
+	if len(rawValue) == 0 {
+		return nil
+	}
+	if bytes.Equal(rawValue, types.EncodedNoDataValue) {
+		return nil
+	}
+	path := types.EncodedKeyToPath(key)
+	if !strings.HasPrefix(path, pathPrefix) {
+		return nil
+	}
+	value, hasPrefix := bytes.CutPrefix(rawValue, types.EncodedDataPrefix)
+	if !hasPrefix {
+		panic(fmt.Errorf("value at path %q starts with unexpected prefix", path))
+	}
+	path = path[len(pathPrefix):]
+	if maxDepth > 0 && strings.Count(path, types.PathSeparator) >= int(maxDepth) {
+		return nil
+	}
+	return &types.DataEntry{Path: path, Value: string(value)}
+}
+
+// exportPathPrefix validates a path for export and returns the prefix shared
+// by paths of all its descendants.
+func exportPathPrefix(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	if err := types.ValidatePath(path); err != nil {
+		panic(err)
+	}
+	return path + types.PathSeparator
+}
+
+// SubtreeIteration selects a strategy for finding the entries under a path.
+// Every strategy finds the same entries, but at different cost.
+type SubtreeIteration int
+
+const (
+	// SubtreeIterationAuto selects a strategy from the estimated size of the
+	// subtree relative to the whole store (see estimateSubtreeIteration).
+	SubtreeIterationAuto SubtreeIteration = iota
+	// SubtreeIterationFullStore iterates over the whole store and checks the
+	// path of each entry, at a cost proportional to the size of the store.
+	// Entries are found in encoded key order, which differs from depth order
+	// for depths of 10 or more (e.g. "10\0..." precedes "2\0...").
+	SubtreeIterationFullStore
+	// SubtreeIterationByDepth uses a prefix iterator for each successive depth
+	// below the path until one finds no entries, at a cost proportional to the
+	// size of the subtree (plus one iterator per level).
+	// Entries are found in order of depth, and then in encoded key order.
+	SubtreeIterationByDepth
+)
+
+// maxSubtreeIterationProbes bounds the number of store entries outside of a
+// subtree that estimateSubtreeIteration visits.
+const maxSubtreeIterationProbes = 64
+
+// estimateSubtreeIteration selects a concrete strategy for iterating over
+// the entries under pathPrefix.
+// Iteration over the full store also visits every entry outside of the
+// subtree, while iteration by depth costs an extra iterator per level of the
+// subtree, so the estimate probes each depth of the store (counting at most
+// maxSubtreeIterationProbes entries outside of the subtree in total) and
+// selects the full store iteration only if it would visit no more entries
+// outside of the subtree than there are levels in it (as is always the case
+// for the empty root path).
+func estimateSubtreeIteration(store sdk.KVStore, pathPrefix string) SubtreeIteration {
+	if len(pathPrefix) == 0 {
+		return SubtreeIterationFullStore
+	}
+	path := strings.TrimSuffix(pathPrefix, types.PathSeparator)
+	subtreeDepth := pathDepth(path)
+
+	outside, levels := 0, 0
+	hasEntries := func(start, end []byte) bool {
+		iterator := store.Iterator(start, end)
+		defer iterator.Close()
+		return iterator.Valid()
+	}
+	// countOutside counts the entries in [start, end), which are outside of
+	// the subtree, up to the remaining probes.
+	countOutside := func(start, end []byte) {
+		iterator := store.Iterator(start, end)
+		defer iterator.Close()
+		for ; iterator.Valid() && outside <= maxSubtreeIterationProbes; iterator.Next() {
+			outside++
+		}
+	}
+	if store.Has(types.PathToEncodedKey("")) {
+		outside++
+	}
+	// Every ancestor of an entry also has an entry, so the first depth
+	// without entries is deeper than the entire store.
+	for depth := 1; outside <= maxSubtreeIterationProbes; depth++ {
+		depthPrefix := types.PathToDescendantsPrefix("", depth)
+		depthEnd := sdk.PrefixEndBytes(depthPrefix)
+		if !hasEntries(depthPrefix, depthEnd) {
+			break
+		}
+		if depth <= subtreeDepth {
+			countOutside(depthPrefix, depthEnd)
+			continue
+		}
+		subtreePrefix := types.PathToDescendantsPrefix(path, depth-subtreeDepth)
+		subtreeEnd := sdk.PrefixEndBytes(subtreePrefix)
+		if hasEntries(subtreePrefix, subtreeEnd) {
+			levels++
+		}
+		countOutside(depthPrefix, subtreePrefix)
+		countOutside(subtreeEnd, depthEnd)
+	}
+	if outside <= levels {
+		return SubtreeIterationFullStore
+	}
+	return SubtreeIterationByDepth
+}
+
+// iterateSubtree calls cb with the encoded key and raw value of every store
+// entry whose path starts with pathPrefix (which must be empty or end with a
+// separator), using the specified iteration strategy.
+// The order of entries depends upon the strategy, so callers that expose it
+// must normalize it (as ExportStorageFromPrefixUsing does).
+func (k Keeper) iterateSubtree(ctx sdk.Context, pathPrefix string, iteration SubtreeIteration, cb func(key, rawValue []byte)) {
+	store := ctx.KVStore(k.storeKey)
+
+	if iteration == SubtreeIterationAuto {
+		iteration = estimateSubtreeIteration(store, pathPrefix)
+	}
+
+	switch iteration {
+	case SubtreeIterationFullStore:
+		iterator := sdk.KVStorePrefixIterator(store, nil)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			if !strings.HasPrefix(types.EncodedKeyToPath(iterator.Key()), pathPrefix) {
+				continue
+			}
+			cb(iterator.Key(), iterator.Value())
+		}
+
+	case SubtreeIterationByDepth:
+		path := strings.TrimSuffix(pathPrefix, types.PathSeparator)
+		iterateDescendantsByDepth(store, path, nil, 0, func(key, rawValue []byte) bool {
+			cb(key, rawValue)
+			return false
+		})
+
+	default:
+		panic(fmt.Errorf("unknown subtree iteration %d", iteration))
+	}
+}
+
+// iterateDescendantsByDepth calls cb with the encoded key and raw value of
+// every store entry that descends from path (or for the empty root path, of
+// the root entry and every other entry), in order of depth and then encoded
+// key, until cb returns true. It starts from the entry with encoded key start
+// (which must descend from path) if not nil, and does not descend more than
+// maxGenerations below path if nonzero.
+func iterateDescendantsByDepth(store sdk.KVStore, path string, start []byte, maxGenerations int, cb func(key, rawValue []byte) (stop bool)) {
+	startGenerations := 1
+	if start != nil {
+		startGenerations = strings.Count(types.EncodedKeyToPath(start), types.PathSeparator) + 1
+		if len(path) > 0 {
+			startGenerations -= strings.Count(path, types.PathSeparator) + 1
+		}
+	} else if len(path) == 0 {
+		// The empty path also matches the root entry itself.
+		rootKey := types.PathToEncodedKey(path)
+		if rawValue := store.Get(rootKey); rawValue != nil && cb(rootKey, rawValue) {
+			return
+		}
+	}
+	// Every ancestor of an entry also has an entry, so the first depth
+	// without entries is deeper than the entire subtree.
+	for generations := startGenerations; maxGenerations == 0 || generations <= maxGenerations; generations++ {
+		depthPrefix := types.PathToDescendantsPrefix(path, generations)
+		iterStart := depthPrefix
+		if generations == startGenerations && start != nil {
+			iterStart = start
+		}
+		found, stopped := false, false
+		iterator := store.Iterator(iterStart, sdk.PrefixEndBytes(depthPrefix))
+		for ; iterator.Valid() && !stopped; iterator.Next() {
+			found = true
+			stopped = cb(iterator.Key(), iterator.Value())
+		}
+		iterator.Close()
+		if stopped {
+			return
+		}
+		if !found && generations == startGenerations && start != nil {
+			// Entries before start still imply deeper entries.
+			before := sdk.KVStorePrefixIterator(store, depthPrefix)
+			found = before.Valid()
+			before.Close()
+		}
+		if !found {
+			return
+		}
+	}
+}
+
 // ExportStorageFromPrefix fetches storage only under the supplied pathPrefix.
 func (k Keeper) ExportStorageFromPrefix(ctx sdk.Context, pathPrefix string) []*types.DataEntry {
+	return k.ExportStorageFromPrefixUsing(ctx, pathPrefix, SubtreeIterationAuto)
+}
+
+// ExportStorageFromPrefixUsing fetches storage only under the supplied
+// pathPrefix, finding it with the specified iteration strategy.
+// Entries are in order of depth, and then of encoded key.
+func (k Keeper) ExportStorageFromPrefixUsing(ctx sdk.Context, pathPrefix string, iteration SubtreeIteration) []*types.DataEntry {
+	pathPrefix = exportPathPrefix(pathPrefix)
+
+	exported := []*types.DataEntry{}
+	k.iterateSubtree(ctx, pathPrefix, iteration, func(key, rawValue []byte) {
+		entry := exportedDataEntry(key, rawValue, pathPrefix, 0)
+		if entry == nil {
+			return
+		}
+		exported = append(exported, entry)
+	})
+	// Use the order of iteration by depth regardless of strategy.
+	sort.SliceStable(exported, func(i, j int) bool {
+		return pathDepth(exported[i].Path) < pathDepth(exported[j].Path)
+	})
+	return exported
+}
+
+// pathDepth returns the number of segments in a path.
+func pathDepth(path string) int {
+	if len(path) == 0 {
+		return 0
+	}
+	return strings.Count(path, types.PathSeparator) + 1
+}
+
+// ExportStoragePageFromPrefix fetches the storage under the supplied
+// pathPrefix that is selected by pageRequest (which supports key, offset,
+// limit, and count_total), limited to entries no more than maxDepth path
+// segments below pathPrefix unless maxDepth is zero.
+// As with ExportStorageFromPrefix, entry paths are relative to pathPrefix and
+// are in order of depth, and then of encoded key. Only the subtree is visited,
+// one depth at a time, and the key of a page request or response is the
+// relative path of the first entry of the page.
+func (k Keeper) ExportStoragePageFromPrefix(
+	ctx sdk.Context,
+	pathPrefix string,
+	maxDepth uint32,
+	pageRequest *query.PageRequest,
+) ([]*types.DataEntry, *query.PageResponse, error) {
 	store := ctx.KVStore(k.storeKey)
 
-	if len(pathPrefix) > 0 {
-		if err := types.ValidatePath(pathPrefix); err != nil {
-			panic(err)
+	var key []byte
+	var offset, limit uint64
+	var countTotal bool
+	if pageRequest != nil {
+		if pageRequest.Reverse {
+			return nil, nil, errors.New("reverse pagination is not supported")
 		}
-		pathPrefix = pathPrefix + types.PathSeparator
+		key, offset, limit, countTotal = pageRequest.Key, pageRequest.Offset, pageRequest.Limit, pageRequest.CountTotal
+	}
+	if len(key) > 0 && offset > 0 {
+		return nil, nil, errors.New("invalid request, either offset or key is expected, got both")
+	}
+	if limit == 0 {
+		limit = query.DefaultLimit
+		countTotal = true
+	}
+	if len(key) > 0 {
+		// As with query.Paginate, a total is counted only from the start.
+		countTotal = false
 	}
 
-	// since vstorage encodes keys with a prefix indicating the number of path
-	// elements, exporting all entries under a given path cannot use a prefix
-	// iterator. Instead we iterate over the whole vstorage content and check
-	// whether each entry matches the path prefix. This choice assumes most
-	// entries will be exported. An alternative implementation would be to
-	// recursively list all children under the pathPrefix, and export them.
+	path := pathPrefix
+	pathPrefix = exportPathPrefix(pathPrefix)
 
-	iterator := sdk.KVStorePrefixIterator(store, nil)
+	var start []byte
+	if len(key) > 0 {
+		if err := types.ValidatePath(string(key)); err != nil {
+			return nil, nil, fmt.Errorf("invalid key: %w", err)
+		}
+		start = types.PathToEncodedKey(pathPrefix + string(key))
+	}
 
 	exported := []*types.DataEntry{}
-	defer iterator.Close()
-	for ; iterator.Valid(); iterator.Next() {
-		rawValue := iterator.Value()
-		if len(rawValue) == 0 {
-			continue
+	var nextKey []byte
+	var count uint64
+	iterateDescendantsByDepth(store, path, start, int(maxDepth), func(key, rawValue []byte) bool {
+		entry := exportedDataEntry(key, rawValue, pathPrefix, maxDepth)
+		if entry == nil {
+			return false
 		}
-		if bytes.Equal(rawValue, types.EncodedNoDataValue) {
-			continue
+		count++
+		if count <= offset {
+			return false
 		}
-		path := types.EncodedKeyToPath(iterator.Key())
-		if !strings.HasPrefix(path, pathPrefix) {
-			continue
+		if uint64(len(exported)) < limit {
+			exported = append(exported, entry)
+			return false
 		}
-		value, hasPrefix := bytes.CutPrefix(rawValue, types.EncodedDataPrefix)
-		if !hasPrefix {
-			panic(fmt.Errorf("value at path %q starts with unexpected prefix", path))
+		if nextKey == nil {
+			nextKey = []byte(entry.Path)
 		}
-		path = path[len(pathPrefix):]
-		entry := types.DataEntry{Path: path, Value: string(value)}
-		exported = append(exported, &entry)
+		return !countTotal
+	})
+
+	pageResponse := &query.PageResponse{NextKey: nextKey}
+	if countTotal {
+		pageResponse.Total = count
 	}
-	return exported
+	return exported, pageResponse, nil
 }
 
 func (k Keeper) ImportStorage(ctx sdk.Context, entries []*types.DataEntry) {
@@ -175,24 +593,18 @@ func (k Keeper) ImportStorage(ctx sdk.Context, entries []*types.DataEntry) {
 	}
 }
 
-func getEncodedKeysWithPrefixFromIterator(iterator sdk.Iterator, prefix string) [][]byte {
-	keys := make([][]byte, 0)
-	defer iterator.Close()
-	for ; iterator.Valid(); iterator.Next() {
-		key := iterator.Key()
-		path := types.EncodedKeyToPath(key)
-		if strings.HasPrefix(path, prefix) {
-			keys = append(keys, key)
-		}
-	}
-	return keys
-}
-
 // RemoveEntriesWithPrefix removes all storage entries starting with the
 // supplied pathPrefix, which may not be empty.
 // It has the same effect as listing children of the prefix and removing each
 // descendant recursively.
 func (k Keeper) RemoveEntriesWithPrefix(ctx sdk.Context, pathPrefix string) {
+	k.RemoveEntriesWithPrefixUsing(ctx, pathPrefix, SubtreeIterationAuto)
+}
+
+// RemoveEntriesWithPrefixUsing removes all storage entries starting with the
+// supplied pathPrefix, which may not be empty, finding them with the specified
+// iteration strategy.
+func (k Keeper) RemoveEntriesWithPrefixUsing(ctx sdk.Context, pathPrefix string, iteration SubtreeIteration) {
 	store := ctx.KVStore(k.storeKey)
 
 	if len(pathPrefix) == 0 {
@@ -203,18 +615,21 @@ func (k Keeper) RemoveEntriesWithPrefix(ctx sdk.Context, pathPrefix string) {
 	}
 	descendantPrefix := pathPrefix + types.PathSeparator
 
-	// since vstorage encodes keys with a prefix indicating the number of path
-	// elements, we cannot use a simple prefix iterator.
-	// Instead we iterate over the whole vstorage content and check
-	// whether each entry matches the descendantPrefix. This choice assumes most
-	// entries will be deleted. An alternative implementation would be to
-	// recursively list all children under the descendantPrefix, and delete them.
-
-	iterator := sdk.KVStorePrefixIterator(store, nil)
-
-	keys := getEncodedKeysWithPrefixFromIterator(iterator, descendantPrefix)
+	// Collect keys before deleting any, so as not to disturb the iteration.
+	keys := make([][]byte, 0)
+	k.iterateSubtree(ctx, descendantPrefix, iteration, func(key, _ []byte) {
+		keys = append(keys, key)
+	})
 
+	var quotas []types.StorageQuota
+	if k.hasStorageQuotas() {
+		quotas = k.getStorageQuotas(ctx)
+	}
 	for _, key := range keys {
+		if path := types.EncodedKeyToPath(key); isAccounted(quotas, path) {
+			k.accountUsage(ctx, path, dataBytes(path, store.Get(key)), 0)
+		}
+		k.clearStreamCellHistory(ctx, types.EncodedKeyToPath(key))
 		store.Delete(key)
 	}
 
@@ -223,8 +638,14 @@ func (k Keeper) RemoveEntriesWithPrefix(ctx sdk.Context, pathPrefix string) {
 	k.SetStorage(ctx, agoric.NewKVEntryWithNoValue(pathPrefix))
 }
 
+// HasEffect tells if the change is a subtree removal or differs from the state
+// as of the last block.
+func (change *ProposedChange) HasEffect() bool {
+	return change.Subtree || change.NewValue != change.ValueFromLastBlock || change.Deleted != change.NoValueFromLastBlock
+}
+
 func (k Keeper) EmitChange(ctx sdk.Context, change *ProposedChange) {
-	if change.NewValue == change.ValueFromLastBlock {
+	if !change.HasEffect() {
 		// No change.
 		return
 	}
@@ -237,19 +658,57 @@ func (k Keeper) EmitChange(ctx sdk.Context, change *ProposedChange) {
 	}
 
 	// Emit the new state change event.
-	ctx.EventManager().EmitEvent(
-		agoric.NewStateChangeEvent(
-			k.GetStoreName(),
-			k.PathToEncodedKey(change.Path),
-			[]byte(change.NewValue),
-		),
-	)
+	var event sdk.Event
+	switch {
+	case change.Subtree:
+		event = agoric.NewStateDeleteSubtreeEvent(k.GetStoreName(), k.PathToEncodedKey(change.Path))
+	case change.Deleted:
+		event = agoric.NewStateDeleteEvent(k.GetStoreName(), k.PathToEncodedKey(change.Path))
+	default:
+		event = agoric.NewStateChangeEvent(k.GetStoreName(), k.PathToEncodedKey(change.Path), []byte(change.NewValue))
+	}
+	ctx.EventManager().EmitEvent(event)
+
+	k.changeWatcher.Record(*change)
 }
 
 // GetEntry gets generic storage.  The default value is an empty string.
 func (k Keeper) GetEntry(ctx sdk.Context, path string) agoric.KVEntry {
 	//fmt.Printf("GetEntry(%s)\n", path);
 	store := ctx.KVStore(k.storeKey)
+	return getEntryFromStore(store, path)
+}
+
+// GetStoreAtHeight returns a read-only view of the store as of a previously
+// committed block height, failing if that state is not available.
+func (k Keeper) GetStoreAtHeight(height int64) (sdk.KVStore, error) {
+	if k.getVersionedStore == nil {
+		return nil, errors.New("historical vstorage data is not available")
+	}
+	return k.getVersionedStore(height)
+}
+
+// GetEntryAtHeight gets generic storage as of a previously committed block
+// height, failing if that state is not available.
+func (k Keeper) GetEntryAtHeight(path string, height int64) (agoric.KVEntry, error) {
+	store, err := k.GetStoreAtHeight(height)
+	if err != nil {
+		return agoric.KVEntry{}, err
+	}
+	return getEntryFromStore(store, path), nil
+}
+
+// GetStoreValueWithProof gets the raw store value for path as of a previously
+// committed block height, along with a proof of it against the app hash and
+// the height of the proven state.
+func (k Keeper) GetStoreValueWithProof(path string, height int64) (storeValue []byte, proof *tmcrypto.ProofOps, proofHeight int64, err error) {
+	if k.getStoreProof == nil {
+		return nil, nil, 0, errors.New("vstorage proofs are not available")
+	}
+	return k.getStoreProof(types.PathToEncodedKey(path), height)
+}
+
+func getEntryFromStore(store sdk.KVStore, path string) agoric.KVEntry {
 	encodedKey := types.PathToEncodedKey(path)
 	rawValue := store.Get(encodedKey)
 	if len(rawValue) == 0 {
@@ -287,6 +746,26 @@ func (k Keeper) GetChildren(ctx sdk.Context, path string) *types.Children {
 	return &children
 }
 
+// GetChildrenPage gets the vstorage children at a given path that are selected
+// by pageRequest (which supports key, offset, limit, count_total, and reverse),
+// along with the corresponding page response.
+func (k Keeper) GetChildrenPage(ctx sdk.Context, path string, pageRequest *query.PageRequest) (*types.Children, *query.PageResponse, error) {
+	// Keys under the children prefix are exactly the child path segments,
+	// because the depth encoding excludes every deeper descendant.
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PathToChildrenPrefix(path))
+
+	var children types.Children
+	children.Children = []string{}
+	pageResponse, err := query.Paginate(store, pageRequest, func(key []byte, _ []byte) error {
+		children.Children = append(children.Children, string(key))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &children, pageResponse, nil
+}
+
 // HasStorage tells if a given path has data.  Some storage nodes have no data
 // (just an empty string) and exist only to provide linkage to subnodes with
 // data.
@@ -318,19 +797,106 @@ func (k Keeper) NewChangeBatch(ctx sdk.Context) {
 func (k Keeper) FlushChangeEvents(ctx sdk.Context) {
 	k.changeManager.EmitEvents(ctx, k)
 	k.changeManager.Rollback(ctx)
+	k.changeWatcher.Publish(ctx.BlockHeight())
 }
 
-func (k Keeper) SetStorageAndNotify(ctx sdk.Context, entry agoric.KVEntry) {
+// WatchChanges subscribes to the changes at or below a path, as flushed at the
+// end of each block (before it is committed).
+// See ChangeWatcher.Subscribe.
+func (k Keeper) WatchChanges(pathPrefix string, fromHeight int64) (*ChangeSubscription, error) {
+	return k.changeWatcher.Subscribe(pathPrefix, fromHeight)
+}
+
+// UnwatchChanges ends a subscription from WatchChanges.
+func (k Keeper) UnwatchChanges(sub *ChangeSubscription) {
+	k.changeWatcher.Unsubscribe(sub)
+}
+
+// SetStorageAndNotify is like SetStorage, but also reports the change at the
+// end of the block. It fails without effect if the change would exceed a
+// storage quota.
+func (k Keeper) SetStorageAndNotify(ctx sdk.Context, entry agoric.KVEntry) error {
+	if err := k.checkStorageQuotas(ctx, entry); err != nil {
+		return err
+	}
 	k.changeManager.Track(ctx, k, entry, false)
 	k.SetStorage(ctx, entry)
+	return nil
 }
 
+// LegacySetStorageAndNotify is like SetStorageAndNotify, but also emits a
+// legacy event and is not subject to storage quotas.
 func (k Keeper) LegacySetStorageAndNotify(ctx sdk.Context, entry agoric.KVEntry) {
 	k.changeManager.Track(ctx, k, entry, true)
 	k.SetStorage(ctx, entry)
 }
 
+// RemoveEntriesWithPrefixAndNotify is like RemoveEntriesWithPrefix, but also
+// reports the removal as a single subtree deletion event at the end of the
+// block.
+func (k Keeper) RemoveEntriesWithPrefixAndNotify(ctx sdk.Context, pathPrefix string) {
+	if len(pathPrefix) == 0 {
+		panic("cannot remove all content")
+	}
+	k.changeManager.TrackSubtreeRemoval(ctx, k, pathPrefix)
+	k.RemoveEntriesWithPrefix(ctx, pathPrefix)
+}
+
+// SetStoragesAndNotify is like SetStorageAndNotify for each of a batch of
+// entries in order, but fails without any effect if any of the changes would
+// exceed a storage quota.
+func (k Keeper) SetStoragesAndNotify(ctx sdk.Context, entries []agoric.KVEntry) error {
+	return k.setStorageBatchAndNotify(ctx, len(entries), func(_ sdk.Context, i int) (agoric.KVEntry, error) {
+		return entries[i], nil
+	})
+}
+
+// AppendStorageValueAndNotify appends value to the StreamCell of the current
+// block at path, and reports the change at the end of the block. It fails
+// without effect if the change would exceed a storage quota.
 func (k Keeper) AppendStorageValueAndNotify(ctx sdk.Context, path, value string) error {
+	return k.AppendStorageValuesAndNotify(ctx, []agoric.KVEntry{agoric.NewKVEntry(path, value)})
+}
+
+// AppendStorageValuesAndNotify is like AppendStorageValueAndNotify for the
+// path and value of each of a batch of entries in order, but fails without
+// any effect if any of the changes would exceed a storage quota.
+func (k Keeper) AppendStorageValuesAndNotify(ctx sdk.Context, entries []agoric.KVEntry) error {
+	return k.setStorageBatchAndNotify(ctx, len(entries), func(cacheCtx sdk.Context, i int) (agoric.KVEntry, error) {
+		return k.appendedStreamCellEntry(cacheCtx, entries[i].Key(), entries[i].StringValue())
+	})
+}
+
+// setStorageBatchAndNotify writes the n entries returned by entryAt (which
+// may read the writes of earlier entries from cacheCtx) and reports the
+// changes at the end of the block, unless any of them fails or would exceed a
+// storage quota, in which case nothing is written or reported.
+func (k Keeper) setStorageBatchAndNotify(ctx sdk.Context, n int, entryAt func(cacheCtx sdk.Context, i int) (agoric.KVEntry, error)) error {
+	cacheCtx, writeCache := ctx.CacheContext()
+	entries := make([]agoric.KVEntry, n)
+	for i := range entries {
+		entry, err := entryAt(cacheCtx, i)
+		if err != nil {
+			return err
+		}
+		if err := k.checkStorageQuotas(cacheCtx, entry); err != nil {
+			return err
+		}
+		k.SetStorage(cacheCtx, entry)
+		entries[i] = entry
+	}
+	// Track the changes against the data from before the batch.
+	for _, entry := range entries {
+		k.changeManager.Track(ctx, k, entry, false)
+	}
+	writeCache()
+	return nil
+}
+
+// appendedStreamCellEntry returns the entry for appending value to the
+// StreamCell of the current block at path, first archiving any StreamCell of
+// an earlier block.
+func (k Keeper) appendedStreamCellEntry(ctx sdk.Context, path, value string) (agoric.KVEntry, error) {
 	blockHeight := strconv.FormatInt(ctx.BlockHeight(), 10)
 
 	// Preserve correctly-formatted data within the current block,
@@ -339,19 +905,20 @@ func (k Keeper) AppendStorageValueAndNotify(ctx sdk.Context, path, value string)
 	var cell StreamCell
 	_ = json.Unmarshal([]byte(currentData), &cell)
 	if cell.BlockHeight != blockHeight {
+		if cell.BlockHeight != "" {
+			k.archiveStreamCell(ctx, path, currentData)
+		}
 		cell = StreamCell{BlockHeight: blockHeight, Values: make([]string, 0, 1)}
 	}
 
 	// Append the new value.
 	cell.Values = append(cell.Values, value)
 
-	// Perform the write.
 	bz, err := json.Marshal(cell)
 	if err != nil {
-		return err
+		return agoric.KVEntry{}, err
 	}
-	k.SetStorageAndNotify(ctx, agoric.NewKVEntry(path, string(bz)))
-	return nil
+	return agoric.NewKVEntry(path, string(bz)), nil
 }
 
 func componentsToPath(components []string) string {
@@ -361,13 +928,19 @@ func componentsToPath(components []string) string {
 // SetStorage sets the data value for a path.
 //
 // Maintains the invariant: path entries exist if and only if self or some
-// descendant has non-empty storage
+// descendant has non-empty storage.
+// Also maintains recorded byte usage, but does not enforce storage quotas.
 func (k Keeper) SetStorage(ctx sdk.Context, entry agoric.KVEntry) {
 	store := ctx.KVStore(k.storeKey)
 	path := entry.Key()
 	encodedKey := types.PathToEncodedKey(path)
 
+	if k.hasStorageQuotas() && isAccounted(k.getStorageQuotas(ctx), path) {
+		k.accountUsage(ctx, path, dataBytes(path, store.Get(encodedKey)), entryBytes(entry))
+	}
+
 	if !entry.HasValue() {
+		k.clearStreamCellHistory(ctx, path)
 		if !k.HasChildren(ctx, path) {
 			// We have no children, can delete.
 			store.Delete(encodedKey)
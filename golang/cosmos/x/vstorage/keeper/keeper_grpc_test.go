@@ -1,11 +1,13 @@
 package keeper
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 
+	"google.golang.org/grpc"
 	grpcCodes "google.golang.org/grpc/codes"
 	grpcStatus "google.golang.org/grpc/status"
 
@@ -13,7 +15,16 @@ import (
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/capdata"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
 
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func ptr[T any](v T) *T {
@@ -233,6 +244,158 @@ func TestCapData(t *testing.T) {
 			}),
 		},
 	})
+	testCases = append(testCases, testCase{label: "remotables as qclass",
+		data:    ptr(cell),
+		request: types.QueryCapDataRequest{RemotableValueFormat: "qclass"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value: mustMarshalTwoLines(map[string]any{
+				"arr": []any{
+					map[string]any{
+						"bigint":    map[string]any{"@qclass": "bigint", "digits": "42"},
+						"remotable": map[string]any{"@qclass": "slot", "id": "a", "iface": "Alleged: Foo brand"},
+						"ref2":      map[string]any{"@qclass": "slot", "id": "a", "iface": "Alleged: Foo brand"},
+					},
+				},
+			}),
+		},
+	})
+	ifacelessCell := mustJsonMarshal(map[string]any{"body": `#{"r":"$0"}`, "slots": []any{"a"}})
+	testCases = append(testCases, testCase{label: "remotable without iface as string",
+		data:    ptr(ifacelessCell),
+		request: types.QueryCapDataRequest{RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			Value: mustJsonMarshal(map[string]any{"r": "[Remotable <a>]"}),
+		},
+	})
+	testCases = append(testCases, testCase{label: "remotable without iface as object",
+		data:    ptr(ifacelessCell),
+		request: types.QueryCapDataRequest{RemotableValueFormat: "object"},
+		expected: types.QueryCapDataResponse{
+			Value: mustJsonMarshal(map[string]any{"r": map[string]any{"id": "a", "allegedName": "Remotable"}}),
+		},
+	})
+	testCases = append(testCases, testCase{label: "remotable without iface as qclass",
+		data:    ptr(ifacelessCell),
+		request: types.QueryCapDataRequest{RemotableValueFormat: "qclass"},
+		expected: types.QueryCapDataResponse{
+			Value: mustJsonMarshal(map[string]any{"r": map[string]any{"@qclass": "slot", "id": "a"}}),
+		},
+	})
+	testCases = append(testCases, testCase{label: "special values as qclass",
+		data: ptr(mustJsonMarshal(map[string]any{
+			"body":  `#{"missing":"#undefined","nan":"#NaN","set":{"#tag":"copySet","payload":["&0"]}}`,
+			"slots": []any{"p"},
+		})),
+		request: types.QueryCapDataRequest{RemotableValueFormat: "qclass"},
+		expected: types.QueryCapDataResponse{
+			Value: mustJsonMarshal(map[string]any{
+				"missing": map[string]any{"@qclass": "undefined"},
+				"nan":     map[string]any{"@qclass": "NaN"},
+				"set": map[string]any{
+					"@qclass": "tagged",
+					"tag":     "copySet",
+					"payload": []any{map[string]any{"@qclass": "promise", "id": "p"}},
+				},
+			}),
+		},
+	})
+
+	testCases = append(testCases, testCase{label: "JSON array",
+		data:    ptr(cell),
+		request: types.QueryCapDataRequest{MediaType: "application/json", ItemFormat: "flat", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value: fmt.Sprintf("[%s,%[1]s]", mustJsonMarshal(map[string]any{
+				"arr-0-bigint":    "42",
+				"arr-0-remotable": "[Alleged: Foo brand <a>]",
+				"arr-0-ref2":      "[Alleged: Foo brand <a>]",
+			})),
+		},
+	})
+	testCases = append(testCases, testCase{label: "JSON Lines with metadata",
+		data:    ptr(cell),
+		request: types.QueryCapDataRequest{MediaType: "JSON Lines with metadata", ItemFormat: "flat", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value: fmt.Sprintf("%s\n%s",
+				mustJsonMarshal(map[string]any{
+					"blockHeight": "1",
+					"index":       0,
+					"value":       map[string]any{"arr-0-bigint": "42", "arr-0-remotable": "[Alleged: Foo brand <a>]", "arr-0-ref2": "[Alleged: Foo brand <a>]"},
+				}),
+				mustJsonMarshal(map[string]any{
+					"blockHeight": "1",
+					"index":       1,
+					"value":       map[string]any{"arr-0-bigint": "42", "arr-0-remotable": "[Alleged: Foo brand <a>]", "arr-0-ref2": "[Alleged: Foo brand <a>]"},
+				}),
+			),
+		},
+	})
+	testCases = append(testCases, testCase{label: "selector, flat",
+		data:    ptr(cell),
+		request: types.QueryCapDataRequest{ItemFormat: "flat", Selector: "$.arr[*].bigint", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value:       mustMarshalTwoLines(map[string]any{"arr-0-bigint": "42"}),
+		},
+	})
+	testCases = append(testCases, testCase{label: "selector with index, flat",
+		data: ptr(mustJsonMarshal(map[string]any{
+			"body":  `#{"vaults":[{"debt":1},{"debt":2},{"debt":3}]}`,
+			"slots": []any{},
+		})),
+		request:  types.QueryCapDataRequest{ItemFormat: "flat", Selector: "$.vaults[-1].debt", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{Value: `{"vaults-2-debt":3}`},
+	})
+	selectorCell := mustMarshalStreamCell("1", []string{
+		`{"body":"#{\"a\":1}","slots":[]}`,
+		`{"body":"#{\"b\":2}","slots":[]}`,
+	})
+	testCases = append(testCases, testCase{label: "selector omitting items",
+		data:    ptr(selectorCell),
+		request: types.QueryCapDataRequest{MediaType: "JSON Lines with metadata", Selector: "$.b", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value:       `{"blockHeight":"1","index":1,"value":{"b":2}}`,
+		},
+	})
+	testCases = append(testCases, testCase{label: "invalid selector",
+		data:        ptr(cell),
+		request:     types.QueryCapDataRequest{Selector: "arr", RemotableValueFormat: "string"},
+		errCode:     grpcCodes.InvalidArgument,
+		errContains: ptr("invalid selector"),
+	})
+	testCases = append(testCases, testCase{label: "CSV",
+		data:    ptr(cell),
+		request: types.QueryCapDataRequest{MediaType: "text/csv", ItemFormat: "flat", RemotableValueFormat: "object"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value: strings.Join([]string{
+				"arr-0-bigint,arr-0-ref2-allegedName,arr-0-ref2-id,arr-0-remotable-allegedName,arr-0-remotable-id",
+				"42,Foo brand,a,Foo brand,a",
+				"42,Foo brand,a,Foo brand,a",
+			}, "\n"),
+		},
+	})
+	heterogeneousCell := mustMarshalStreamCell("1", []string{
+		mustJsonMarshal(map[string]any{"body": `#{"a":1,"b":"x,y"}`, "slots": []any{}}),
+		mustJsonMarshal(map[string]any{"body": `#{"b":null,"c":[true]}`, "slots": []any{}}),
+	})
+	testCases = append(testCases, testCase{label: "CSV with heterogeneous items",
+		data:    ptr(heterogeneousCell),
+		request: types.QueryCapDataRequest{MediaType: "text/csv", ItemFormat: "flat", RemotableValueFormat: "string"},
+		expected: types.QueryCapDataResponse{
+			BlockHeight: "1",
+			Value:       "a,b,c-0\n1,\"x,y\",\n,,true",
+		},
+	})
+	testCases = append(testCases, testCase{label: "CSV without flat",
+		data:        ptr(cell),
+		request:     types.QueryCapDataRequest{MediaType: "text/csv", RemotableValueFormat: "string"},
+		errCode:     grpcCodes.InvalidArgument,
+		errContains: ptr("item_format"),
+	})
 
 	// Test errors from CapData that includes unsupported values.
 	expectNotImplemented := func(label, capdataBody string, slots []any) testCase {
@@ -269,6 +432,14 @@ func TestCapData(t *testing.T) {
 		expectNotImplemented("smallcaps error", `{"@qclass":"error","message":"foo","name":"Error"}`, nil),
 		expectNotImplemented("smallcaps Hilbert Hotel", `{"@qclass":"hilbert","original":"foo"}`, nil),
 	}...)
+	testCases = append(testCases, testCase{label: "legacy Hilbert Hotel as qclass",
+		data: ptr(mustJsonMarshal(map[string]any{
+			"body":  `{"@qclass":"hilbert","original":"foo","rest":{"bar":1}}`,
+			"slots": []any{},
+		})),
+		request:  types.QueryCapDataRequest{RemotableValueFormat: "qclass"},
+		expected: types.QueryCapDataResponse{Value: `{"@qclass":"hilbert","original":"foo","rest":{"bar":1}}`},
+	})
 	for _, desc := range testCases {
 		desc.request.Path = "key"
 		if desc.data == nil {
@@ -298,3 +469,1009 @@ func TestCapData(t *testing.T) {
 		}
 	}
 }
+
+func TestChildren(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	querier := Querier{keeper}
+
+	for _, path := range []string{"top.a", "top.b.deep", "top.c", "top.d", "top.e", "topNot.x"} {
+		keeper.SetStorage(ctx, agoric.NewKVEntry(path, "value"))
+	}
+
+	type testCase struct {
+		label       string
+		path        string
+		pagination  *query.PageRequest
+		expected    []string
+		nextKey     []byte
+		total       uint64
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "unpaginated",
+			path:     "top",
+			expected: []string{"a", "b", "c", "d", "e"},
+		},
+		{label: "unpaginated root",
+			path:     "",
+			expected: []string{"top", "topNot"},
+		},
+		{label: "limit",
+			path:       "top",
+			pagination: &query.PageRequest{Limit: 2},
+			expected:   []string{"a", "b"},
+			nextKey:    []byte("c"),
+		},
+		{label: "key",
+			path:       "top",
+			pagination: &query.PageRequest{Key: []byte("c"), Limit: 2},
+			expected:   []string{"c", "d"},
+			nextKey:    []byte("e"),
+		},
+		{label: "final page",
+			path:       "top",
+			pagination: &query.PageRequest{Key: []byte("e"), Limit: 2},
+			expected:   []string{"e"},
+		},
+		{label: "offset with total",
+			path:       "top",
+			pagination: &query.PageRequest{Offset: 1, Limit: 3, CountTotal: true},
+			expected:   []string{"b", "c", "d"},
+			nextKey:    []byte("e"),
+			total:      5,
+		},
+		{label: "reverse",
+			path:       "top",
+			pagination: &query.PageRequest{Limit: 2, Reverse: true},
+			expected:   []string{"e", "d"},
+			nextKey:    []byte("c"),
+		},
+		{label: "nonexistent",
+			path:       "top.nonexistent",
+			pagination: &query.PageRequest{Limit: 2},
+			expected:   []string{},
+		},
+		{label: "both key and offset",
+			path:        "top",
+			pagination:  &query.PageRequest{Key: []byte("c"), Offset: 1},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("offset"),
+		},
+		{label: "invalid path",
+			path:        "top.",
+			pagination:  &query.PageRequest{Limit: 2},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("separator"),
+		},
+	}
+	for _, desc := range testCases {
+		request := types.QueryChildrenRequest{Path: desc.path, Pagination: desc.pagination}
+		resp, err := querier.Children(sdk.WrapSDKContext(ctx), &request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp.Children, desc.expected) {
+			t.Errorf("%s: got children %q, want %q", desc.label, resp.Children, desc.expected)
+		}
+		if desc.pagination == nil {
+			if resp.Pagination != nil {
+				t.Errorf("%s: got unexpected pagination %v", desc.label, resp.Pagination)
+			}
+			continue
+		}
+		if resp.Pagination == nil {
+			t.Errorf("%s: got no pagination", desc.label)
+			continue
+		}
+		if !reflect.DeepEqual(resp.Pagination.NextKey, desc.nextKey) {
+			t.Errorf("%s: got next key %q, want %q", desc.label, resp.Pagination.NextKey, desc.nextKey)
+		}
+		if resp.Pagination.Total != desc.total {
+			t.Errorf("%s: got total %d, want %d", desc.label, resp.Pagination.Total, desc.total)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	querier := Querier{keeper}
+
+	for _, path := range []string{"top.a", "top.b.deep", "top.b.deep.deeper", "top.c", "topNot.x"} {
+		keeper.SetStorage(ctx, agoric.NewKVEntry(path, path+" value"))
+	}
+
+	entry := func(path, fullPath string) *types.DataEntry {
+		return &types.DataEntry{Path: path, Value: fullPath + " value"}
+	}
+
+	type testCase struct {
+		label       string
+		request     types.QueryExportRequest
+		expected    []*types.DataEntry
+		total       uint64
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "subtree",
+			request: types.QueryExportRequest{Path: "top"},
+			expected: []*types.DataEntry{
+				entry("a", "top.a"),
+				entry("c", "top.c"),
+				entry("b.deep", "top.b.deep"),
+				entry("b.deep.deeper", "top.b.deep.deeper"),
+			},
+			total: 4,
+		},
+		{label: "root",
+			request: types.QueryExportRequest{Path: ""},
+			expected: []*types.DataEntry{
+				entry("top.a", "top.a"),
+				entry("top.c", "top.c"),
+				entry("topNot.x", "topNot.x"),
+				entry("top.b.deep", "top.b.deep"),
+				entry("top.b.deep.deeper", "top.b.deep.deeper"),
+			},
+			total: 5,
+		},
+		{label: "max depth",
+			request: types.QueryExportRequest{Path: "top", MaxDepth: 2},
+			expected: []*types.DataEntry{
+				entry("a", "top.a"),
+				entry("c", "top.c"),
+				entry("b.deep", "top.b.deep"),
+			},
+			total: 3,
+		},
+		{label: "offset and limit",
+			request: types.QueryExportRequest{Path: "top", Pagination: &query.PageRequest{Offset: 1, Limit: 2}},
+			expected: []*types.DataEntry{
+				entry("c", "top.c"),
+				entry("b.deep", "top.b.deep"),
+			},
+		},
+		{label: "nonexistent",
+			request:  types.QueryExportRequest{Path: "top.nonexistent"},
+			expected: []*types.DataEntry{},
+		},
+		{label: "invalid path",
+			request:     types.QueryExportRequest{Path: ".top"},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("separator"),
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.Export(sdk.WrapSDKContext(ctx), &desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp.Entries, desc.expected) {
+			t.Errorf("%s: got entries %v, want %v", desc.label, resp.Entries, desc.expected)
+		}
+		if resp.Pagination.Total != desc.total {
+			t.Errorf("%s: got total %d, want %d", desc.label, resp.Pagination.Total, desc.total)
+		}
+	}
+
+	// Walk every page by key.
+	var walked []*types.DataEntry
+	pageRequest := &query.PageRequest{Limit: 1}
+	for i := 0; i < 10; i++ {
+		resp, err := querier.Export(sdk.WrapSDKContext(ctx), &types.QueryExportRequest{Path: "top", Pagination: pageRequest})
+		if err != nil {
+			t.Fatalf("page %d: got unexpected error %v", i, err)
+		}
+		walked = append(walked, resp.Entries...)
+		if resp.Pagination.NextKey == nil {
+			break
+		}
+		if expected := testCases[0].expected[i+1].Path; string(resp.Pagination.NextKey) != expected {
+			t.Errorf("page %d: got next key %q, want %q", i, resp.Pagination.NextKey, expected)
+		}
+		pageRequest = &query.PageRequest{Key: resp.Pagination.NextKey, Limit: 1}
+	}
+	if expected := testCases[0].expected; !reflect.DeepEqual(walked, expected) {
+		t.Errorf("got walked entries %v, want %v", walked, expected)
+	}
+}
+
+func TestQueueQuery(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	querier := Querier{keeper}
+
+	for _, value := range []string{"a", "b", "c"} {
+		keeper.PushQueueItem(ctx, "actionQueue", value)
+	}
+	keeper.PopQueueItems(ctx, "actionQueue", 1)
+	keeper.SetStorage(ctx, agoric.NewKVEntry("brokenQueue.tail", "3"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("brokenQueue.0", "x"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("brokenQueue.2", "z"))
+
+	type testCase struct {
+		label       string
+		request     types.QueryQueueRequest
+		expected    *types.QueryQueueResponse
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "queue",
+			request: types.QueryQueueRequest{Path: "actionQueue"},
+			expected: &types.QueryQueueResponse{Head: "1", Tail: "3", Items: []types.QueueItem{
+				{Index: "1", Value: "b"},
+				{Index: "2", Value: "c"},
+			}},
+		},
+		{label: "limit",
+			request: types.QueryQueueRequest{Path: "actionQueue", Limit: 1},
+			expected: &types.QueryQueueResponse{Head: "1", Tail: "3", Items: []types.QueueItem{
+				{Index: "1", Value: "b"},
+			}, NextIndex: "2"},
+		},
+		{label: "start index",
+			request: types.QueryQueueRequest{Path: "actionQueue", Limit: 1, StartIndex: "2"},
+			expected: &types.QueryQueueResponse{Head: "1", Tail: "3", Items: []types.QueueItem{
+				{Index: "2", Value: "c"},
+			}},
+		},
+		{label: "start index before head",
+			request: types.QueryQueueRequest{Path: "actionQueue", StartIndex: "0"},
+			expected: &types.QueryQueueResponse{Head: "1", Tail: "3", Items: []types.QueueItem{
+				{Index: "1", Value: "b"},
+				{Index: "2", Value: "c"},
+			}},
+		},
+		{label: "start index after tail",
+			request:  types.QueryQueueRequest{Path: "actionQueue", StartIndex: "3"},
+			expected: &types.QueryQueueResponse{Head: "1", Tail: "3", Items: []types.QueueItem{}},
+		},
+		{label: "invalid start index",
+			request:     types.QueryQueueRequest{Path: "actionQueue", StartIndex: "-1"},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("start index"),
+		},
+		{label: "absent",
+			request:  types.QueryQueueRequest{Path: "highPriorityQueue"},
+			expected: &types.QueryQueueResponse{Head: "0", Tail: "0", Items: []types.QueueItem{}},
+		},
+		{label: "invariant violation",
+			request: types.QueryQueueRequest{Path: "brokenQueue"},
+			expected: &types.QueryQueueResponse{Head: "0", Tail: "3", Items: []types.QueueItem{
+				{Index: "0", Value: "x"},
+			}, InvariantViolation: "queue brokenQueue has 2 items but length 3"},
+		},
+		{label: "empty path",
+			request:     types.QueryQueueRequest{Path: ""},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("empty"),
+		},
+		{label: "invalid path",
+			request:     types.QueryQueueRequest{Path: "actionQueue."},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("separator"),
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.Queue(sdk.WrapSDKContext(ctx), &desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp, desc.expected) {
+			t.Errorf("%s: got response %+v, want %+v", desc.label, resp, desc.expected)
+		}
+	}
+
+	// A long queue is returned in bounded pages.
+	for i := 0; i < MaxQueueItems+1; i++ {
+		keeper.PushQueueItem(ctx, "longQueue", "x")
+	}
+	checkPage := func(label string, limit uint32, wantItems int, wantNext string) {
+		t.Helper()
+		resp, err := querier.Queue(sdk.WrapSDKContext(ctx), &types.QueryQueueRequest{Path: "longQueue", Limit: limit})
+		if err != nil {
+			t.Fatalf("%s: got unexpected error %v", label, err)
+		}
+		if len(resp.Items) != wantItems || resp.NextIndex != wantNext {
+			t.Errorf("%s: got %d items and next index %q, want %d and %q",
+				label, len(resp.Items), resp.NextIndex, wantItems, wantNext)
+		}
+	}
+	checkPage("default limit", 0, query.DefaultLimit, fmt.Sprint(query.DefaultLimit))
+	checkPage("maximum limit", MaxQueueItems+1, MaxQueueItems, fmt.Sprint(MaxQueueItems))
+}
+
+func TestDataMany(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	ctx = ctx.WithBlockHeight(42)
+	querier := Querier{keeper}
+
+	keeper.SetStorage(ctx, agoric.NewKVEntry("a.b", "ab"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("c", ""))
+
+	tooManyPaths := make([]string, MaxDataManyPaths+1)
+	for i := range tooManyPaths {
+		tooManyPaths[i] = "a.b"
+	}
+
+	type testCase struct {
+		label       string
+		request     types.QueryDataManyRequest
+		expected    *types.QueryDataManyResponse
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "paths",
+			request: types.QueryDataManyRequest{Paths: []string{"c", "a.b", "a", "d", "a.b"}},
+			expected: &types.QueryDataManyResponse{BlockHeight: "42", Entries: []types.DataManyEntry{
+				{Path: "c", Value: "", Found: true},
+				{Path: "a.b", Value: "ab", Found: true},
+				{Path: "a", Value: "", Found: false},
+				{Path: "d", Value: "", Found: false},
+				{Path: "a.b", Value: "ab", Found: true},
+			}},
+		},
+		{label: "no paths",
+			request:  types.QueryDataManyRequest{},
+			expected: &types.QueryDataManyResponse{BlockHeight: "42", Entries: []types.DataManyEntry{}},
+		},
+		{label: "invalid path",
+			request:     types.QueryDataManyRequest{Paths: []string{"a.b", "a."}},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("paths[1]"),
+		},
+		{label: "too many paths",
+			request:     types.QueryDataManyRequest{Paths: tooManyPaths},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("too many paths"),
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.DataMany(sdk.WrapSDKContext(ctx), &desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp, desc.expected) {
+			t.Errorf("%s: got response %+v, want %+v", desc.label, resp, desc.expected)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	querier := Querier{keeper.WithMaxPatternMatches(3)}
+
+	for _, path := range []string{
+		"published.vaults.manager0.vaults.vault0",
+		"published.vaults.manager0.vaults.vault1",
+		"published.vaults.manager1.vaults.vault0",
+		"published.vaults.manager1.metrics",
+		"published.psm.IST.metrics",
+		"published.reserve.metrics",
+		"published.metrics",
+	} {
+		keeper.SetStorage(ctx, agoric.NewKVEntry(path, path[len("published."):]))
+	}
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.vaults.manager0.metrics", ""))
+
+	paths := func(paths ...string) []types.DataManyEntry {
+		entries := make([]types.DataManyEntry, len(paths))
+		for i, path := range paths {
+			entries[i] = types.DataManyEntry{Path: path}
+		}
+		return entries
+	}
+
+	type testCase struct {
+		label       string
+		request     types.QueryMatchRequest
+		expected    *types.QueryMatchResponse
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "any segment",
+			request: types.QueryMatchRequest{Pattern: "published.vaults.*.vaults.*"},
+			expected: &types.QueryMatchResponse{
+				Entries: paths(
+					"published.vaults.manager0.vaults.vault0",
+					"published.vaults.manager0.vaults.vault1",
+					"published.vaults.manager1.vaults.vault0",
+				),
+				Pagination: &query.PageResponse{},
+			},
+		},
+		{label: "values",
+			request: types.QueryMatchRequest{Pattern: "published.vaults.*.metrics", IncludeValues: true},
+			expected: &types.QueryMatchResponse{
+				Entries: []types.DataManyEntry{
+					{Path: "published.vaults.manager0.metrics", Value: "", Found: true},
+					{Path: "published.vaults.manager1.metrics", Value: "vaults.manager1.metrics", Found: true},
+				},
+				Pagination: &query.PageResponse{},
+			},
+		},
+		{label: "placeholders",
+			request: types.QueryMatchRequest{Pattern: "published.*", IncludeValues: true},
+			expected: &types.QueryMatchResponse{
+				Entries: []types.DataManyEntry{
+					{Path: "published.metrics", Value: "metrics", Found: true},
+					{Path: "published.psm", Value: "", Found: false},
+					{Path: "published.reserve", Value: "", Found: false},
+				},
+				Pagination: &query.PageResponse{NextKey: []byte("published.vaults")},
+			},
+		},
+		{label: "any segments capped by node",
+			request: types.QueryMatchRequest{Pattern: "**.metrics", Pagination: &query.PageRequest{Limit: 10}},
+			expected: &types.QueryMatchResponse{
+				Entries: paths(
+					"published.metrics",
+					"published.psm.IST.metrics",
+					"published.reserve.metrics",
+				),
+				Pagination: &query.PageResponse{NextKey: []byte("published.vaults.manager0.metrics")},
+			},
+		},
+		{label: "next page",
+			request: types.QueryMatchRequest{Pattern: "**.metrics", Pagination: &query.PageRequest{
+				Key: []byte("published.vaults.manager0.metrics"),
+			}},
+			expected: &types.QueryMatchResponse{
+				Entries: paths(
+					"published.vaults.manager0.metrics",
+					"published.vaults.manager1.metrics",
+				),
+				Pagination: &query.PageResponse{},
+			},
+		},
+		{label: "limit",
+			request: types.QueryMatchRequest{Pattern: "published.vaults.**", Pagination: &query.PageRequest{
+				Key:   []byte("published.vaults.manager0.vaults"),
+				Limit: 2,
+			}},
+			expected: &types.QueryMatchResponse{
+				Entries: paths(
+					"published.vaults.manager0.vaults",
+					"published.vaults.manager0.vaults.vault0",
+				),
+				Pagination: &query.PageResponse{NextKey: []byte("published.vaults.manager0.vaults.vault1")},
+			},
+		},
+		{label: "no match",
+			request: types.QueryMatchRequest{Pattern: "published.*.vault0"},
+			expected: &types.QueryMatchResponse{
+				Entries:    []types.DataManyEntry{},
+				Pagination: &query.PageResponse{},
+			},
+		},
+		{label: "invalid pattern",
+			request:     types.QueryMatchRequest{Pattern: "published.*x"},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("invalid segment"),
+		},
+		{label: "unsupported pagination",
+			request:     types.QueryMatchRequest{Pattern: "**", Pagination: &query.PageRequest{Offset: 1}},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("only key and limit"),
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.Match(sdk.WrapSDKContext(ctx), &desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp, desc.expected) {
+			t.Errorf("%s: got response %+v, want %+v", desc.label, resp, desc.expected)
+		}
+	}
+
+	// A search that reads too many entries is resumed from the next key, even
+	// if it found fewer matches than the limit.
+	boundedQuerier := Querier{keeper.WithMaxPatternVisits(4)}
+	walk := func(pattern string) (matched []string, pages int) {
+		t.Helper()
+		request := types.QueryMatchRequest{Pattern: pattern}
+		for pages = 1; pages <= 20; pages++ {
+			resp, err := boundedQuerier.Match(sdk.WrapSDKContext(ctx), &request)
+			if err != nil {
+				t.Fatalf("%s page %d: got unexpected error %v", pattern, pages, err)
+			}
+			for _, entry := range resp.Entries {
+				matched = append(matched, entry.Path)
+			}
+			if resp.Pagination.NextKey == nil {
+				return matched, pages
+			}
+			request.Pagination = &query.PageRequest{Key: resp.Pagination.NextKey}
+		}
+		t.Fatalf("%s: got too many pages", pattern)
+		return nil, 0
+	}
+	if matched, pages := walk("**.nomatch"); len(matched) != 0 || pages < 3 {
+		t.Errorf("got %d pages of no-match search with matches %q", pages, matched)
+	}
+	expectedMetrics := []string{
+		"published.metrics",
+		"published.psm.IST.metrics",
+		"published.reserve.metrics",
+		"published.vaults.manager0.metrics",
+		"published.vaults.manager1.metrics",
+	}
+	if matched, _ := walk("**.metrics"); !reflect.DeepEqual(matched, expectedMetrics) {
+		t.Errorf("got bounded matches %q, want %q", matched, expectedMetrics)
+	}
+}
+
+func TestDataWithProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	keeper := NewKeeper(vstorageStoreKey).WithStoreProofGetter(func(key []byte, height int64) ([]byte, *tmcrypto.ProofOps, int64, error) {
+		res := ms.(storetypes.Queryable).Query(abci.RequestQuery{
+			Path:   "/" + vstorageStoreKey.Name() + "/key",
+			Data:   key,
+			Height: height,
+			Prove:  true,
+		})
+		if !res.IsOK() {
+			return nil, nil, 0, fmt.Errorf("query failed: %s", res.Log)
+		}
+		return res.Value, res.ProofOps, res.Height, nil
+	})
+	querier := Querier{keeper}
+
+	// Write different values in each of blocks 1 and 2.
+	appHashes := map[int64][]byte{}
+	for height := int64(1); height <= 2; height++ {
+		ctx := sdk.NewContext(ms, tmproto.Header{Height: height}, false, log.NewNopLogger())
+		keeper.SetStorage(ctx, agoric.NewKVEntry("a.b.c", fmt.Sprintf("abc%d", height)))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("x", ""))
+		appHashes[height] = ms.Commit().Hash
+	}
+
+	type testCase struct {
+		label    string
+		path     string
+		height   int64
+		expected string
+		errCode  grpcCodes.Code
+	}
+	testCases := []testCase{
+		{label: "data", path: "a.b.c", height: 2, expected: "abc2"},
+		{label: "historical data", path: "a.b.c", height: 1, expected: "abc1"},
+		{label: "empty data", path: "x", height: 2, expected: ""},
+		{label: "placeholder", path: "a.b", height: 2, expected: ""},
+		{label: "absent", path: "a.b.d", height: 2, expected: ""},
+		{label: "invalid path", path: "a.", height: 2, errCode: grpcCodes.InvalidArgument},
+		{label: "pruned height", path: "a.b.c", height: 3, errCode: grpcCodes.Unavailable},
+	}
+	for _, desc := range testCases {
+		ctx := sdk.NewContext(ms, tmproto.Header{Height: desc.height}, false, log.NewNopLogger())
+		resp, err := querier.DataWithProof(sdk.WrapSDKContext(ctx), &types.QueryDataWithProofRequest{Path: desc.path})
+		if desc.errCode != grpcCodes.OK {
+			if grpcStatus.Code(err) != desc.errCode {
+				t.Errorf("%s: got error %v, want code %s", desc.label, err, desc.errCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if resp.Value != desc.expected || resp.Height != desc.height {
+			t.Errorf("%s: got value %q at height %d, want %q at height %d", desc.label, resp.Value, resp.Height, desc.expected, desc.height)
+		}
+		if err := types.VerifyDataWithProof(appHashes[desc.height], desc.path, resp); err != nil {
+			t.Errorf("%s: got verification error %v", desc.label, err)
+		}
+		if err := types.VerifyDataWithProof(appHashes[3-desc.height], desc.path, resp); err == nil {
+			t.Errorf("%s: got no verification error for the wrong app hash", desc.label)
+		}
+		if err := types.VerifyDataWithProof(appHashes[desc.height], "a.b.e", resp); err == nil {
+			t.Errorf("%s: got no verification error for the wrong path", desc.label)
+		}
+
+		forged := *resp
+		forged.Value = "forged"
+		forged.StoreValue = []byte("\x00forged")
+		if err := types.VerifyDataWithProof(appHashes[desc.height], desc.path, &forged); err == nil {
+			t.Errorf("%s: got no verification error for a forged value", desc.label)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	keeper := NewKeeper(vstorageStoreKey).WithVersionedStoreGetter(func(height int64) (sdk.KVStore, error) {
+		cms, err := ms.CacheMultiStoreWithVersion(height)
+		if err != nil {
+			return nil, err
+		}
+		return cms.GetKVStore(vstorageStoreKey), nil
+	})
+	querier := Querier{keeper}
+
+	blocks := []map[string]*string{
+		1: {"a.b": ptr("ab"), "a.c": ptr("ac"), "d": ptr(""), "e.f.g": ptr("efg"), "x": ptr("x")},
+		2: {"a.b": ptr("ab2"), "a.c": nil, "a": ptr("a"), "d": nil, "e.f.g": ptr("efg"), "e.h": ptr("eh"), "x": ptr("x")},
+		3: {"a.b": nil, "e.f.g": nil},
+	}
+	var ctx sdk.Context
+	for height := int64(1); height < int64(len(blocks)); height++ {
+		ctx = sdk.NewContext(ms, tmproto.Header{Height: height}, false, log.NewNopLogger())
+		for path, value := range blocks[height] {
+			if value == nil {
+				keeper.SetStorage(ctx, agoric.NewKVEntryWithNoValue(path))
+			} else {
+				keeper.SetStorage(ctx, agoric.NewKVEntry(path, *value))
+			}
+		}
+		ms.Commit()
+	}
+	// Uncommitted state.
+	ctx = sdk.NewContext(ms.CacheMultiStore(), tmproto.Header{Height: 4}, false, log.NewNopLogger())
+	keeper.SetStorage(ctx, agoric.NewKVEntry("x", "x4"))
+
+	type testCase struct {
+		label       string
+		request     types.QueryDiffRequest
+		expected    *types.QueryDiffResponse
+		errCode     grpcCodes.Code
+		errContains *string
+	}
+	testCases := []testCase{
+		{label: "one block",
+			request: types.QueryDiffRequest{FromHeight: 1, ToHeight: 2},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{
+				{Path: "a", Change: "added", NewValue: "a"},
+				{Path: "d", Change: "removed", OldValue: ""},
+				{Path: "a.b", Change: "changed", OldValue: "ab", NewValue: "ab2"},
+				{Path: "a.c", Change: "removed", OldValue: "ac"},
+				{Path: "e.h", Change: "added", NewValue: "eh"},
+			}, Pagination: &query.PageResponse{}},
+		},
+		{label: "path",
+			request: types.QueryDiffRequest{FromHeight: 1, ToHeight: 3, Path: "e"},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{
+				{Path: "e.h", Change: "added", NewValue: "eh"},
+				{Path: "e.f.g", Change: "removed", OldValue: "efg"},
+			}, Pagination: &query.PageResponse{}},
+		},
+		{label: "current state",
+			request: types.QueryDiffRequest{FromHeight: 3, Path: "x"},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{
+				{Path: "x", Change: "changed", OldValue: "x", NewValue: "x4"},
+			}, Pagination: &query.PageResponse{}},
+		},
+		{label: "first page",
+			request: types.QueryDiffRequest{FromHeight: 1, ToHeight: 2, Pagination: &query.PageRequest{Limit: 2}},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{
+				{Path: "a", Change: "added", NewValue: "a"},
+				{Path: "d", Change: "removed", OldValue: ""},
+			}, Pagination: &query.PageResponse{NextKey: []byte("a.b")}},
+		},
+		{label: "next page",
+			request: types.QueryDiffRequest{FromHeight: 1, ToHeight: 2, Pagination: &query.PageRequest{Key: []byte("a.b"), Limit: 2}},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{
+				{Path: "a.b", Change: "changed", OldValue: "ab", NewValue: "ab2"},
+				{Path: "a.c", Change: "removed", OldValue: "ac"},
+			}, Pagination: &query.PageResponse{NextKey: []byte("e.h")}},
+		},
+		{label: "no differences",
+			request:  types.QueryDiffRequest{FromHeight: 2, ToHeight: 2},
+			expected: &types.QueryDiffResponse{Diffs: []types.DataDiff{}, Pagination: &query.PageResponse{}},
+		},
+		{label: "unavailable height",
+			request:     types.QueryDiffRequest{FromHeight: 1, ToHeight: 9},
+			errCode:     grpcCodes.Unavailable,
+			errContains: ptr(""),
+		},
+		{label: "invalid height",
+			request:     types.QueryDiffRequest{FromHeight: 0, ToHeight: 2},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("invalid heights"),
+		},
+		{label: "pagination key outside path",
+			request:     types.QueryDiffRequest{FromHeight: 1, ToHeight: 2, Path: "e", Pagination: &query.PageRequest{Key: []byte("a.b")}},
+			errCode:     grpcCodes.InvalidArgument,
+			errContains: ptr("not at or below"),
+		},
+	}
+	for _, desc := range testCases {
+		resp, err := querier.Diff(sdk.WrapSDKContext(ctx), &desc.request)
+		if desc.errCode != grpcCodes.OK {
+			if err == nil {
+				t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+			} else if code := grpcStatus.Code(err); code != desc.errCode {
+				t.Errorf("%s: got error code %q, want %q", desc.label, code, desc.errCode)
+			} else if desc.errContains != nil && !strings.Contains(err.Error(), *desc.errContains) {
+				t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp, desc.expected) {
+			t.Errorf("%s: got response %+v, want %+v", desc.label, resp, desc.expected)
+		}
+	}
+
+	// Bounding the keys scanned per call must resume across depths without
+	// skipping or repeating any difference.
+	from, err := keeper.GetStoreAtHeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := keeper.GetStoreAtHeight(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collect := func(diffs *[]types.DataDiff) func(diff types.DataDiff) bool {
+		return func(diff types.DataDiff) bool {
+			*diffs = append(*diffs, diff)
+			return false
+		}
+	}
+	var allDiffs []types.DataDiff
+	if resumePath, err := DiffStoreData(from, to, "", "", 0, collect(&allDiffs)); err != nil || resumePath != "" {
+		t.Fatalf("unbounded diff: got resume path %q and error %v", resumePath, err)
+	}
+	if len(allDiffs) != 6 {
+		t.Errorf("unbounded diff: got %+v, want 6 differences", allDiffs)
+	}
+	for maxScanned := 1; maxScanned <= 4; maxScanned++ {
+		var diffs []types.DataDiff
+		startPath := ""
+		calls := 0
+		for {
+			calls++
+			resumePath, err := DiffStoreData(from, to, "", startPath, maxScanned, collect(&diffs))
+			if err != nil {
+				t.Fatalf("max scanned %d: got unexpected error %v", maxScanned, err)
+			}
+			if resumePath == "" {
+				break
+			}
+			if resumePath == startPath || calls > 20 {
+				t.Fatalf("max scanned %d: no progress from %q", maxScanned, startPath)
+			}
+			startPath = resumePath
+		}
+		if maxScanned == 1 && calls < 9 {
+			t.Errorf("max scanned 1: got %d calls, want at least one per key", calls)
+		}
+		if !reflect.DeepEqual(diffs, allDiffs) {
+			t.Errorf("max scanned %d: got %+v, want %+v", maxScanned, diffs, allDiffs)
+		}
+	}
+}
+
+func TestCapDataHistory(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	keeper := NewKeeper(vstorageStoreKey).WithVersionedStoreGetter(func(height int64) (sdk.KVStore, error) {
+		cms, err := ms.CacheMultiStoreWithVersion(height)
+		if err != nil {
+			return nil, err
+		}
+		return cms.GetKVStore(vstorageStoreKey), nil
+	})
+	querier := Querier{keeper}
+
+	capDataOf := func(n int) string {
+		return mustJsonMarshal(map[string]any{"body": fmt.Sprintf("#%d", n), "slots": []any{}})
+	}
+
+	// Append two values in each of blocks 1 and 2, nothing in block 3,
+	// and one value in block 4.
+	appends := map[int64][]int{1: {1, 2}, 2: {3, 4}, 4: {5}}
+	var ctx sdk.Context
+	for height := int64(1); height <= 4; height++ {
+		ctx = sdk.NewContext(ms, tmproto.Header{Height: height}, false, log.NewNopLogger())
+		for _, n := range appends[height] {
+			if err := keeper.AppendStorageValueAndNotify(ctx, "key", capDataOf(n)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Also append records with differing fields in blocks 1 and 2.
+		if height <= 2 {
+			field := map[int64]string{1: "a", 2: "b"}[height]
+			record := mustJsonMarshal(map[string]any{"body": fmt.Sprintf(`#{"%s":%d}`, field, height), "slots": []any{}})
+			if err := keeper.AppendStorageValueAndNotify(ctx, "records", record); err != nil {
+				t.Fatal(err)
+			}
+		}
+		ms.Commit()
+	}
+
+	request := types.QueryCapDataRequest{Path: "key", RemotableValueFormat: "string"}
+	type testCase struct {
+		label    string
+		limit    uint32
+		expected []*types.CapDataCell
+	}
+	testCases := []testCase{
+		{label: "no history", limit: 0, expected: nil},
+		{label: "partial history", limit: 1, expected: []*types.CapDataCell{
+			{BlockHeight: "2", Value: "3\n4"},
+		}},
+		{label: "full history", limit: 5, expected: []*types.CapDataCell{
+			{BlockHeight: "2", Value: "3\n4"},
+			{BlockHeight: "1", Value: "1\n2"},
+		}},
+	}
+	for _, desc := range testCases {
+		request.HistoryLimit = desc.limit
+		resp, err := querier.CapData(sdk.WrapSDKContext(ctx), &request)
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		if resp.BlockHeight != "4" || resp.Value != "5" {
+			t.Errorf("%s: got current cell %q at %q, want %q at %q", desc.label, resp.Value, resp.BlockHeight, "5", "4")
+		}
+		if !reflect.DeepEqual(resp.History, desc.expected) {
+			t.Errorf("%s: got history %v, want %v", desc.label, resp.History, desc.expected)
+		}
+	}
+
+	// CSV values of the current cell and its history share a header.
+	csvRequest := types.QueryCapDataRequest{Path: "records", MediaType: "text/csv", ItemFormat: "flat", RemotableValueFormat: "string", HistoryLimit: 1}
+	resp, err := querier.CapData(sdk.WrapSDKContext(ctx), &csvRequest)
+	if err != nil {
+		t.Fatalf("csv: got unexpected error %v", err)
+	}
+	if expected := "a,b\n,2"; resp.Value != expected {
+		t.Errorf("csv: got current cell %q, want %q", resp.Value, expected)
+	}
+	expectedHistory := []*types.CapDataCell{{BlockHeight: "1", Value: "a,b\n1,"}}
+	if !reflect.DeepEqual(resp.History, expectedHistory) {
+		t.Errorf("csv: got history %v, want %v", resp.History, expectedHistory)
+	}
+
+	request.HistoryLimit = MaxCapDataHistoryLimit + 1
+	_, err = querier.CapData(sdk.WrapSDKContext(ctx), &request)
+	if code := grpcStatus.Code(err); code != grpcCodes.InvalidArgument {
+		t.Errorf("excessive history limit: got error %v, want code %q", err, grpcCodes.InvalidArgument)
+	}
+}
+
+// watchPathStream is a types.Query_WatchPathServer that forwards responses to
+// a channel.
+type watchPathStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *types.QueryWatchPathResponse
+}
+
+func (s *watchPathStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchPathStream) Send(resp *types.QueryWatchPathResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func TestWatchPath(t *testing.T) {
+	testKit := makeTestKit()
+	ctx, keeper := testKit.ctx, testKit.vstorageKeeper
+	querier := Querier{keeper}
+
+	if err := querier.WatchPath(&types.QueryWatchPathRequest{Path: "foo..bar"}, nil); grpcStatus.Code(err) != grpcCodes.InvalidArgument {
+		t.Errorf("invalid path: got error %v", err)
+	}
+
+	ctx = ctx.WithBlockHeight(1)
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("watched.a", "1"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("unwatched", "1"))
+	keeper.FlushChangeEvents(ctx)
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream := &watchPathStream{ctx: streamCtx, responses: make(chan *types.QueryWatchPathResponse, 10)}
+	done := make(chan error)
+	go func() {
+		done <- querier.WatchPath(&types.QueryWatchPathRequest{Path: "watched", FromHeight: 1}, stream)
+	}()
+
+	// Wait for the resumed change before writing more.
+	expected := []types.QueryWatchPathResponse{
+		{BlockHeight: 1, Path: "watched.a", OldValue: "", NewValue: "1"},
+		{BlockHeight: 2, Path: "watched", OldValue: "", NewValue: "root"},
+		{BlockHeight: 2, Path: "watched.a", OldValue: "1", NewValue: "", Deleted: true},
+	}
+	got := []types.QueryWatchPathResponse{*<-stream.responses}
+
+	ctx = ctx.WithBlockHeight(2)
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("watched", "root"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("watched.a", "2"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntryWithNoValue("watched.a"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("watchedNot", "1"))
+	keeper.FlushChangeEvents(ctx)
+
+	got = append(got, *<-stream.responses, *<-stream.responses)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got changes %v, want %v", got, expected)
+	}
+
+	cancel()
+	if err := <-done; grpcStatus.Code(err) != grpcCodes.Canceled {
+		t.Errorf("got error %v on cancellation", err)
+	}
+}
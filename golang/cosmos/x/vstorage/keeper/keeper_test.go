@@ -1,15 +1,22 @@
 package keeper
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Agoric/agoric-sdk/golang/cosmos/app/params"
 	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
 	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -19,6 +26,7 @@ import (
 
 var (
 	vstorageStoreKey = storetypes.NewKVStoreKey(types.StoreKey)
+	usageStoreKey    = storetypes.NewKVStoreKey(types.UsageStoreKey)
 )
 
 type testKit struct {
@@ -41,6 +49,30 @@ func makeTestKit() testKit {
 	return testKit{ctx, keeper}
 }
 
+// makeQuotaTestKit is like makeTestKit, but with storage quotas configured.
+func makeQuotaTestKit() testKit {
+	encodingConfig := params.MakeEncodingConfig()
+	paramsTStoreKey := sdk.NewTransientStoreKey(paramstypes.TStoreKey)
+	paramsStoreKey := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	pk := paramskeeper.NewKeeper(encodingConfig.Marshaler, encodingConfig.Amino, paramsStoreKey, paramsTStoreKey)
+	keeper := NewKeeper(vstorageStoreKey).WithStorageQuotas(pk.Subspace(types.ModuleName), usageStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(vstorageStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(usageStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsStoreKey, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsTStoreKey, storetypes.StoreTypeTransient, db)
+	err := ms.LoadLatestVersion()
+	if err != nil {
+		panic(err)
+	}
+	ctx := sdk.NewContext(ms, tmproto.Header{}, false, log.NewNopLogger())
+
+	keeper.SetParams(ctx, types.DefaultParams())
+	return testKit{ctx, keeper}
+}
+
 func childrenEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -292,3 +324,837 @@ func TestStorageNotify(t *testing.T) {
 		t.Errorf("got after second flush events %#v, want %#v", got, expectedAfterFlushEvents)
 	}
 }
+
+func TestStorageDeleteNotify(t *testing.T) {
+	tk := makeTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+	keeper.SetStorage(ctx, agoric.NewKVEntry("del.empty", "x"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("del.gone", "y"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("tree.a", "1"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("tree.a.b", "2"))
+
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("del.empty", ""))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntryWithNoValue("del.gone"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntryWithNoValue("tree.a.b"))
+	keeper.RemoveEntriesWithPrefixAndNotify(ctx, "tree")
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("tree.c", "3"))
+	// Removing an absent subtree is not a change.
+	keeper.RemoveEntriesWithPrefixAndNotify(ctx, "absent")
+
+	stateChange := func(key, value string, extra ...abci.EventAttribute) sdk.Event {
+		return sdk.Event{
+			Type: "state_change",
+			Attributes: append([]abci.EventAttribute{
+				{Key: []byte("store"), Value: []byte("vstorage")},
+				{Key: []byte("key"), Value: []byte(key)},
+				{Key: []byte("anckey"), Value: []byte("\x01" + key + "\x01")},
+				{Key: []byte("value"), Value: []byte(value)},
+			}, extra...),
+		}
+	}
+	expectedEvents := sdk.Events{
+		stateChange("2\x00del\x00empty", ""),
+		stateChange("2\x00del\x00gone", "",
+			abci.EventAttribute{Key: []byte("deleted"), Value: []byte("true")}),
+		stateChange("1\x00tree", "",
+			abci.EventAttribute{Key: []byte("deleted"), Value: []byte("subtree")}),
+		stateChange("2\x00tree\x00c", "3"),
+	}
+
+	keeper.FlushChangeEvents(ctx)
+	if got := ctx.EventManager().Events(); !reflect.DeepEqual(got, expectedEvents) {
+		for _, e := range got {
+			t.Logf("got event: %s", e.Type)
+			for _, a := range e.Attributes {
+				t.Logf("got attr: %q = %q", a.Key, a.Value)
+			}
+		}
+		t.Errorf("got events %#v, want %#v", got, expectedEvents)
+	}
+
+	if keeper.HasEntry(ctx, "tree.a") || keeper.HasEntry(ctx, "tree.a.b") {
+		t.Errorf("got entries remaining in removed subtree")
+	}
+}
+
+func TestChangeManagers(t *testing.T) {
+	tk := makeTestKit()
+	changeManager, recorded := NewMemoryChangeManager()
+	ctx, keeper := tk.ctx.WithBlockHeight(7), NewKeeper(vstorageStoreKey, WithChangeManager(changeManager))
+
+	keeper.SetStorage(ctx, agoric.NewKVEntry("tree.a", "1"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("gone", "x"))
+	keeper.NewChangeBatch(ctx)
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("unchanged", "u"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntryWithNoValue("unchanged"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("key", "value"))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntryWithNoValue("gone"))
+	keeper.RemoveEntriesWithPrefixAndNotify(ctx, "tree")
+	keeper.FlushChangeEvents(ctx)
+
+	expected := []FlushedChange{
+		{BlockHeight: 7, Path: "gone", Deleted: true},
+		{BlockHeight: 7, Path: "key", Value: "value"},
+		{BlockHeight: 7, Path: "tree", Deleted: true, Subtree: true},
+	}
+	if got := recorded.Changes(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got recorded changes %+v, want %+v", got, expected)
+	}
+	if got := len(ctx.EventManager().Events()); got != 3 {
+		t.Errorf("got %d events, want 3", got)
+	}
+
+	// Flushing an empty batch records nothing.
+	recorded.Reset()
+	keeper.FlushChangeEvents(ctx)
+	if got := recorded.Changes(); len(got) != 0 {
+		t.Errorf("got recorded changes %+v for empty batch", got)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "changes.jsonl")
+	sink, err := NewFileChangeSink(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keeper = NewKeeper(vstorageStoreKey, WithChangeManager(NewSinkChangeManager(sink)))
+	keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("key", ""))
+	keeper.FlushChangeEvents(ctx)
+	keeper.SetStorageAndNotify(ctx.WithBlockHeight(8), agoric.NewKVEntry("key", "again"))
+	keeper.FlushChangeEvents(ctx.WithBlockHeight(8))
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedLines := `{"blockHeight":7,"path":"key"}` + "\n" + `{"blockHeight":8,"path":"key","value":"again"}` + "\n"
+	if string(bz) != expectedLines {
+		t.Errorf("got file contents %q, want %q", bz, expectedLines)
+	}
+}
+
+var subtreeIterations = []struct {
+	name      string
+	iteration SubtreeIteration
+}{
+	{"auto", SubtreeIterationAuto},
+	{"full store", SubtreeIterationFullStore},
+	{"by depth", SubtreeIterationByDepth},
+}
+
+func TestSubtreeIteration(t *testing.T) {
+	for _, si := range subtreeIterations {
+		tk := makeTestKit()
+		ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+		keeper.SetStorage(ctx, agoric.NewKVEntry("key1", "value1"))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("key1.child1", "value1child"))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("key10.child1", "value10child"))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("key1.child1.grandchild1", "value1grandchild"))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("key1.child2.grandchild2", "value2grandchild"))
+
+		expectedExport := []*types.DataEntry{
+			{Path: "child1", Value: "value1child"},
+			{Path: "child1.grandchild1", Value: "value1grandchild"},
+			{Path: "child2.grandchild2", Value: "value2grandchild"},
+		}
+		if got := keeper.ExportStorageFromPrefixUsing(ctx, "key1", si.iteration); !reflect.DeepEqual(got, expectedExport) {
+			t.Errorf("%s: got export %q, want %q", si.name, got, expectedExport)
+		}
+		if got := keeper.ExportStorageFromPrefixUsing(ctx, "nonexistent", si.iteration); len(got) != 0 {
+			t.Errorf("%s: got nonexistent export %q, want []", si.name, got)
+		}
+
+		// Encoded keys of depth 10 or more precede those of depth 2.
+		deepPath := "deep.a.b.c.d.e.f.g.h.i.j"
+		keeper.SetStorage(ctx, agoric.NewKVEntry(deepPath, "deepValue"))
+		keeper.SetStorage(ctx, agoric.NewKVEntry("deep.shallow", "shallowValue"))
+		expectedDeepExport := []*types.DataEntry{
+			{Path: "shallow", Value: "shallowValue"},
+			{Path: strings.TrimPrefix(deepPath, "deep."), Value: "deepValue"},
+		}
+		if got := keeper.ExportStorageFromPrefixUsing(ctx, "deep", si.iteration); !reflect.DeepEqual(got, expectedDeepExport) {
+			t.Errorf("%s: got deep export %q, want %q", si.name, got, expectedDeepExport)
+		}
+		keeper.RemoveEntriesWithPrefixUsing(ctx, "deep", si.iteration)
+
+		keeper.RemoveEntriesWithPrefixUsing(ctx, "key1", si.iteration)
+		expectedRemaining := []*types.DataEntry{
+			{Path: "key10.child1", Value: "value10child"},
+		}
+		if got := keeper.ExportStorageFromPrefixUsing(ctx, "", si.iteration); !reflect.DeepEqual(got, expectedRemaining) {
+			t.Errorf("%s: got remaining export %q, want %q", si.name, got, expectedRemaining)
+		}
+		if keeper.HasEntry(ctx, "key1") {
+			t.Errorf("%s: got leftover entries for key1 after removal", si.name)
+		}
+	}
+}
+
+func TestEstimateSubtreeIteration(t *testing.T) {
+	tk := makeTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+	populateForBenchmark(ctx, keeper)
+	store := ctx.KVStore(keeper.storeKey)
+
+	for _, tc := range []struct {
+		pathPrefix string
+		expected   SubtreeIteration
+	}{
+		{"", SubtreeIterationFullStore},
+		{"small.subtree.", SubtreeIterationByDepth},
+		{"big.", SubtreeIterationByDepth},
+		{"nonexistent.", SubtreeIterationByDepth},
+	} {
+		if got := estimateSubtreeIteration(store, tc.pathPrefix); got != tc.expected {
+			t.Errorf("%q: got iteration %d, want %d", tc.pathPrefix, got, tc.expected)
+		}
+	}
+
+	// A subtree with nearly everything is cheaper to find in the full store.
+	keeper.RemoveEntriesWithPrefix(ctx, "small")
+	if got := estimateSubtreeIteration(store, "big."); got != SubtreeIterationFullStore {
+		t.Errorf("%q: got iteration %d, want %d", "big.", got, SubtreeIterationFullStore)
+	}
+}
+
+// populateForBenchmark fills storage with a large tree under "big" and a
+// small one under "small.subtree".
+func populateForBenchmark(ctx sdk.Context, keeper Keeper) {
+	for i := 0; i < 100; i++ {
+		for j := 0; j < 100; j++ {
+			keeper.SetStorage(ctx, agoric.NewKVEntry(fmt.Sprintf("big.child%d.leaf%d", i, j), "value"))
+		}
+	}
+	for i := 0; i < 10; i++ {
+		keeper.SetStorage(ctx, agoric.NewKVEntry(fmt.Sprintf("small.subtree.leaf%d", i), "value"))
+	}
+}
+
+func BenchmarkExportStorageFromPrefix(b *testing.B) {
+	tk := makeTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+	populateForBenchmark(ctx, keeper)
+
+	for _, si := range subtreeIterations {
+		for _, path := range []string{"small.subtree", "big", ""} {
+			b.Run(fmt.Sprintf("%s/%q", si.name, path), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					keeper.ExportStorageFromPrefixUsing(ctx, path, si.iteration)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRemoveEntriesWithPrefix(b *testing.B) {
+	for _, si := range subtreeIterations {
+		for _, path := range []string{"small.subtree", "big"} {
+			b.Run(fmt.Sprintf("%s/%q", si.name, path), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					tk := makeTestKit()
+					ctx, keeper := tk.ctx, tk.vstorageKeeper
+					populateForBenchmark(ctx, keeper)
+					ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+					b.StartTimer()
+
+					keeper.RemoveEntriesWithPrefixUsing(ctx, path, si.iteration)
+
+					b.ReportMetric(float64(ctx.GasMeter().GasConsumed()), "gas/op")
+				}
+			})
+		}
+	}
+}
+
+func TestChangeWatcher(t *testing.T) {
+	cw := NewChangeWatcher(2, 1)
+	publish := func(height int64, paths ...string) {
+		for _, path := range paths {
+			cw.Record(ProposedChange{Path: path, NewValue: fmt.Sprintf("%s@%d", path, height)})
+		}
+		cw.Publish(height)
+	}
+	receivedPaths := func(sub *ChangeSubscription) []string {
+		paths := []string{}
+		for {
+			select {
+			case block, ok := <-sub.C:
+				if !ok {
+					return append(paths, "closed")
+				}
+				for _, change := range block.Changes {
+					paths = append(paths, fmt.Sprintf("%d:%s", block.BlockHeight, change.Path))
+				}
+			default:
+				return paths
+			}
+		}
+	}
+
+	publish(1, "a", "b")
+	publish(2, "a.x", "ab")
+	publish(3, "c")
+
+	// Only blocks 2 and 3 are retained.
+	if _, err := cw.Subscribe("", 1); err == nil || !strings.Contains(err.Error(), "not retained") {
+		t.Errorf("resume from height 1: got error %v", err)
+	}
+	resumed, err := cw.Subscribe("a", 2)
+	if err != nil {
+		t.Fatalf("resume from height 2: got error %v", err)
+	}
+	future, err := cw.Subscribe("", 4)
+	if err != nil {
+		t.Fatalf("resume from height 4: got error %v", err)
+	}
+	if got, want := receivedPaths(resumed), []string{"2:a.x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed subscription got %v, want %v", got, want)
+	}
+	if got, want := receivedPaths(future), []string{}; !reflect.DeepEqual(got, want) {
+		t.Errorf("future subscription got %v, want %v", got, want)
+	}
+
+	// A subscriber that falls behind is dropped without blocking publication,
+	// but blocks without matching changes do not count against it.
+	publish(4, "a")
+	publish(5, "c")
+	publish(6, "a", "d")
+	if got, want := receivedPaths(resumed), []string{"4:a", "6:a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered subscription got %v, want %v", got, want)
+	}
+	if got, want := receivedPaths(future), []string{"4:a", "closed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lagging subscription got %v, want %v", got, want)
+	}
+	if got := future.ResumeHeight(); got != 5 {
+		t.Errorf("lagging subscription resume height got %d, want 5", got)
+	}
+
+	// Subscribers below a removed subtree see the removal.
+	below, err := cw.Subscribe("a.x", 0)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	cw.Record(ProposedChange{Path: "a", Deleted: true, Subtree: true})
+	cw.Record(ProposedChange{Path: "a.y", Deleted: true})
+	cw.Publish(7)
+	if got, want := receivedPaths(below), []string{"7:a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subscription below removed subtree got %v, want %v", got, want)
+	}
+
+	// Unsubscribing is idempotent.
+	sub, err := cw.Subscribe("", 0)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	cw.Unsubscribe(sub)
+	cw.Unsubscribe(sub)
+	if got, want := receivedPaths(sub), []string{"closed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unsubscribed subscription got %v, want %v", got, want)
+	}
+	if got := sub.ResumeHeight(); got != 0 {
+		t.Errorf("unsubscribed subscription resume height got %d, want 0", got)
+	}
+}
+
+func TestStorageQuotas(t *testing.T) {
+	tk := makeQuotaTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+	// Data that predates the quotas is accounted when they first apply.
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.a.x", "12345"))    // 13 + 5
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.b", "1234567890")) // 11 + 10
+	keeper.SetStorage(ctx, agoric.NewKVEntry("other", "unaccounted"))
+
+	keeper.SetParams(ctx, types.Params{StorageQuotas: []types.StorageQuota{
+		types.NewStorageQuota("published", true, 40),
+		types.NewStorageQuota("published.b", false, 0),
+	}})
+
+	checkUsages := func(label, path string, want []types.StorageUsage) {
+		t.Helper()
+		got, next, err := keeper.GetStorageUsages(ctx, path, "", MaxUsageEntries, MaxUsageScannedEntries)
+		if err != nil || next != "" {
+			t.Errorf("%s: got next path %q and error %v", label, next, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got usages %+v, want %+v", label, got, want)
+		}
+	}
+	checkUsages("initial", "", []types.StorageUsage{
+		{Path: "published.a", Bytes: 18, MaxBytes: 40},
+		{Path: "published.b", Bytes: 21, MaxBytes: 40},
+	})
+	checkUsages("filtered", "published.b", []types.StorageUsage{
+		{Path: "published.b", Bytes: 21, MaxBytes: 40},
+	})
+
+	// 18 + 13 + 9 = 40 fits exactly.
+	if err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.a.y", "123456789")); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.a.z", ""))
+	if err == nil || !strings.Contains(err.Error(), "storage quota exceeded") {
+		t.Errorf("got error %v, want quota exceeded", err)
+	}
+	if keeper.HasEntry(ctx, "published.a.z") {
+		t.Errorf("got entry for write that exceeded quota")
+	}
+	// Appending is subject to the same quota.
+	if err := keeper.AppendStorageValueAndNotify(ctx, "published.a.x", "1"); err == nil {
+		t.Errorf("got no error appending beyond quota")
+	}
+	// Writes that do not increase usage are allowed, and other paths are
+	// unaffected.
+	if err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.a.y", "987654321")); err != nil {
+		t.Errorf("got error %v for same-size write", err)
+	}
+	if err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.c", "1234567890123456789")); err != nil {
+		t.Errorf("got error %v for separately accounted path", err)
+	}
+	checkUsages("after writes", "", []types.StorageUsage{
+		{Path: "published.a", Bytes: 40, MaxBytes: 40},
+		{Path: "published.b", Bytes: 21, MaxBytes: 40},
+		{Path: "published.c", Bytes: 30, MaxBytes: 40},
+	})
+
+	// Usage is maintained by every kind of write.
+	keeper.SetStorage(ctx, agoric.NewKVEntryWithNoValue("published.a.y"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.b.deep.child", "1"))
+	checkUsages("after writes without notify", "", []types.StorageUsage{
+		{Path: "published.a", Bytes: 18, MaxBytes: 40},
+		{Path: "published.b", Bytes: 44, MaxBytes: 40},
+		{Path: "published.c", Bytes: 30, MaxBytes: 40},
+	})
+	keeper.RemoveEntriesWithPrefix(ctx, "published.b")
+	if err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.b", "")); err != nil {
+		t.Errorf("got error %v after removing subtree", err)
+	}
+	checkUsages("after removal", "published.b", []types.StorageUsage{
+		{Path: "published.b", Bytes: 11, MaxBytes: 40},
+	})
+
+	// A batch that would exceed a quota has no effect at all.
+	err = keeper.SetStoragesAndNotify(ctx, []agoric.KVEntry{
+		agoric.NewKVEntry("published.d.fits", "1"),
+		agoric.NewKVEntry("published.a.excess", "123456789"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "storage quota exceeded") {
+		t.Errorf("got batch error %v, want quota exceeded", err)
+	}
+	err = keeper.AppendStorageValuesAndNotify(ctx, []agoric.KVEntry{
+		agoric.NewKVEntry("published.d.fits", "1"),
+		agoric.NewKVEntry("published.a.x", "1"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "storage quota exceeded") {
+		t.Errorf("got append batch error %v, want quota exceeded", err)
+	}
+	if keeper.HasEntry(ctx, "published.d") {
+		t.Errorf("got entry for batch that exceeded quota")
+	}
+	checkUsages("after failed batches", "published.d", []types.StorageUsage{})
+
+	// Unaccounted writes record no usage, and a change to the quotas discards
+	// recorded usage at the beginning of the next block (after which it is
+	// recomputed).
+	usageStore := ctx.KVStore(usageStoreKey)
+	keeper.SetStorage(ctx, agoric.NewKVEntry("other.deep", "unaccounted"))
+	if usageStore.Has(types.PathToEncodedKey("other")) {
+		t.Errorf("got recorded usage for unaccounted path")
+	}
+	keeper.SetParams(ctx, types.Params{StorageQuotas: []types.StorageQuota{
+		types.NewStorageQuota("other", false, 100),
+	}})
+	if !usageStore.Has(types.PathToEncodedKey("published.a")) {
+		t.Errorf("got usage records discarded before the next block")
+	}
+	keeper.SyncUsageRecords(ctx)
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.a.x", ""))
+	if usageStore.Has(types.PathToEncodedKey("published.a")) {
+		t.Errorf("got recorded usage for path that is no longer accounted")
+	}
+	checkUsages("after quota change", "", []types.StorageUsage{
+		{Path: "other", Bytes: 37, MaxBytes: 100},
+	})
+}
+
+func TestStorageUsagePaging(t *testing.T) {
+	tk := makeQuotaTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.a", "1"))     // 11 + 1
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.b.x", "123")) // 13 + 3
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.b.y", "1"))   // 13 + 1
+	keeper.SetStorage(ctx, agoric.NewKVEntry("published.c", "12"))    // 11 + 2
+	keeper.SetParams(ctx, types.Params{StorageQuotas: []types.StorageQuota{
+		types.NewStorageQuota("published", true, 100),
+	}})
+	keeper.SyncUsageRecords(ctx)
+
+	// Queries never write, not even to record the usages they compute.
+	usageStore := ctx.KVStore(usageStoreKey)
+	countUsageEntries := func() int {
+		n := 0
+		iterator := usageStore.Iterator(nil, nil)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			n++
+		}
+		return n
+	}
+	before := countUsageEntries()
+
+	testCases := []struct {
+		label      string
+		path       string
+		startPath  string
+		limit      int
+		maxScanned int
+		expected   []string
+		nextPath   string
+		errors     bool
+	}{
+		{label: "all", limit: 10, maxScanned: 100,
+			expected: []string{"published.a:12", "published.b:30", "published.c:13"}},
+		{label: "first page", limit: 2, maxScanned: 100,
+			expected: []string{"published.a:12", "published.b:30"}, nextPath: "published.c"},
+		{label: "next page", startPath: "published.c", limit: 2, maxScanned: 100,
+			expected: []string{"published.c:13"}},
+		{label: "start between children", startPath: "published.a.z", limit: 10, maxScanned: 100,
+			expected: []string{"published.b:30", "published.c:13"}},
+		{label: "within path", path: "published.b", limit: 10, maxScanned: 100,
+			expected: []string{"published.b:30"}},
+		{label: "below accounted path", path: "published.b.x", limit: 10, maxScanned: 100,
+			expected: []string{}},
+		{label: "scan budget", limit: 10, maxScanned: 3,
+			expected: []string{"published.a:12"}, nextPath: "published.b"},
+		{label: "scan budget exceeded by first usage", startPath: "published.b", limit: 10, maxScanned: 2,
+			errors: true},
+	}
+	for _, desc := range testCases {
+		usages, nextPath, err := keeper.GetStorageUsages(ctx, desc.path, desc.startPath, desc.limit, desc.maxScanned)
+		if desc.errors {
+			if err == nil {
+				t.Errorf("%s: got no error, want an error", desc.label)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: got unexpected error %v", desc.label, err)
+			continue
+		}
+		got := []string{}
+		for _, usage := range usages {
+			got = append(got, fmt.Sprintf("%s:%d", usage.Path, usage.Bytes))
+		}
+		if !reflect.DeepEqual(got, desc.expected) {
+			t.Errorf("%s: got usages %v, want %v", desc.label, got, desc.expected)
+		}
+		if nextPath != desc.nextPath {
+			t.Errorf("%s: got next path %q, want %q", desc.label, nextPath, desc.nextPath)
+		}
+	}
+	if after := countUsageEntries(); after != before {
+		t.Errorf("got %d usage store entries after queries, want %d", after, before)
+	}
+
+	// Once recorded by a write, a usage is not recomputed by queries.
+	if err := keeper.SetStorageAndNotify(ctx, agoric.NewKVEntry("published.b.z", "")); err != nil { // 13
+		t.Fatalf("got error %v", err)
+	}
+	usages, _, err := keeper.GetStorageUsages(ctx, "published.b", "", 10, 0)
+	if err != nil || len(usages) != 1 || usages[0].Bytes != 43 {
+		t.Errorf("got recorded usages %+v and error %v, want 43 bytes", usages, err)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tk := makeTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+	values := func(items []QueueItem) []string {
+		values := []string{}
+		for _, item := range items {
+			values = append(values, fmt.Sprintf("%s:%s", item.Index, item.Value))
+		}
+		return values
+	}
+
+	for _, value := range []string{"a", "b", "c", "d"} {
+		if err := keeper.PushQueueItem(ctx, "q", value); err != nil {
+			t.Fatalf("push %s: got error %v", value, err)
+		}
+	}
+	if err := keeper.CheckQueueInvariants(ctx, "q"); err != nil {
+		t.Errorf("got invariant violation %v", err)
+	}
+
+	peeked, err := keeper.PeekQueueItems(ctx, "q", 2)
+	if err != nil {
+		t.Fatalf("peek: got error %v", err)
+	}
+	if got, want := values(peeked), []string{"0:a", "1:b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("peek: got %v, want %v", got, want)
+	}
+
+	popped, err := keeper.PopQueueItems(ctx, "q", 3)
+	if err != nil {
+		t.Fatalf("pop: got error %v", err)
+	}
+	if got, want := values(popped), []string{"0:a", "1:b", "2:c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pop: got %v, want %v", got, want)
+	}
+	if length, _ := keeper.GetQueueLength(ctx, "q"); length.Int64() != 1 {
+		t.Errorf("got length %s after pop, want 1", length)
+	}
+	if keeper.HasEntry(ctx, "q.0") {
+		t.Errorf("got popped item remaining")
+	}
+
+	keeper.PushQueueItem(ctx, "q", "e")
+	iterated := []string{}
+	err = keeper.IterateQueue(ctx, "q", func(item QueueItem) bool {
+		iterated = append(iterated, item.Value)
+		return false
+	})
+	if err != nil {
+		t.Fatalf("iterate: got error %v", err)
+	}
+	if want := []string{"d", "e"}; !reflect.DeepEqual(iterated, want) {
+		t.Errorf("iterate: got %v, want %v", iterated, want)
+	}
+
+	popped, err = keeper.PopQueueItems(ctx, "q", 0)
+	if err != nil {
+		t.Fatalf("pop all: got error %v", err)
+	}
+	if got, want := values(popped), []string{"3:d", "4:e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pop all: got %v, want %v", got, want)
+	}
+	if popped, _ := keeper.PopQueueItems(ctx, "q", 0); len(popped) != 0 {
+		t.Errorf("pop empty: got %v", values(popped))
+	}
+	if err := keeper.CheckQueueInvariants(ctx, "q"); err != nil {
+		t.Errorf("got invariant violation %v for empty queue", err)
+	}
+
+	violations := []struct {
+		label       string
+		entries     map[string]string
+		errContains string
+	}{
+		{"head after tail", map[string]string{"head": "3", "tail": "2"}, "after tail"},
+		{"negative head", map[string]string{"head": "-1", "tail": "0"}, "negative"},
+		{"hole", map[string]string{"head": "0", "tail": "2", "1": "x"}, "has 1 items but length 2"},
+		{"stale item", map[string]string{"head": "1", "tail": "2", "0": "x", "1": "y"}, "outside of head"},
+		{"unexpected child", map[string]string{"tail": "1", "0": "x", "01": "y"}, "unexpected child"},
+		{"bad tail", map[string]string{"tail": "x"}, "couldn't parse"},
+	}
+	for _, desc := range violations {
+		queuePath := "bad"
+		keeper.RemoveEntriesWithPrefix(ctx, queuePath)
+		for child, value := range desc.entries {
+			keeper.SetStorage(ctx, agoric.NewKVEntry(queuePath+"."+child, value))
+		}
+		err := keeper.CheckQueueInvariants(ctx, queuePath)
+		if err == nil || !strings.Contains(err.Error(), desc.errContains) {
+			t.Errorf("%s: got error %v, want error %q", desc.label, err, desc.errContains)
+		}
+	}
+
+	// Popping fails without effect at a hole.
+	keeper.RemoveEntriesWithPrefix(ctx, "bad")
+	keeper.SetStorage(ctx, agoric.NewKVEntry("bad.tail", "2"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("bad.0", "x"))
+	if _, err := keeper.PopQueueItems(ctx, "bad", 0); err == nil || !strings.Contains(err.Error(), "no item at index 1") {
+		t.Errorf("pop with hole: got error %v", err)
+	}
+	if !keeper.HasStorage(ctx, "bad.0") {
+		t.Errorf("pop with hole: got item removed")
+	}
+}
+
+func TestStoreStructureInvariant(t *testing.T) {
+	tk := makeTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+
+	keeper.SetStorage(ctx, agoric.NewKVEntry("a.b.c", "abc"))
+	keeper.SetStorage(ctx, agoric.NewKVEntry("d", ""))
+	invariant := StoreStructureInvariant(keeper)
+	if msg, broken := invariant(ctx); broken {
+		t.Fatalf("got broken invariant for consistent store: %s", msg)
+	}
+
+	store := ctx.KVStore(vstorageStoreKey)
+	store.Set([]byte("1\x00e"), types.EncodedNoDataValue)
+	store.Set([]byte("3\x00f\x00g\x00h"), []byte("\x00fgh"))
+	store.Set([]byte("2\x00i"), []byte("\x00i"))
+	store.Delete(types.PathToEncodedKey("a.b"))
+	store.Set(types.PathToEncodedKey("d"), []byte("no prefix"))
+	store.Set([]byte("nonsense"), []byte("\x00"))
+
+	problems, count := keeper.CheckStoreStructure(ctx, 0)
+	expected := []string{
+		`placeholder at path "a" has no children`,
+		`value at path "d" lacks the data prefix`,
+		`placeholder at path "e" has no children`,
+		`key "2\x00i" for path "i" should be "1\x00i"`,
+		`entry at path "a.b.c" has no parent entry`,
+		`entry at path "f.g.h" has no parent entry`,
+		`key "nonsense" has no separator`,
+	}
+	if !reflect.DeepEqual(problems, expected) || count != len(expected) {
+		t.Errorf("got %d problems %q, want %q", count, problems, expected)
+	}
+
+	problems, count = keeper.CheckStoreStructure(ctx, 2)
+	if len(problems) != 2 || count != len(expected) {
+		t.Errorf("got %d of %d problems, want 2 of %d", len(problems), count, len(expected))
+	}
+	msg, broken := invariant(ctx)
+	if !broken || !strings.Contains(msg, "found 7 problems") {
+		t.Errorf("got invariant %t with message %q", broken, msg)
+	}
+
+	repairs := keeper.RepairStoreStructure(ctx)
+	if msg, broken := invariant(ctx); broken {
+		t.Errorf("got broken invariant after repairs %q: %s", repairs, msg)
+	}
+	expectedData := []*types.DataEntry{
+		{Path: "d", Value: "no prefix"},
+		{Path: "i", Value: "i"},
+		{Path: "a.b.c", Value: "abc"},
+		{Path: "f.g.h", Value: "fgh"},
+	}
+	if got := keeper.ExportStorage(ctx); !reflect.DeepEqual(got, expectedData) {
+		t.Errorf("got repaired data %q, want %q", got, expectedData)
+	}
+	if repairs := keeper.RepairStoreStructure(ctx); len(repairs) != 0 {
+		t.Errorf("got repairs of repaired store %q", repairs)
+	}
+}
+
+func TestStreamCellRetention(t *testing.T) {
+	tk := makeQuotaTestKit()
+	ctx, keeper := tk.ctx, tk.vstorageKeeper
+	querier := Querier{keeper}
+
+	keeper.SetParams(ctx, types.Params{StreamCellRetentions: []types.StreamCellRetention{
+		types.NewStreamCellRetention("published", 2),
+	}})
+
+	appendAt := func(height int64, path string, n int) {
+		t.Helper()
+		ctx = ctx.WithBlockHeight(height)
+		value := mustJsonMarshal(map[string]any{"body": fmt.Sprintf("#%d", n), "slots": []any{}})
+		if err := keeper.AppendStorageValueAndNotify(ctx, path, value); err != nil {
+			t.Fatalf("append %d at %d: got error %v", n, height, err)
+		}
+	}
+	checkHistory := func(label string, want []*types.CapDataCell) {
+		t.Helper()
+		request := types.QueryCapDataRequest{Path: "published.a", RemotableValueFormat: "string", HistoryLimit: 5}
+		resp, err := querier.CapData(sdk.WrapSDKContext(ctx), &request)
+		if err != nil {
+			t.Fatalf("%s: got error %v", label, err)
+		}
+		if !reflect.DeepEqual(resp.History, want) {
+			t.Errorf("%s: got history %v, want %v", label, resp.History, want)
+		}
+	}
+
+	// Only cells replaced by a later block are retained, in a ring of 2.
+	appendAt(1, "published.a", 1)
+	appendAt(1, "published.a", 2)
+	appendAt(2, "published.a", 3)
+	appendAt(3, "published.a", 4)
+	appendAt(4, "published.a", 5)
+	appendAt(4, "other", 1)
+	appendAt(5, "other", 2)
+	if keeper.HasEntry(ctx, "published.a-history") || keeper.HasEntry(ctx, "other-history") {
+		t.Errorf("got history at a vstorage path")
+	}
+	countHistory := func(prefix []byte) int {
+		iterator := sdk.KVStorePrefixIterator(keeper.streamCellHistoryStore(ctx), prefix)
+		defer iterator.Close()
+		n := 0
+		for ; iterator.Valid(); iterator.Next() {
+			n++
+		}
+		return n
+	}
+	countSlots := func() int {
+		return countHistory(streamCellSlotsPrefix("published.a"))
+	}
+	if got := countSlots(); got != 2 {
+		t.Errorf("got %d slots, want 2", got)
+	}
+	checkHistory("full ring", []*types.CapDataCell{
+		{BlockHeight: "3", Value: "4"},
+		{BlockHeight: "2", Value: "3"},
+	})
+
+	// Reducing the retention hides the excess slots, and drops them at the
+	// beginning of the next block.
+	keeper.SetParams(ctx, types.Params{StreamCellRetentions: []types.StreamCellRetention{
+		types.NewStreamCellRetention("published", 1),
+	}})
+	appendAt(5, "published.a", 6)
+	checkHistory("reduced ring", []*types.CapDataCell{
+		{BlockHeight: "4", Value: "5"},
+	})
+	keeper.SyncStreamCellHistory(ctx)
+	if got := countSlots(); got != 1 {
+		t.Errorf("got %d slots, want 1", got)
+	}
+	checkHistory("trimmed ring", []*types.CapDataCell{
+		{BlockHeight: "4", Value: "5"},
+	})
+
+	// Disabling retention hides the ring, and removes it at the beginning of
+	// the next block.
+	keeper.SetParams(ctx, types.DefaultParams())
+	appendAt(6, "published.a", 7)
+	checkHistory("no ring", []*types.CapDataCell{})
+	keeper.SyncStreamCellHistory(ctx)
+	if got := countHistory(nil); got != 0 {
+		t.Errorf("got %d history entries without retention, want 0", got)
+	}
+
+	// Removing a path removes its ring, whether directly or with its subtree.
+	keeper.SetParams(ctx, types.Params{StreamCellRetentions: []types.StreamCellRetention{
+		types.NewStreamCellRetention("published", 2),
+	}})
+	keeper.SyncStreamCellHistory(ctx)
+	for _, path := range []string{"published.a", "published.b.c", "published.b.d"} {
+		appendAt(10, path, 1)
+		appendAt(11, path, 2)
+	}
+	// Each ring has a next slot index, and published.a also retains its cell
+	// from before.
+	if got := countHistory(nil); got != 7 {
+		t.Errorf("got %d history entries, want 7", got)
+	}
+	keeper.SetStorage(ctx, agoric.NewKVEntryWithNoValue("published.a"))
+	if got := countHistory(types.PathToEncodedKey("published.a")); got != 0 {
+		t.Errorf("got %d history entries for deleted path, want 0", got)
+	}
+	appendAt(12, "published.a", 3)
+	checkHistory("recreated path", []*types.CapDataCell{})
+	keeper.RemoveEntriesWithPrefix(ctx, "published.b")
+	if got := countHistory(nil); got != 0 {
+		t.Errorf("got %d history entries after removing subtree, want 0", got)
+	}
+
+	// Without retention, appending leaves data at a path named like a ring
+	// alone.
+	keeper.SetStorage(ctx, agoric.NewKVEntry("a.log-history.x", "unrelated"))
+	appendAt(7, "a.log", 1)
+	appendAt(8, "a.log", 2)
+	if got := keeper.GetEntry(ctx, "a.log-history.x").StringValue(); got != "unrelated" {
+		t.Errorf("got %q at a.log-history.x, want %q", got, "unrelated")
+	}
+}
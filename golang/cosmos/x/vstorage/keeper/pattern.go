@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// DefaultMaxPatternMatches is how many matching paths a single path pattern
+// query can return unless configured otherwise.
+const DefaultMaxPatternMatches = 1000
+
+// DefaultMaxPatternVisits is how many entries a single path pattern query can
+// read while looking for matches unless configured otherwise.
+const DefaultMaxPatternVisits = 100000
+
+// WithMaxPatternMatches returns a copy of the keeper that limits path pattern
+// queries to max matching paths, or to DefaultMaxPatternMatches if max is not
+// positive.
+func (k Keeper) WithMaxPatternMatches(max int) Keeper {
+	k.maxPatternMatches = max
+	return k
+}
+
+// MaxPatternMatches returns how many matching paths a single path pattern
+// query can return.
+func (k Keeper) MaxPatternMatches() int {
+	if k.maxPatternMatches <= 0 {
+		return DefaultMaxPatternMatches
+	}
+	return k.maxPatternMatches
+}
+
+// WithMaxPatternVisits returns a copy of the keeper that limits path pattern
+// queries to reading max entries, or DefaultMaxPatternVisits if max is not
+// positive.
+func (k Keeper) WithMaxPatternVisits(max int) Keeper {
+	k.maxPatternVisits = max
+	return k
+}
+
+// MaxPatternVisits returns how many entries a single path pattern query can
+// read while looking for matches.
+func (k Keeper) MaxPatternVisits() int {
+	if k.maxPatternVisits <= 0 {
+		return DefaultMaxPatternVisits
+	}
+	return k.maxPatternVisits
+}
+
+// IteratePathPatternMatches calls cb with each nonempty path that has an entry
+// and matches pattern, in types.ComparePaths order starting from startPath
+// (unless it is empty), until either cb returns true or there are no more
+// matches. Each level is resolved by point reads where the pattern admits only
+// literal segments, and otherwise by a scan of the children prefix (starting
+// from the branch of startPath, if any).
+// If maxVisits is positive, the iteration also stops before reading more than
+// that many entries (not counting the ancestors of startPath), in which case
+// it returns the nonempty path from which to resume as a new startPath.
+func (k Keeper) IteratePathPatternMatches(ctx sdk.Context, pattern types.PathPattern, startPath string, maxVisits int, cb func(path string) (stop bool)) (resumePath string) {
+	store := ctx.KVStore(k.storeKey)
+
+	// isBeforeStart tells if path and all of its descendants precede startPath.
+	isBeforeStart := func(path string) bool {
+		if len(startPath) == 0 || strings.HasPrefix(startPath, path+types.PathSeparator) {
+			return false
+		}
+		return types.ComparePaths(path, startPath) < 0
+	}
+
+	// spend accounts for reading the entry at path, and tells if it is
+	// within the budget.
+	visits := 0
+	spend := func(path string) bool {
+		if maxVisits <= 0 || strings.HasPrefix(startPath, path+types.PathSeparator) {
+			return true
+		}
+		if visits >= maxVisits {
+			resumePath = path
+			return false
+		}
+		visits++
+		return true
+	}
+
+	var visit func(path string, positions []int) bool
+	// visitChild visits a child that has an entry, and tells if iteration
+	// should stop.
+	visitChild := func(path, child string, positions []int) bool {
+		next := pattern.Advance(positions, child)
+		return len(next) > 0 && visit(joinPath(path, child), next)
+	}
+	visit = func(path string, positions []int) bool {
+		if len(path) > 0 && pattern.IsMatch(positions) && types.ComparePaths(path, startPath) >= 0 {
+			if cb(path) {
+				return true
+			}
+		}
+
+		if literals, ok := pattern.LiteralSegments(positions); ok {
+			sort.Strings(literals)
+			for i, child := range literals {
+				childPath := joinPath(path, child)
+				if (i > 0 && child == literals[i-1]) || isBeforeStart(childPath) {
+					continue
+				}
+				if !spend(childPath) {
+					return true
+				}
+				if k.HasEntry(ctx, childPath) && visitChild(path, child, positions) {
+					return true
+				}
+			}
+			return false
+		}
+
+		// Scan the children, starting from the branch of startPath.
+		childrenPrefix := types.PathToChildrenPrefix(path)
+		start := childrenPrefix
+		if rest, ok := strings.CutPrefix(startPath, joinPath(path, "")); ok && len(rest) > 0 {
+			startChild, _, _ := strings.Cut(rest, types.PathSeparator)
+			start = types.PathToEncodedKey(joinPath(path, startChild))
+		}
+		iterator := store.Iterator(start, sdk.PrefixEndBytes(childrenPrefix))
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			childPath := types.EncodedKeyToPath(iterator.Key())
+			if isBeforeStart(childPath) {
+				continue
+			}
+			if !spend(childPath) {
+				return true
+			}
+			child := childPath[strings.LastIndex(childPath, types.PathSeparator)+1:]
+			if visitChild(path, child, positions) {
+				return true
+			}
+		}
+		return false
+	}
+	visit("", pattern.Start())
+	return resumePath
+}
+
+func joinPath(path, child string) string {
+	if len(path) == 0 {
+		return child
+	}
+	return path + types.PathSeparator + child
+}
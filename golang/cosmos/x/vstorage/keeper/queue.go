@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// A queue stores items at paths like "$queuePath.$n", documenting the n for
+// the next item to be consumed at "$queuePath.head" and the n for the next item
+// to be pushed at "$queuePath.tail" (each defaulting to zero), such that the
+// queue is empty when both are the same n. This is the format of `makeQueue`
+// in packages/cosmic-swingset/src/helpers/make-queue.js.
+
+// QueueItem is a queue item along with its index.
+type QueueItem struct {
+	Index sdkmath.Int
+	Value string
+}
+
+func queueItemPath(queuePath string, index sdkmath.Int) string {
+	return queuePath + types.PathSeparator + index.String()
+}
+
+// GetQueueBounds returns the index of the first item of a queue and the index
+// after its last item.
+func (k Keeper) GetQueueBounds(ctx sdk.Context, queuePath string) (head, tail sdkmath.Int, err error) {
+	head, err = k.GetIntValue(ctx, queuePath+".head")
+	if err != nil {
+		return head, tail, err
+	}
+	tail, err = k.GetIntValue(ctx, queuePath+".tail")
+	return head, tail, err
+}
+
+// IterateQueue calls cb with each item of a queue in order from its head,
+// until either cb returns true or there are no more items. It fails upon
+// reaching an index that has no item.
+func (k Keeper) IterateQueue(ctx sdk.Context, queuePath string, cb func(item QueueItem) (stop bool)) error {
+	return k.IterateQueueFrom(ctx, queuePath, sdkmath.ZeroInt(), cb)
+}
+
+// IterateQueueFrom is like IterateQueue, but starts from startIndex if that
+// is after the head.
+func (k Keeper) IterateQueueFrom(ctx sdk.Context, queuePath string, startIndex sdkmath.Int, cb func(item QueueItem) (stop bool)) error {
+	head, tail, err := k.GetQueueBounds(ctx, queuePath)
+	if err != nil {
+		return err
+	}
+	if startIndex.GT(head) {
+		head = startIndex
+	}
+	for index := head; index.LT(tail); index = index.AddRaw(1) {
+		entry := k.GetEntry(ctx, queueItemPath(queuePath, index))
+		if !entry.HasValue() {
+			return fmt.Errorf("queue %s has no item at index %s", queuePath, index)
+		}
+		if cb(QueueItem{Index: index, Value: entry.StringValue()}) {
+			break
+		}
+	}
+	return nil
+}
+
+// PeekQueueItems returns up to max items from the head of a queue (or every
+// item if max is zero) without removing them.
+func (k Keeper) PeekQueueItems(ctx sdk.Context, queuePath string, max int) ([]QueueItem, error) {
+	items := []QueueItem{}
+	err := k.IterateQueue(ctx, queuePath, func(item QueueItem) bool {
+		items = append(items, item)
+		return max > 0 && len(items) >= max
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// PopQueueItems removes and returns up to max items from the head of a queue
+// (or every item if max is zero). It fails without effect if any of those
+// items is missing.
+func (k Keeper) PopQueueItems(ctx sdk.Context, queuePath string, max int) ([]QueueItem, error) {
+	items, err := k.PeekQueueItems(ctx, queuePath, max)
+	if err != nil || len(items) == 0 {
+		return items, err
+	}
+	for _, item := range items {
+		k.SetStorage(ctx, agoric.NewKVEntryWithNoValue(queueItemPath(queuePath, item.Index)))
+	}
+	nextHead := items[len(items)-1].Index.AddRaw(1)
+	k.SetStorage(ctx, agoric.NewKVEntry(queuePath+".head", nextHead.String()))
+	return items, nil
+}
+
+// CheckQueueInvariants returns an error if a queue's head is negative or after
+// its tail, if it is missing an item between them, or if it has any other
+// child (such as an item outside of them).
+func (k Keeper) CheckQueueInvariants(ctx sdk.Context, queuePath string) error {
+	head, tail, err := k.GetQueueBounds(ctx, queuePath)
+	if err != nil {
+		return err
+	}
+	if head.IsNegative() {
+		return fmt.Errorf("queue %s head %s is negative", queuePath, head)
+	}
+	if head.GT(tail) {
+		return fmt.Errorf("queue %s head %s is after tail %s", queuePath, head, tail)
+	}
+
+	// Count the items rather than visiting each index, which would take too
+	// long if a corrupt tail were very far from the head.
+	itemCount := sdkmath.ZeroInt()
+	for _, child := range k.GetChildren(ctx, queuePath).Children {
+		if child == "head" || child == "tail" {
+			continue
+		}
+		index, ok := sdkmath.NewIntFromString(child)
+		if !ok || index.String() != child {
+			return fmt.Errorf("queue %s has unexpected child %q", queuePath, child)
+		}
+		if index.LT(head) || index.GTE(tail) {
+			return fmt.Errorf("queue %s has item at index %s outside of head %s and tail %s", queuePath, index, head, tail)
+		}
+		if !k.HasStorage(ctx, queueItemPath(queuePath, index)) {
+			return fmt.Errorf("queue %s has no data at index %s", queuePath, index)
+		}
+		itemCount = itemCount.AddRaw(1)
+	}
+	if length := tail.Sub(head); !itemCount.Equal(length) {
+		return fmt.Errorf("queue %s has %s items but length %s", queuePath, itemCount, length)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// Previous StreamCells of a path with a nonzero StreamCellRetention are kept in
+// a ring in the store of usageStoreKey (apart from the paths of vstorage, so
+// that it can neither collide with data written by the VM nor count against
+// storage quotas). Under the history prefix, the encoded key of the path holds
+// the index of the next slot to overwrite, and the encoded key followed by
+// streamCellSlotSeparator and a big-endian slot index holds the StreamCell
+// written to that slot. The ring of a path is removed along with the path, and
+// rings are trimmed to the current params at the beginning of the first block
+// after the retentions change (as recorded under streamCellRetentionsKey).
+var (
+	streamCellHistoryKeyPrefix = []byte("streamCellHistory:")
+	// streamCellRetentionsKey holds the raw StreamCell retentions param to
+	// which the rings have been trimmed. Like storageQuotasKey, it cannot
+	// collide with the encoded key of a path or with the history prefix.
+	streamCellRetentionsKey = []byte("streamCellRetentions")
+	// streamCellSlotSeparator cannot appear in a path, so the slots of a path
+	// are not under the encoded key of any other path.
+	streamCellSlotSeparator = []byte("/")
+)
+
+func (k Keeper) streamCellHistoryStore(ctx sdk.Context) sdk.KVStore {
+	return prefix.NewStore(ctx.KVStore(k.usageStoreKey), streamCellHistoryKeyPrefix)
+}
+
+func streamCellSlotsPrefix(path string) []byte {
+	return append(types.PathToEncodedKey(path), streamCellSlotSeparator...)
+}
+
+func streamCellSlotKey(path string, slot uint32) []byte {
+	return binary.BigEndian.AppendUint32(streamCellSlotsPrefix(path), slot)
+}
+
+// archiveStreamCell retains cellData, the StreamCell of path from an earlier
+// block, in the history ring of path according to the current params. It does
+// nothing (not even reading the ring) for a path without retention.
+func (k Keeper) archiveStreamCell(ctx sdk.Context, path, cellData string) {
+	if !k.hasStorageQuotas() {
+		return
+	}
+	maxCells := k.GetParams(ctx).StreamCellRetentionFor(path)
+	if maxCells == 0 {
+		return
+	}
+	store := k.streamCellHistoryStore(ctx)
+	nextKey := types.PathToEncodedKey(path)
+
+	next := uint32(0)
+	if bz := store.Get(nextKey); len(bz) == 4 {
+		next = binary.BigEndian.Uint32(bz)
+	}
+	if next >= maxCells {
+		next = 0
+	}
+	// Any slots beyond a retention reduced within this block are hidden until
+	// SyncStreamCellHistory drops them.
+	store.Set(streamCellSlotKey(path, next), []byte(cellData))
+	store.Set(nextKey, binary.BigEndian.AppendUint32(nil, (next+1)%maxCells))
+}
+
+// GetRetainedStreamCells returns the StreamCells in the history ring of path
+// that precede beforeHeight, most recent first. A path without retention has
+// none, even if its ring has not yet been removed since its retention was.
+func (k Keeper) GetRetainedStreamCells(ctx sdk.Context, path string, beforeHeight int64) []StreamCell {
+	if !k.hasStorageQuotas() {
+		return nil
+	}
+	maxCells := k.GetParams(ctx).StreamCellRetentionFor(path)
+	if maxCells == 0 {
+		return nil
+	}
+	type heightCell struct {
+		height int64
+		cell   StreamCell
+	}
+	var retained []heightCell
+	iterator := sdk.KVStorePrefixIterator(k.streamCellHistoryStore(ctx), streamCellSlotsPrefix(path))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if slot := binary.BigEndian.Uint32(iterator.Key()[len(iterator.Key())-4:]); slot >= maxCells {
+			continue
+		}
+		var cell StreamCell
+		if err := json.Unmarshal(iterator.Value(), &cell); err != nil {
+			continue
+		}
+		height, err := strconv.ParseInt(cell.BlockHeight, 10, 64)
+		if err != nil || height >= beforeHeight {
+			continue
+		}
+		retained = append(retained, heightCell{height, cell})
+	}
+	sort.SliceStable(retained, func(i, j int) bool {
+		return retained[i].height > retained[j].height
+	})
+	cells := make([]StreamCell, len(retained))
+	for i, r := range retained {
+		cells[i] = r.cell
+	}
+	return cells
+}
+
+// clearStreamCellHistory removes the history ring of path, if any.
+func (k Keeper) clearStreamCellHistory(ctx sdk.Context, path string) {
+	if !k.hasStorageQuotas() {
+		return
+	}
+	store := k.streamCellHistoryStore(ctx)
+	nextKey := types.PathToEncodedKey(path)
+	// Every ring has a next slot index, so a path without one has no ring.
+	if !store.Has(nextKey) {
+		return
+	}
+	trimStreamCellHistory(store, sdk.KVStorePrefixIterator(store, streamCellSlotsPrefix(path)), func(string) uint32 {
+		return 0
+	})
+	store.Delete(nextKey)
+}
+
+// SyncStreamCellHistory trims every history ring to the current StreamCell
+// retentions if they have changed since the rings were last trimmed, removing
+// the rings of paths that no longer have retention. It is called at the
+// beginning of each block.
+func (k Keeper) SyncStreamCellHistory(ctx sdk.Context) {
+	if !k.hasStorageQuotas() {
+		return
+	}
+	usageStore := ctx.KVStore(k.usageStoreKey)
+	rawRetentions := k.paramSpace.GetRaw(ctx, types.ParamStoreKeyStreamCellRetentions)
+	if bytes.Equal(usageStore.Get(streamCellRetentionsKey), rawRetentions) {
+		return
+	}
+	params := k.GetParams(ctx)
+	store := k.streamCellHistoryStore(ctx)
+	trimStreamCellHistory(store, store.Iterator(nil, nil), params.StreamCellRetentionFor)
+	if rawRetentions == nil {
+		usageStore.Delete(streamCellRetentionsKey)
+	} else {
+		usageStore.Set(streamCellRetentionsKey, rawRetentions)
+	}
+}
+
+// trimStreamCellHistory removes the history entries from iterator (which it
+// closes) that are beyond the retention of their path, including the next
+// slot index of a path without retention.
+func trimStreamCellHistory(store sdk.KVStore, iterator sdk.Iterator, retentionFor func(path string) uint32) {
+	// Collect keys before deleting any, so as not to disturb the iteration.
+	var excess [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		encodedKey, slotBytes, isSlot := bytes.Cut(key, streamCellSlotSeparator)
+		maxCells := retentionFor(types.EncodedKeyToPath(encodedKey))
+		if isSlot && len(slotBytes) == 4 {
+			if binary.BigEndian.Uint32(slotBytes) < maxCells {
+				continue
+			}
+		} else if maxCells > 0 {
+			continue
+		}
+		excess = append(excess, append([]byte{}, key...))
+	}
+	iterator.Close()
+	for _, key := range excess {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,411 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
+)
+
+// Byte usage is accounted in a separate store, keyed by the encoded key of
+// each accounted path. A path's usage is recorded on demand (when a quota
+// first applies to it) by summing the data of its subtree, and from then on
+// every write at or below the path keeps the record up to date while a quota
+// accounts for the written path (writes that no quota accounts for skip usage
+// accounting entirely). A record is removed when its usage drops to zero, and
+// every record is discarded at the beginning of the first block after the
+// quotas change (as recorded under storageQuotasKey), so that none is left
+// stale by writes while it was not accounted. Until then, only the records of
+// paths that the new quotas no longer account for can be stale, and they are
+// not read unless the quotas change back within the same block.
+
+// MaxUsageScannedEntries bounds the number of store entries that a single
+// usage query can read to compute usages that are not recorded.
+const MaxUsageScannedEntries = 100000
+
+// MaxUsageEntries bounds the number of usages that a single usage query can
+// return.
+const MaxUsageEntries = 1000
+
+var (
+	// storageQuotasKey holds the raw storage quotas param for which the
+	// recorded usages are maintained. It cannot collide with the encoded key of
+	// a path, which starts with a digit.
+	storageQuotasKey = []byte("storageQuotas")
+	// usageRecordsStart and usageRecordsEnd bound the encoded keys of paths,
+	// which start with a digit.
+	usageRecordsStart = []byte("0")
+	usageRecordsEnd   = []byte(":")
+)
+
+// WithStorageQuotas returns a copy of the keeper that accounts byte usage in
+// the store of usageStoreKey and enforces the storage quotas in the params of
+// paramSpace.
+func (k Keeper) WithStorageQuotas(paramSpace paramtypes.Subspace, usageStoreKey storetypes.StoreKey) Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+	k.paramSpace = paramSpace
+	k.usageStoreKey = usageStoreKey
+	k.storageQuotasCache = new(atomic.Pointer[storageQuotasCache])
+	return k
+}
+
+// storageQuotasCache holds the storage quotas decoded from the raw param
+// that they were read from, so that writes need not decode the param again
+// until it changes.
+type storageQuotasCache struct {
+	rawQuotas []byte
+	quotas    []types.StorageQuota
+}
+
+func (k Keeper) hasStorageQuotas() bool {
+	return k.usageStoreKey != nil
+}
+
+// GetParams returns the vstorage params, which are the defaults if storage
+// quotas are not configured.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	if k.hasStorageQuotas() {
+		k.paramSpace.GetParamSetIfExists(ctx, &params)
+	}
+	return params
+}
+
+// SetParams sets the vstorage params, which must be the defaults if storage
+// quotas are not configured.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	if !k.hasStorageQuotas() {
+		if len(params.StorageQuotas) > 0 {
+			panic("storage quotas are not configured")
+		}
+		if len(params.StreamCellRetentions) > 0 {
+			panic("StreamCell retention is not configured (it requires the storage usage store)")
+		}
+		return
+	}
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// getStorageQuotas returns the storage quotas, without writing to any store.
+func (k Keeper) getStorageQuotas(ctx sdk.Context) []types.StorageQuota {
+	rawQuotas := k.paramSpace.GetRaw(ctx, types.ParamStoreKeyStorageQuotas)
+	if cached := k.storageQuotasCache.Load(); cached != nil && bytes.Equal(cached.rawQuotas, rawQuotas) {
+		return cached.quotas
+	}
+	quotas := []types.StorageQuota{}
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyStorageQuotas, &quotas)
+	k.storageQuotasCache.Store(&storageQuotasCache{rawQuotas: rawQuotas, quotas: quotas})
+	return quotas
+}
+
+// hasCurrentUsageRecords tells if the recorded usages are maintained for the
+// current storage quotas.
+func (k Keeper) hasCurrentUsageRecords(ctx sdk.Context) bool {
+	rawQuotas := k.paramSpace.GetRaw(ctx, types.ParamStoreKeyStorageQuotas)
+	return bytes.Equal(ctx.KVStore(k.usageStoreKey).Get(storageQuotasKey), rawQuotas)
+}
+
+// SyncUsageRecords discards every recorded usage if the storage quotas have
+// changed since the usages were recorded. It is called at the beginning of
+// each block.
+func (k Keeper) SyncUsageRecords(ctx sdk.Context) {
+	if !k.hasStorageQuotas() || k.hasCurrentUsageRecords(ctx) {
+		return
+	}
+	k.discardRecordedUsages(ctx)
+	usageStore := ctx.KVStore(k.usageStoreKey)
+	if rawQuotas := k.paramSpace.GetRaw(ctx, types.ParamStoreKeyStorageQuotas); rawQuotas == nil {
+		usageStore.Delete(storageQuotasKey)
+	} else {
+		usageStore.Set(storageQuotasKey, rawQuotas)
+	}
+}
+
+// discardRecordedUsages removes every usage record, so that each usage will
+// be recomputed on demand.
+func (k Keeper) discardRecordedUsages(ctx sdk.Context) {
+	usageStore := ctx.KVStore(k.usageStoreKey)
+	// Collect keys before deleting any, so as not to disturb the iteration.
+	var keys [][]byte
+	iterator := usageStore.Iterator(usageRecordsStart, usageRecordsEnd)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		usageStore.Delete(key)
+	}
+}
+
+// isAccounted tells if any of the quotas accounts for data at path.
+func isAccounted(quotas []types.StorageQuota, path string) bool {
+	for _, quota := range quotas {
+		if _, ok := quota.AccountedPath(path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dataBytes returns the usage of a raw store value at path, which is zero
+// unless it has data.
+func dataBytes(path string, rawValue []byte) uint64 {
+	value, hasData := bytes.CutPrefix(rawValue, types.EncodedDataPrefix)
+	if !hasData {
+		return 0
+	}
+	return uint64(len(path) + len(value))
+}
+
+// entryBytes returns the usage of an entry, which is zero unless it has data.
+func entryBytes(entry agoric.KVEntry) uint64 {
+	if !entry.HasValue() {
+		return 0
+	}
+	return uint64(len(entry.Key()) + len(entry.StringValue()))
+}
+
+func (k Keeper) getRecordedUsage(ctx sdk.Context, path string) (uint64, bool) {
+	bz := ctx.KVStore(k.usageStoreKey).Get(types.PathToEncodedKey(path))
+	if bz == nil {
+		return 0, false
+	}
+	return sdk.BigEndianToUint64(bz), true
+}
+
+func (k Keeper) setRecordedUsage(ctx sdk.Context, path string, usage uint64) {
+	usageStore := ctx.KVStore(k.usageStoreKey)
+	key := types.PathToEncodedKey(path)
+	if usage == 0 {
+		usageStore.Delete(key)
+		return
+	}
+	usageStore.Set(key, sdk.Uint64ToBigEndian(usage))
+}
+
+// computeUsage sums the usage of the data at and below path.
+func (k Keeper) computeUsage(ctx sdk.Context, path string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	usage := dataBytes(path, store.Get(types.PathToEncodedKey(path)))
+	k.iterateSubtree(ctx, path+types.PathSeparator, SubtreeIterationAuto, func(key, rawValue []byte) {
+		usage += dataBytes(types.EncodedKeyToPath(key), rawValue)
+	})
+	return usage
+}
+
+// computeUsageWithin is like computeUsage, but gives up (returning false)
+// rather than read more than maxEntries store entries. It also returns the
+// number of entries read.
+func (k Keeper) computeUsageWithin(ctx sdk.Context, path string, maxEntries int) (usage uint64, read int, ok bool) {
+	store := ctx.KVStore(k.storeKey)
+	if len(path) > 0 {
+		usage = dataBytes(path, store.Get(types.PathToEncodedKey(path)))
+		read++
+	}
+	ok = true
+	iterateDescendantsByDepth(store, path, nil, 0, func(key, rawValue []byte) bool {
+		if read >= maxEntries {
+			ok = false
+			return true
+		}
+		read++
+		usage += dataBytes(types.EncodedKeyToPath(key), rawValue)
+		return false
+	})
+	return usage, read, ok
+}
+
+// ensureUsage returns the usage of path, recording it if necessary so that it
+// will be maintained by subsequent writes.
+func (k Keeper) ensureUsage(ctx sdk.Context, path string) uint64 {
+	if usage, ok := k.getRecordedUsage(ctx, path); ok {
+		return usage
+	}
+	usage := k.computeUsage(ctx, path)
+	k.setRecordedUsage(ctx, path, usage)
+	return usage
+}
+
+// accountUsage updates the recorded usage of path and each of its ancestors
+// for a change in the usage of the data at path.
+func (k Keeper) accountUsage(ctx sdk.Context, path string, oldBytes, newBytes uint64) {
+	if oldBytes == newBytes {
+		return
+	}
+	for ancestor := path; len(ancestor) > 0; {
+		if usage, ok := k.getRecordedUsage(ctx, ancestor); ok {
+			usage += newBytes
+			// Never wrap around, even if the record is somehow inconsistent.
+			if usage < oldBytes {
+				usage = 0
+			} else {
+				usage -= oldBytes
+			}
+			k.setRecordedUsage(ctx, ancestor, usage)
+		}
+		i := strings.LastIndex(ancestor, types.PathSeparator)
+		if i < 0 {
+			break
+		}
+		ancestor = ancestor[:i]
+	}
+}
+
+// checkStorageQuotas returns an error if writing entry would exceed the
+// storage quota of any path that accounts for it. Writes that do not increase
+// usage are always allowed.
+func (k Keeper) checkStorageQuotas(ctx sdk.Context, entry agoric.KVEntry) error {
+	if !k.hasStorageQuotas() {
+		return nil
+	}
+	path := entry.Key()
+	oldBytes := dataBytes(path, ctx.KVStore(k.storeKey).Get(types.PathToEncodedKey(path)))
+	newBytes := entryBytes(entry)
+	for _, quota := range k.getStorageQuotas(ctx) {
+		accountedPath, ok := quota.AccountedPath(path)
+		if !ok {
+			continue
+		}
+		usage := k.ensureUsage(ctx, accountedPath)
+		if quota.MaxBytes == 0 || newBytes <= oldBytes {
+			continue
+		}
+		if newUsage := usage + newBytes - oldBytes; newUsage > quota.MaxBytes {
+			return fmt.Errorf("storage quota exceeded: writing %q would use %d bytes under %q, more than its limit of %d",
+				path, newUsage, accountedPath, quota.MaxBytes)
+		}
+	}
+	return nil
+}
+
+// GetStorageUsages returns the usage of each path at or below path that is
+// accounted by a storage quota, in path order starting from startPath (unless
+// it is empty), along with the path from which to resume if there are more.
+// It returns at most limit usages, and stops early rather than read more than
+// maxScanned store entries to compute usages that are not recorded, failing
+// only if the first usage alone would need more. It never writes to a store.
+func (k Keeper) GetStorageUsages(ctx sdk.Context, path, startPath string, limit, maxScanned int) ([]types.StorageUsage, string, error) {
+	if !k.hasStorageQuotas() {
+		return []types.StorageUsage{}, "", nil
+	}
+
+	// Map each candidate accounted path to its lowest nonzero limit.
+	limits := make(map[string]uint64)
+	addLimit := func(accountedPath string, maxBytes uint64) {
+		if !isCoveredBySubtree(accountedPath, path) || accountedPath < startPath {
+			return
+		}
+		if limit, ok := limits[accountedPath]; ok && (maxBytes == 0 || (limit != 0 && limit <= maxBytes)) {
+			return
+		}
+		limits[accountedPath] = maxBytes
+	}
+	for _, quota := range k.getStorageQuotas(ctx) {
+		if !quota.PerChild {
+			if k.HasEntry(ctx, quota.Path) {
+				addLimit(quota.Path, quota.MaxBytes)
+			}
+			continue
+		}
+		k.iterateAccountedChildren(ctx, quota.Path, path, startPath, limit+1, func(childPath string) {
+			addLimit(childPath, quota.MaxBytes)
+		})
+	}
+
+	accountedPaths := make([]string, 0, len(limits))
+	for accountedPath := range limits {
+		accountedPaths = append(accountedPaths, accountedPath)
+	}
+	sort.Strings(accountedPaths)
+	nextPath := ""
+	if len(accountedPaths) > limit {
+		nextPath = accountedPaths[limit]
+		accountedPaths = accountedPaths[:limit]
+	}
+
+	// Stale records are ignored until they are discarded.
+	useRecords := k.hasCurrentUsageRecords(ctx)
+	usages := make([]types.StorageUsage, 0, len(accountedPaths))
+	scanned := 0
+	for _, accountedPath := range accountedPaths {
+		usage, recorded := uint64(0), false
+		if useRecords {
+			usage, recorded = k.getRecordedUsage(ctx, accountedPath)
+		}
+		if !recorded {
+			var read int
+			var ok bool
+			usage, read, ok = k.computeUsageWithin(ctx, accountedPath, maxScanned-scanned)
+			if !ok {
+				if len(usages) == 0 {
+					return nil, "", fmt.Errorf("computing the usage of %q would read more than %d entries", accountedPath, maxScanned)
+				}
+				nextPath = accountedPath
+				break
+			}
+			scanned += read
+		}
+		usages = append(usages, types.StorageUsage{
+			Path:     accountedPath,
+			Bytes:    usage,
+			MaxBytes: limits[accountedPath],
+		})
+	}
+	return usages, nextPath, nil
+}
+
+// iterateAccountedChildren calls cb with up to max paths of the children of
+// quotaPath that are at or below path and not before startPath, in path order.
+func (k Keeper) iterateAccountedChildren(ctx sdk.Context, quotaPath, path, startPath string, max int, cb func(childPath string)) {
+	childPath := func(child string) string {
+		if len(quotaPath) == 0 {
+			return child
+		}
+		return quotaPath + types.PathSeparator + child
+	}
+	childPrefix := quotaPath + types.PathSeparator
+	if len(quotaPath) == 0 {
+		childPrefix = ""
+	}
+
+	if !isCoveredBySubtree(quotaPath, path) {
+		// Only the child on the way to path can be at or below it.
+		if !strings.HasPrefix(path, childPrefix) {
+			return
+		}
+		child, _, _ := strings.Cut(path[len(childPrefix):], types.PathSeparator)
+		if childPath(child) == path && k.HasEntry(ctx, path) {
+			cb(path)
+		}
+		return
+	}
+
+	// Start from the first child that is not before startPath.
+	var start []byte
+	if startPath > childPrefix {
+		if !strings.HasPrefix(startPath, childPrefix) {
+			// Every child is before startPath.
+			return
+		}
+		start = []byte(startPath[len(childPrefix):])
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PathToChildrenPrefix(quotaPath))
+	iterator := store.Iterator(start, nil)
+	defer iterator.Close()
+	for n := 0; iterator.Valid() && n < max; iterator.Next() {
+		cb(childPath(string(iterator.Key())))
+		n++
+	}
+}
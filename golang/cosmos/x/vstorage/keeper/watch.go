@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultWatchRetainedBlocks is how many recent blocks of changes a
+	// ChangeWatcher keeps for subscribers that resume from a past height.
+	DefaultWatchRetainedBlocks = 20
+	// DefaultWatchBufferedBlocks is how many blocks of changes a subscriber may
+	// fall behind before it is dropped.
+	DefaultWatchBufferedBlocks = 64
+)
+
+// BlockChanges are the actual changes flushed at the end of a block,
+// ordered by path.
+type BlockChanges struct {
+	BlockHeight int64
+	Changes     []ProposedChange
+}
+
+// ChangeWatcher fans out the changes of each block to subscribers.
+// Publishing never blocks; a subscriber that falls too far behind is dropped
+// with an indication of the height from which to resume.
+// It holds only in-memory state, which is not part of consensus.
+type ChangeWatcher struct {
+	mu             sync.Mutex
+	retainedBlocks int
+	bufferedBlocks int
+	// retained is the most recent published BlockChanges, oldest first.
+	retained    []*BlockChanges
+	pending     []ProposedChange
+	subscribers map[*ChangeSubscription]bool
+}
+
+// ChangeSubscription receives the changes at or below a path, in batches per
+// block (omitting blocks without such changes).
+type ChangeSubscription struct {
+	pathPrefix string
+	// C is closed when the subscription ends, either by Unsubscribe or by
+	// falling behind (in which case ResumeHeight is nonzero).
+	C            chan *BlockChanges
+	resumeHeight int64
+}
+
+// The ChangeWatcher needs to be a pointer because its state is mutated.
+func NewChangeWatcher(retainedBlocks, bufferedBlocks int) *ChangeWatcher {
+	return &ChangeWatcher{
+		retainedBlocks: retainedBlocks,
+		bufferedBlocks: bufferedBlocks,
+		subscribers:    make(map[*ChangeSubscription]bool),
+	}
+}
+
+// matches tells if a path is the subscription path prefix or descends from it.
+func (sub *ChangeSubscription) matches(path string) bool {
+	return isCoveredBySubtree(path, sub.pathPrefix)
+}
+
+// filter returns the changes of a block that match the subscription
+// (including removals of subtrees that contain its path prefix), or nil if
+// there are none.
+func (sub *ChangeSubscription) filter(block *BlockChanges) *BlockChanges {
+	var changes []ProposedChange
+	for _, change := range block.Changes {
+		if sub.matches(change.Path) || (change.Subtree && isCoveredBySubtree(sub.pathPrefix, change.Path)) {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return &BlockChanges{BlockHeight: block.BlockHeight, Changes: changes}
+}
+
+// ResumeHeight returns the height of the first block that was not delivered
+// to a subscription dropped for falling behind, or zero if it was not dropped.
+// It must only be called after C has been closed.
+func (sub *ChangeSubscription) ResumeHeight() int64 {
+	return sub.resumeHeight
+}
+
+// Record stages an actual change for the next Publish.
+func (cw *ChangeWatcher) Record(change ProposedChange) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.pending = append(cw.pending, change)
+}
+
+// Publish delivers the changes recorded since the last Publish to every
+// subscriber as the changes of a block, and retains them for resumption.
+func (cw *ChangeWatcher) Publish(blockHeight int64) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	block := &BlockChanges{BlockHeight: blockHeight, Changes: cw.pending}
+	cw.pending = nil
+
+	if cw.retainedBlocks > 0 {
+		if len(cw.retained) >= cw.retainedBlocks {
+			cw.retained = cw.retained[len(cw.retained)-cw.retainedBlocks+1:]
+		}
+		cw.retained = append(cw.retained, block)
+	}
+
+	for sub := range cw.subscribers {
+		filtered := sub.filter(block)
+		if filtered == nil {
+			continue
+		}
+		select {
+		case sub.C <- filtered:
+		default:
+			// Drop the subscriber rather than stall the block.
+			sub.resumeHeight = blockHeight
+			delete(cw.subscribers, sub)
+			close(sub.C)
+		}
+	}
+}
+
+// Subscribe starts a subscription to changes at or below pathPrefix.
+// If fromHeight is nonzero, the subscription begins with retained changes
+// from blocks at or after that height, failing if any such block is no longer
+// retained.
+func (cw *ChangeWatcher) Subscribe(pathPrefix string, fromHeight int64) (*ChangeSubscription, error) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	var backlog []*BlockChanges
+	if fromHeight > 0 && len(cw.retained) > 0 && fromHeight <= cw.retained[len(cw.retained)-1].BlockHeight {
+		if oldest := cw.retained[0].BlockHeight; fromHeight < oldest {
+			return nil, fmt.Errorf("changes before height %d are not retained", oldest)
+		}
+		for _, block := range cw.retained {
+			if block.BlockHeight >= fromHeight {
+				backlog = append(backlog, block)
+			}
+		}
+	}
+
+	sub := &ChangeSubscription{
+		pathPrefix: pathPrefix,
+		C:          make(chan *BlockChanges, cw.bufferedBlocks+len(backlog)),
+	}
+	for _, block := range backlog {
+		if filtered := sub.filter(block); filtered != nil {
+			sub.C <- filtered
+		}
+	}
+	cw.subscribers[sub] = true
+	return sub, nil
+}
+
+// Unsubscribe ends a subscription if it has not already ended.
+func (cw *ChangeWatcher) Unsubscribe(sub *ChangeSubscription) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	if cw.subscribers[sub] {
+		delete(cw.subscribers, sub)
+		close(sub.C)
+	}
+}
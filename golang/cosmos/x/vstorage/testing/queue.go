@@ -25,3 +25,20 @@ func GetQueueItems(ctx sdk.Context, vstorageKeeper keeper.Keeper, queuePath stri
 	}
 	return values, nil
 }
+
+// GetCheckedQueueItems is like GetQueueItems, but returns an error if the
+// queue violates any invariant (such as by missing an item).
+func GetCheckedQueueItems(ctx sdk.Context, vstorageKeeper keeper.Keeper, queuePath string) ([]string, error) {
+	if err := vstorageKeeper.CheckQueueInvariants(ctx, queuePath); err != nil {
+		return nil, err
+	}
+	items, err := vstorageKeeper.PeekQueueItems(ctx, queuePath, 0)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(items))
+	for i, item := range items {
+		values[i] = item.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RepairGenesisState normalizes exported genesis state in place and returns a
+// description of each repair. Importing the result (which reconstructs every
+// placeholder ancestor) yields a store that satisfies the vstorage invariants.
+// Genesis state consists only of data entries, so it cannot represent (and
+// this cannot repair) placeholders without children, missing ancestors, or
+// values lacking the data prefix, the last of which even prevents export.
+// Those must be repaired in the store itself, such as by
+// Keeper.RepairStoreStructure in an upgrade handler.
+//   - data entries with an invalid path are dropped
+//   - data entries with a duplicate path are dropped in favor of the last one,
+//     which is the one that import would have preserved
+//   - data entries are sorted by path
+//   - storage quotas that are invalid or duplicate earlier ones are dropped
+func RepairGenesisState(gs *GenesisState) []string {
+	repairs := []string{}
+
+	lastIndexOfPath := make(map[string]int, len(gs.Data))
+	for i, entry := range gs.Data {
+		lastIndexOfPath[entry.Path] = i
+	}
+	data := make([]*DataEntry, 0, len(lastIndexOfPath))
+	for i, entry := range gs.Data {
+		if err := ValidatePath(entry.Path); err != nil {
+			repairs = append(repairs, fmt.Sprintf("dropped data entry %d: %s", i, err))
+			continue
+		}
+		if lastIndexOfPath[entry.Path] != i {
+			repairs = append(repairs, fmt.Sprintf("dropped data entry %d: path %q is superseded by entry %d", i, entry.Path, lastIndexOfPath[entry.Path]))
+			continue
+		}
+		data = append(data, entry)
+	}
+	if !sort.SliceIsSorted(data, func(i, j int) bool { return data[i].Path < data[j].Path }) {
+		sort.Slice(data, func(i, j int) bool { return data[i].Path < data[j].Path })
+		repairs = append(repairs, "sorted data entries by path")
+	}
+	gs.Data = data
+
+	quotas := make([]StorageQuota, 0, len(gs.Params.StorageQuotas))
+	for i, quota := range gs.Params.StorageQuotas {
+		if err := validateStorageQuotas(append(quotas, quota)); err != nil {
+			repairs = append(repairs, fmt.Sprintf("dropped storage quota %d: %s", i, err))
+			continue
+		}
+		quotas = append(quotas, quota)
+	}
+	gs.Params.StorageQuotas = quotas
+
+	retentions := make([]StreamCellRetention, 0, len(gs.Params.StreamCellRetentions))
+	for i, retention := range gs.Params.StreamCellRetentions {
+		if err := validateStreamCellRetentions(append(retentions, retention)); err != nil {
+			repairs = append(repairs, fmt.Sprintf("dropped stream cell retention %d: %s", i, err))
+			continue
+		}
+		retentions = append(retentions, retention)
+	}
+	gs.Params.StreamCellRetentions = retentions
+
+	return repairs
+}
@@ -26,6 +26,8 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 // The initial or exported state.
 type GenesisState struct {
 	Data []*DataEntry `protobuf:"bytes,1,rep,name=data,proto3" json:"data" yaml:"data"`
+	// params defines all the parameters of the module.
+	Params Params `protobuf:"bytes,2,opt,name=params,proto3" json:"params"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -68,6 +70,13 @@ func (m *GenesisState) GetData() []*DataEntry {
 	return nil
 }
 
+func (m *GenesisState) GetParams() Params {
+	if m != nil {
+		return m.Params
+	}
+	return Params{}
+}
+
 // A vstorage entry.  The only necessary entries are those with data, as the
 // ancestor nodes are reconstructed on import.
 type DataEntry struct {
@@ -132,24 +141,26 @@ func init() {
 func init() { proto.RegisterFile("agoric/vstorage/genesis.proto", fileDescriptor_fddf50d092fbeeb3) }
 
 var fileDescriptor_fddf50d092fbeeb3 = []byte{
-	// 261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4d, 0x4c, 0xcf, 0x2f,
-	0xca, 0x4c, 0xd6, 0x2f, 0x2b, 0x2e, 0xc9, 0x2f, 0x4a, 0x4c, 0x4f, 0xd5, 0x4f, 0x4f, 0xcd, 0x4b,
-	0x2d, 0xce, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x87, 0x48, 0xeb, 0xc1, 0xa4,
-	0xa5, 0x44, 0xd2, 0xf3, 0xd3, 0xf3, 0xc1, 0x72, 0xfa, 0x20, 0x16, 0x44, 0x99, 0x52, 0x2c, 0x17,
-	0x8f, 0x3b, 0x44, 0x5f, 0x70, 0x49, 0x62, 0x49, 0xaa, 0x90, 0x3b, 0x17, 0x4b, 0x4a, 0x62, 0x49,
-	0xa2, 0x04, 0xa3, 0x02, 0xb3, 0x06, 0xb7, 0x91, 0x94, 0x1e, 0x9a, 0x29, 0x7a, 0x2e, 0x89, 0x25,
-	0x89, 0xae, 0x79, 0x25, 0x45, 0x95, 0x4e, 0xe2, 0xaf, 0xee, 0xc9, 0x83, 0xd5, 0x7e, 0xba, 0x27,
-	0xcf, 0x5d, 0x99, 0x98, 0x9b, 0x63, 0xa5, 0x04, 0xe2, 0x29, 0x05, 0x81, 0x05, 0xad, 0x58, 0x5e,
-	0x2c, 0x90, 0x67, 0x50, 0x32, 0xe5, 0xe2, 0x84, 0xeb, 0x10, 0x12, 0xe2, 0x62, 0x29, 0x48, 0x2c,
-	0xc9, 0x90, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x02, 0xb3, 0x85, 0x44, 0xb8, 0x58, 0xcb, 0x12,
-	0x73, 0x4a, 0x53, 0x25, 0x98, 0xc0, 0x82, 0x10, 0x8e, 0x53, 0xe8, 0x89, 0x47, 0x72, 0x8c, 0x17,
-	0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x85, 0xc7, 0x72, 0x0c,
-	0x37, 0x1e, 0xcb, 0x31, 0x44, 0x59, 0xa7, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7, 0xe7,
-	0xea, 0x3b, 0x42, 0x02, 0x00, 0xe2, 0x44, 0xdd, 0xe2, 0x94, 0x6c, 0xfd, 0xf4, 0xfc, 0x9c, 0xc4,
-	0xbc, 0x74, 0xfd, 0xe4, 0xfc, 0xe2, 0xdc, 0xfc, 0x62, 0xfd, 0x0a, 0x44, 0xd8, 0x94, 0x54, 0x16,
-	0xa4, 0x16, 0x27, 0xb1, 0x81, 0xfd, 0x6c, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x71, 0x28, 0xb4,
-	0x23, 0x3b, 0x01, 0x00, 0x00,
+	// 302 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x31, 0x4b, 0xf3, 0x40,
+	0x1c, 0xc6, 0x73, 0xef, 0x1b, 0x0b, 0xbd, 0x0a, 0xc2, 0x51, 0x68, 0x29, 0x78, 0x29, 0x99, 0xba,
+	0x98, 0x83, 0x4a, 0x97, 0x3a, 0x59, 0x94, 0xae, 0x12, 0x71, 0x71, 0xfb, 0xb7, 0x3d, 0xae, 0xc1,
+	0x24, 0x17, 0x72, 0xd7, 0x62, 0xbe, 0x85, 0x83, 0x1f, 0xc0, 0x8f, 0xd3, 0xb1, 0xa3, 0x53, 0x90,
+	0x64, 0x11, 0x47, 0x3f, 0x81, 0xf4, 0xce, 0x2a, 0xd4, 0xed, 0xf9, 0xdf, 0xef, 0xe1, 0x79, 0x8e,
+	0x07, 0x9f, 0x82, 0x90, 0x79, 0x34, 0x67, 0x6b, 0xa5, 0x65, 0x0e, 0x82, 0x33, 0xc1, 0x53, 0xae,
+	0x22, 0x15, 0x64, 0xb9, 0xd4, 0x92, 0x9c, 0x58, 0x1c, 0xec, 0x71, 0xaf, 0x2d, 0xa4, 0x90, 0x86,
+	0xb1, 0x9d, 0xb2, 0xb6, 0x1e, 0x3d, 0x4c, 0xd9, 0x0b, 0xcb, 0xfd, 0x67, 0x84, 0x8f, 0xa7, 0x36,
+	0xf8, 0x56, 0x83, 0xe6, 0x64, 0x8a, 0xdd, 0x05, 0x68, 0xe8, 0xa2, 0xfe, 0xff, 0x41, 0x6b, 0xd8,
+	0x0b, 0x0e, 0x6a, 0x82, 0x2b, 0xd0, 0x70, 0x9d, 0xea, 0xbc, 0x98, 0x74, 0x3e, 0x4a, 0xcf, 0x78,
+	0x3f, 0x4b, 0xaf, 0x55, 0x40, 0x12, 0x8f, 0xfd, 0xdd, 0xe5, 0x87, 0xe6, 0x91, 0x8c, 0x70, 0x23,
+	0x83, 0x1c, 0x12, 0xd5, 0xfd, 0xd7, 0x47, 0x83, 0xd6, 0xb0, 0xf3, 0x27, 0xea, 0xc6, 0xe0, 0x89,
+	0xbb, 0x29, 0x3d, 0x27, 0xfc, 0x36, 0x8f, 0xdd, 0xf7, 0x17, 0xcf, 0xf1, 0x47, 0xb8, 0xf9, 0x53,
+	0x44, 0x08, 0x76, 0x33, 0xd0, 0xcb, 0x2e, 0xea, 0xa3, 0x41, 0x33, 0x34, 0x9a, 0xb4, 0xf1, 0xd1,
+	0x1a, 0xe2, 0x15, 0x37, 0xe1, 0xcd, 0xd0, 0x1e, 0x93, 0xbb, 0x4d, 0x45, 0xd1, 0xb6, 0xa2, 0xe8,
+	0xad, 0xa2, 0xe8, 0xa9, 0xa6, 0xce, 0xb6, 0xa6, 0xce, 0x6b, 0x4d, 0x9d, 0xfb, 0x0b, 0x11, 0xe9,
+	0xe5, 0x6a, 0x16, 0xcc, 0x65, 0xc2, 0x2e, 0xed, 0x24, 0xf6, 0x3b, 0x67, 0x6a, 0xf1, 0xc0, 0x84,
+	0x8c, 0x21, 0x15, 0x6c, 0x2e, 0x55, 0x22, 0x15, 0x7b, 0xfc, 0x5d, 0x4b, 0x17, 0x19, 0x57, 0xb3,
+	0x86, 0xd9, 0xea, 0xfc, 0x6b, 0x00, 0xd2, 0x5e, 0x3f, 0x4b, 0x93, 0x01, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -172,6 +183,16 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
 	if len(m.Data) > 0 {
 		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -249,6 +270,8 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	l = m.Params.Size()
+	n += 1 + l + sovGenesis(uint64(l))
 	return n
 }
 
@@ -338,6 +361,39 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_RepairGenesisState(t *testing.T) {
+	gs := GenesisState{
+		Data: []*DataEntry{
+			{Path: "b", Value: "old"},
+			{Path: "a.", Value: "invalid"},
+			{Path: "b", Value: "new"},
+			{Path: "a", Value: "a"},
+		},
+		Params: Params{StorageQuotas: []StorageQuota{
+			NewStorageQuota("published", true, 10),
+			NewStorageQuota("", false, 10),
+			NewStorageQuota("published", true, 20),
+		}, StreamCellRetentions: []StreamCellRetention{
+			NewStreamCellRetention("published", MaxStreamCellRetention+1),
+			NewStreamCellRetention("published", 3),
+		}},
+	}
+	repairs := RepairGenesisState(&gs)
+
+	expectedRepairs := []string{
+		`dropped data entry 0: path "b" is superseded by entry 2`,
+		`dropped data entry 1: path "a." ends with separator`,
+		"sorted data entries by path",
+		"dropped storage quota 1: storage quota for the root path must be per child",
+		`dropped storage quota 2: duplicate storage quota for path "published" (per child true)`,
+		`dropped stream cell retention 0: stream cell retention for path "published" must not exceed 1000 cells`,
+	}
+	if !reflect.DeepEqual(repairs, expectedRepairs) {
+		t.Errorf("got repairs %q, want %q", repairs, expectedRepairs)
+	}
+	expectedData := []*DataEntry{{Path: "a", Value: "a"}, {Path: "b", Value: "new"}}
+	if !reflect.DeepEqual(gs.Data, expectedData) {
+		t.Errorf("got data %v, want %v", gs.Data, expectedData)
+	}
+	expectedQuotas := []StorageQuota{NewStorageQuota("published", true, 10)}
+	if !reflect.DeepEqual(gs.Params.StorageQuotas, expectedQuotas) {
+		t.Errorf("got quotas %v, want %v", gs.Params.StorageQuotas, expectedQuotas)
+	}
+	expectedRetentions := []StreamCellRetention{NewStreamCellRetention("published", 3)}
+	if !reflect.DeepEqual(gs.Params.StreamCellRetentions, expectedRetentions) {
+		t.Errorf("got retentions %v, want %v", gs.Params.StreamCellRetentions, expectedRetentions)
+	}
+
+	if repairs := RepairGenesisState(&gs); len(repairs) != 0 {
+		t.Errorf("got repairs %q of repaired state", repairs)
+	}
+}
@@ -6,4 +6,10 @@ const (
 
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
+
+	// UsageStoreKey to be used when creating the KVStore that accounts byte
+	// usage and retains previous StreamCells, apart from the paths of StoreKey
+	// (and not prefixed by it, which the multistore would reject as a potential
+	// collision)
+	UsageStoreKey = "storage_usage"
 )
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Parameter keys
+var (
+	ParamStoreKeyStorageQuotas        = []byte("storage_quotas")
+	ParamStoreKeyStreamCellRetentions = []byte("stream_cell_retentions")
+)
+
+// MaxStreamCellRetention bounds the number of previous StreamCells that can be
+// retained for a path.
+const MaxStreamCellRetention = 1000
+
+func NewStorageQuota(path string, perChild bool, maxBytes uint64) StorageQuota {
+	return StorageQuota{
+		Path:     path,
+		PerChild: perChild,
+		MaxBytes: maxBytes,
+	}
+}
+
+func NewStreamCellRetention(path string, maxCells uint32) StreamCellRetention {
+	return StreamCellRetention{
+		Path:     path,
+		MaxCells: maxCells,
+	}
+}
+
+// ParamKeyTable returns the parameter key table.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns default vstorage parameters
+func DefaultParams() Params {
+	return Params{
+		StorageQuotas:        []StorageQuota{},
+		StreamCellRetentions: []StreamCellRetention{},
+	}
+}
+
+func (p Params) String() string {
+	out, _ := yaml.Marshal(p)
+	return string(out)
+}
+
+// ParamSetPairs returns the parameter set pairs.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(ParamStoreKeyStorageQuotas, &p.StorageQuotas, validateStorageQuotas),
+		paramtypes.NewParamSetPair(ParamStoreKeyStreamCellRetentions, &p.StreamCellRetentions, validateStreamCellRetentions),
+	}
+}
+
+// ValidateBasic performs basic validation on vstorage parameters.
+func (p Params) ValidateBasic() error {
+	if err := validateStorageQuotas(p.StorageQuotas); err != nil {
+		return err
+	}
+	return validateStreamCellRetentions(p.StreamCellRetentions)
+}
+
+func validateStorageQuotas(i interface{}) error {
+	quotas, ok := i.([]StorageQuota)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[StorageQuota]bool)
+	for _, quota := range quotas {
+		if err := ValidatePath(quota.Path); err != nil {
+			return fmt.Errorf("storage quota: %w", err)
+		}
+		if len(quota.Path) == 0 && !quota.PerChild {
+			return fmt.Errorf("storage quota for the root path must be per child")
+		}
+		key := StorageQuota{Path: quota.Path, PerChild: quota.PerChild}
+		if seen[key] {
+			return fmt.Errorf("duplicate storage quota for path %q (per child %t)", quota.Path, quota.PerChild)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func validateStreamCellRetentions(i interface{}) error {
+	retentions, ok := i.([]StreamCellRetention)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool)
+	for _, retention := range retentions {
+		if err := ValidatePath(retention.Path); err != nil {
+			return fmt.Errorf("stream cell retention: %w", err)
+		}
+		if retention.MaxCells > MaxStreamCellRetention {
+			return fmt.Errorf("stream cell retention for path %q must not exceed %d cells", retention.Path, MaxStreamCellRetention)
+		}
+		if seen[retention.Path] {
+			return fmt.Errorf("duplicate stream cell retention for path %q", retention.Path)
+		}
+		seen[retention.Path] = true
+	}
+	return nil
+}
+
+// StreamCellRetentionFor returns the number of previous StreamCells to retain
+// for path, as specified by the most specific retention that applies to it.
+func (p Params) StreamCellRetentionFor(path string) uint32 {
+	maxCells := uint32(0)
+	matchedLen := -1
+	for _, retention := range p.StreamCellRetentions {
+		if len(retention.Path) <= matchedLen {
+			continue
+		}
+		if len(retention.Path) == 0 || path == retention.Path || strings.HasPrefix(path, retention.Path+PathSeparator) {
+			maxCells = retention.MaxCells
+			matchedLen = len(retention.Path)
+		}
+	}
+	return maxCells
+}
+
+// AccountedPath returns the path whose usage the quota accounts for data at
+// path, and whether there is one.
+func (quota StorageQuota) AccountedPath(path string) (string, bool) {
+	if !quota.PerChild {
+		if path == quota.Path || strings.HasPrefix(path, quota.Path+PathSeparator) {
+			return quota.Path, true
+		}
+		return "", false
+	}
+	rest := path
+	if len(quota.Path) > 0 {
+		var found bool
+		rest, found = strings.CutPrefix(path, quota.Path+PathSeparator)
+		if !found {
+			return "", false
+		}
+	} else if len(path) == 0 {
+		return "", false
+	}
+	child, _, _ := strings.Cut(rest, PathSeparator)
+	return path[:len(path)-len(rest)] + child, true
+}
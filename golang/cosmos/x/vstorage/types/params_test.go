@@ -0,0 +1,99 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_StorageQuota_AccountedPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    StorageQuota
+		path     string
+		accounts string
+	}{
+		{name: "subtree root", quota: NewStorageQuota("a.b", false, 0), path: "a.b", accounts: "a.b"},
+		{name: "subtree descendant", quota: NewStorageQuota("a.b", false, 0), path: "a.b.c.d", accounts: "a.b"},
+		{name: "subtree sibling", quota: NewStorageQuota("a.b", false, 0), path: "a.bc"},
+		{name: "subtree ancestor", quota: NewStorageQuota("a.b", false, 0), path: "a"},
+		{name: "per child parent", quota: NewStorageQuota("a", true, 0), path: "a"},
+		{name: "per child child", quota: NewStorageQuota("a", true, 0), path: "a.b", accounts: "a.b"},
+		{name: "per child descendant", quota: NewStorageQuota("a", true, 0), path: "a.b.c.d", accounts: "a.b"},
+		{name: "per child sibling", quota: NewStorageQuota("a", true, 0), path: "ab.c"},
+		{name: "per child of root", quota: NewStorageQuota("", true, 0), path: "a.b", accounts: "a"},
+		{name: "per child of root at root", quota: NewStorageQuota("", true, 0), path: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, ok := tt.quota.AccountedPath(tt.path)
+			if ok != (tt.accounts != "") || accounts != tt.accounts {
+				t.Errorf("AccountedPath(%q) = %q, %t; want %q", tt.path, accounts, ok, tt.accounts)
+			}
+		})
+	}
+}
+
+func Test_Params_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name        string
+		quotas      []StorageQuota
+		errContains string
+	}{
+		{name: "default", quotas: DefaultParams().StorageQuotas},
+		{name: "nested", quotas: []StorageQuota{NewStorageQuota("a", true, 10), NewStorageQuota("a.b", false, 5)}},
+		{name: "same path", quotas: []StorageQuota{NewStorageQuota("a", true, 10), NewStorageQuota("a", false, 5)}},
+		{name: "invalid path", quotas: []StorageQuota{NewStorageQuota("a.", false, 0)}, errContains: "ends with separator"},
+		{name: "whole root", quotas: []StorageQuota{NewStorageQuota("", false, 0)}, errContains: "must be per child"},
+		{name: "duplicate", quotas: []StorageQuota{NewStorageQuota("a", false, 1), NewStorageQuota("a", false, 2)}, errContains: "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Params{StorageQuotas: tt.quotas}.ValidateBasic()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("got error %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("got error %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func Test_Params_StreamCellRetentions(t *testing.T) {
+	tests := []struct {
+		name        string
+		retentions  []StreamCellRetention
+		errContains string
+	}{
+		{name: "default", retentions: DefaultParams().StreamCellRetentions},
+		{name: "nested", retentions: []StreamCellRetention{NewStreamCellRetention("", 1), NewStreamCellRetention("a.b", 0)}},
+		{name: "maximum", retentions: []StreamCellRetention{NewStreamCellRetention("a", MaxStreamCellRetention)}},
+		{name: "excessive", retentions: []StreamCellRetention{NewStreamCellRetention("a", MaxStreamCellRetention+1)}, errContains: "must not exceed"},
+		{name: "invalid path", retentions: []StreamCellRetention{NewStreamCellRetention("a..b", 1)}, errContains: "stream cell retention"},
+		{name: "duplicate", retentions: []StreamCellRetention{NewStreamCellRetention("a", 1), NewStreamCellRetention("a", 2)}, errContains: "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Params{StreamCellRetentions: tt.retentions}.ValidateBasic()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("got error %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("got error %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+
+	params := Params{StreamCellRetentions: []StreamCellRetention{
+		NewStreamCellRetention("a", 5),
+		NewStreamCellRetention("a.b.c", 2),
+		NewStreamCellRetention("a.b", 0),
+	}}
+	for path, want := range map[string]uint32{"": 0, "a": 5, "a.x": 5, "a.b": 0, "a.bc": 5, "a.b.c.d": 2, "ab": 0} {
+		if got := params.StreamCellRetentionFor(path); got != want {
+			t.Errorf("StreamCellRetentionFor(%q) = %d; want %d", path, got, want)
+		}
+	}
+}
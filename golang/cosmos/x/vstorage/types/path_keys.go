@@ -89,15 +89,27 @@ func PathToEncodedKey(path string) []byte {
 
 // PathToChildrenPrefix converts a path to a prefix for its children
 func PathToChildrenPrefix(path string) []byte {
+	return PathToDescendantsPrefix(path, 1)
+}
+
+// PathToDescendantsPrefix converts a path to a prefix for its descendants
+// exactly `generations` segments below it (e.g., 1 for children and 2 for
+// grandchildren). Because encoded keys start with their depth, every
+// descendant of a path is under exactly one such prefix.
+func PathToDescendantsPrefix(path string, generations int) []byte {
 	if err := ValidatePath(path); err != nil {
 		panic(err)
 	}
+	if generations < 1 {
+		panic(fmt.Errorf("descendant generations %d must be positive", generations))
+	}
 	encodedPrefix := PathSeparator + path
+	depth := generations
 	if len(path) > 0 {
 		// Append so that only the empty prefix has no trailing separator.
 		encodedPrefix += PathSeparator
+		depth += strings.Count(path, PathSeparator) + 1
 	}
-	depth := strings.Count(encodedPrefix, PathSeparator)
 	encoded := []byte(fmt.Sprintf("%d%s", depth, encodedPrefix))
 	return bytes.ReplaceAll(encoded, []byte(PathSeparator), EncodedKeySeparator)
 }
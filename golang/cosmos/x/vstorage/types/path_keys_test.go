@@ -105,3 +105,31 @@ func Test_Key_Encoding(t *testing.T) {
 		})
 	}
 }
+
+func Test_Descendants_Prefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		generations int
+		prefix      []byte
+	}{
+		{name: "children of empty path", path: "", generations: 1, prefix: []byte("1\x00")},
+		{name: "grandchildren of empty path", path: "", generations: 2, prefix: []byte("2\x00")},
+		{name: "children", path: "some", generations: 1, prefix: []byte("2\x00some\x00")},
+		{name: "deep descendants", path: "some.child", generations: 9, prefix: []byte("11\x00some\x00child\x00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if prefix := PathToDescendantsPrefix(tt.path, tt.generations); !bytes.Equal(prefix, tt.prefix) {
+				t.Errorf("pathToDescendantsPrefix(%q, %d) = []byte(%q), want []byte(%q)", tt.path, tt.generations, prefix, tt.prefix)
+			}
+			if tt.generations != 1 {
+				return
+			}
+			if prefix := PathToChildrenPrefix(tt.path); !bytes.Equal(prefix, tt.prefix) {
+				t.Errorf("pathToChildrenPrefix(%q) = []byte(%q), want []byte(%q)", tt.path, prefix, tt.prefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// - A "path pattern" is a sequence of one or more dot-separated segments, each
+// of which is either a path segment (matching only itself), `*` (matching any
+// single segment), or `**` (matching any sequence of zero or more segments).
+// So `"published.vaultFactory.managers.*.vaults.*"` matches every vault of
+// every manager, and `"published.**.metrics"` matches every "metrics" path
+// under "published" (including "published.metrics").
+const (
+	PathPatternAnySegment  = "*"
+	PathPatternAnySegments = "**"
+)
+
+var pathSegmentMatcher = regexp.MustCompile(`^` + pathSegmentPattern + `$`)
+
+// PathPattern is a parsed path pattern, with one element per segment.
+type PathPattern []string
+
+// ParsePathPattern parses and validates a path pattern.
+func ParsePathPattern(pattern string) (PathPattern, error) {
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("path pattern is empty")
+	}
+	segments := strings.Split(pattern, PathSeparator)
+	for i, segment := range segments {
+		if segment == PathPatternAnySegment || segment == PathPatternAnySegments {
+			continue
+		}
+		if !pathSegmentMatcher.MatchString(segment) {
+			return nil, fmt.Errorf("path pattern %q has invalid segment %d %q", pattern, i, segment)
+		}
+	}
+	return PathPattern(segments), nil
+}
+
+// String returns the unparsed path pattern.
+func (pp PathPattern) String() string {
+	return strings.Join(pp, PathSeparator)
+}
+
+// Matches tells if a path matches the pattern.
+func (pp PathPattern) Matches(path string) bool {
+	var segments []string
+	if len(path) > 0 {
+		segments = strings.Split(path, PathSeparator)
+	}
+	positions := pp.Start()
+	for _, segment := range segments {
+		positions = pp.Advance(positions, segment)
+	}
+	return pp.IsMatch(positions)
+}
+
+// Start returns the pattern positions (i.e., the indexes of pattern segments
+// that have yet to be matched) reachable before matching any path segment.
+func (pp PathPattern) Start() []int {
+	return pp.closure([]int{0})
+}
+
+// Advance returns the pattern positions reachable from positions by matching
+// segment, which are empty if there can be no match below it.
+func (pp PathPattern) Advance(positions []int, segment string) []int {
+	next := make([]int, 0, len(positions))
+	for _, pos := range positions {
+		if pos == len(pp) {
+			continue
+		}
+		switch pp[pos] {
+		case PathPatternAnySegments:
+			next = append(next, pos)
+		case PathPatternAnySegment, segment:
+			next = append(next, pos+1)
+		}
+	}
+	return pp.closure(next)
+}
+
+// IsMatch tells if positions include the end of the pattern.
+func (pp PathPattern) IsMatch(positions []int) bool {
+	for _, pos := range positions {
+		if pos == len(pp) {
+			return true
+		}
+	}
+	return false
+}
+
+// LiteralSegments returns the path segments that can advance positions, or
+// false if any segment can (i.e., if some position is at a wildcard).
+func (pp PathPattern) LiteralSegments(positions []int) ([]string, bool) {
+	literals := []string{}
+	for _, pos := range positions {
+		if pos == len(pp) {
+			continue
+		}
+		if pp[pos] == PathPatternAnySegment || pp[pos] == PathPatternAnySegments {
+			return nil, false
+		}
+		literals = append(literals, pp[pos])
+	}
+	return literals, true
+}
+
+// closure returns the distinct positions in increasing order, including those
+// reachable by matching zero segments with `**`.
+func (pp PathPattern) closure(positions []int) []int {
+	included := make([]bool, len(pp)+1)
+	for _, pos := range positions {
+		for ; !included[pos]; pos++ {
+			included[pos] = true
+			if pos == len(pp) || pp[pos] != PathPatternAnySegments {
+				break
+			}
+		}
+	}
+	closed := make([]int, 0, len(positions))
+	for pos, ok := range included {
+		if ok {
+			closed = append(closed, pos)
+		}
+	}
+	return closed
+}
+
+// ComparePaths compares paths by their sequences of segments, which is the
+// order of a depth-first walk that visits each path before its descendants
+// and children in encoded key order. It returns -1 if a is first, 1 if b is
+// first, and otherwise 0.
+func ComparePaths(a, b string) int {
+	aSegments, bSegments := strings.Split(a, PathSeparator), strings.Split(b, PathSeparator)
+	if len(a) == 0 {
+		aSegments = nil
+	}
+	if len(b) == 0 {
+		bSegments = nil
+	}
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		if cmp := strings.Compare(aSegments[i], bSegments[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(aSegments) < len(bSegments):
+		return -1
+	case len(aSegments) > len(bSegments):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_PathPattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		matches     []string
+		nonMatches  []string
+		errContains string
+	}{
+		{
+			name:       "literal",
+			pattern:    "published.reserve",
+			matches:    []string{"published.reserve"},
+			nonMatches: []string{"", "published", "published.reserve.metrics", "published.reserved"},
+		},
+		{
+			name:       "any segment",
+			pattern:    "published.*.metrics",
+			matches:    []string{"published.reserve.metrics", "published.psm.metrics"},
+			nonMatches: []string{"published.metrics", "published.psm.IST.metrics"},
+		},
+		{
+			name:       "any segments",
+			pattern:    "published.**.metrics",
+			matches:    []string{"published.metrics", "published.psm.metrics", "published.psm.IST.metrics"},
+			nonMatches: []string{"published", "published.psm.metrics.x", "metrics"},
+		},
+		{
+			name:       "trailing any segments",
+			pattern:    "published.**",
+			matches:    []string{"published", "published.a", "published.a.b.c"},
+			nonMatches: []string{"", "a.published"},
+		},
+		{
+			name:       "repeated any segments",
+			pattern:    "**.a.**.a",
+			matches:    []string{"a.a", "a.a.a", "x.a.y.a", "a.b.a.c.a"},
+			nonMatches: []string{"a", "a.a.b"},
+		},
+		{
+			name:       "mixed wildcards",
+			pattern:    "*.**.*",
+			matches:    []string{"a.b", "a.b.c", "a.b.c.d"},
+			nonMatches: []string{"", "a"},
+		},
+		{
+			name:        "empty pattern",
+			pattern:     "",
+			errContains: "empty",
+		},
+		{
+			name:        "empty segment",
+			pattern:     "published..reserve",
+			errContains: `invalid segment 1 ""`,
+		},
+		{
+			name:        "partial wildcard",
+			pattern:     "published.res*",
+			errContains: `invalid segment 1 "res*"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := ParsePathPattern(tt.pattern)
+			if len(tt.errContains) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("got error %v, want error containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error %v", err)
+			}
+			if pattern.String() != tt.pattern {
+				t.Errorf("got string %q, want %q", pattern.String(), tt.pattern)
+			}
+			for _, path := range tt.matches {
+				if !pattern.Matches(path) {
+					t.Errorf("got no match for %q", path)
+				}
+			}
+			for _, path := range tt.nonMatches {
+				if pattern.Matches(path) {
+					t.Errorf("got unexpected match for %q", path)
+				}
+			}
+		})
+	}
+}
+
+func Test_ComparePaths(t *testing.T) {
+	// Each path precedes the next.
+	paths := []string{"", "a", "a.b", "a.b.c", "a.c", "a-b", "a-b.a", "b"}
+	for i, a := range paths {
+		for j, b := range paths {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			if cmp := ComparePaths(a, b); cmp != expected {
+				t.Errorf("got ComparePaths(%q, %q) = %d, want %d", a, b, cmp, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	tmmerkle "github.com/tendermint/tendermint/crypto/merkle"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// NewProofOps converts tendermint proof operations to their vstorage form.
+func NewProofOps(proof *tmcrypto.ProofOps) []ProofOp {
+	if proof == nil {
+		return []ProofOp{}
+	}
+	ops := make([]ProofOp, len(proof.Ops))
+	for i, op := range proof.Ops {
+		ops[i] = ProofOp{Type: op.Type, Key: op.Key, Data: op.Data}
+	}
+	return ops
+}
+
+// TendermintProofOps converts vstorage proof operations to their tendermint
+// form.
+func TendermintProofOps(ops []ProofOp) *tmcrypto.ProofOps {
+	proof := &tmcrypto.ProofOps{Ops: make([]tmcrypto.ProofOp, len(ops))}
+	for i, op := range ops {
+		proof.Ops[i] = tmcrypto.ProofOp{Type: op.Type, Key: op.Key, Data: op.Data}
+	}
+	return proof
+}
+
+// VerifyStoreValue checks that proof proves storeValue at key in the vstorage
+// store against appHash, where an empty storeValue requires proof that key is
+// absent.
+func VerifyStoreValue(appHash, key, storeValue []byte, proof *tmcrypto.ProofOps) error {
+	keyPath := tmmerkle.KeyPath{}.
+		AppendKey([]byte(StoreKey), tmmerkle.KeyEncodingURL).
+		AppendKey(key, tmmerkle.KeyEncodingURL).
+		String()
+	prt := rootmulti.DefaultProofRuntime()
+	if len(storeValue) == 0 {
+		return prt.VerifyAbsence(proof, appHash, keyPath)
+	}
+	return prt.VerifyValue(proof, appHash, keyPath, storeValue)
+}
+
+// VerifyDataWithProof checks that res is a Query/DataWithProof response for
+// path that is proven against appHash, which must be the app hash in the
+// header of the block after res.Height. Only the value of a verified response
+// can be trusted.
+func VerifyDataWithProof(appHash []byte, path string, res *QueryDataWithProofResponse) error {
+	if err := ValidatePath(path); err != nil {
+		return err
+	}
+	key := PathToEncodedKey(path)
+	if !bytes.Equal(res.Key, key) {
+		return fmt.Errorf("key %q does not match path %q", res.Key, path)
+	}
+	value, hasData := bytes.CutPrefix(res.StoreValue, EncodedDataPrefix)
+	if !hasData {
+		value = nil
+	}
+	if res.Value != string(value) {
+		return fmt.Errorf("value %q does not match store value %q", res.Value, res.StoreValue)
+	}
+	if err := VerifyStoreValue(appHash, key, res.StoreValue, TendermintProofOps(res.ProofOps)); err != nil {
+		return fmt.Errorf("invalid proof for path %q: %w", path, err)
+	}
+	return nil
+}
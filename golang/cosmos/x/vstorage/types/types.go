@@ -7,3 +7,10 @@ func NewData() *Data {
 func NewChildren() *Children {
 	return &Children{}
 }
+
+// Kinds of DataDiff change.
+const (
+	DataDiffAdded   = "added"
+	DataDiffRemoved = "removed"
+	DataDiffChanged = "changed"
+)
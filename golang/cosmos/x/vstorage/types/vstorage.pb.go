@@ -114,33 +114,394 @@ func (m *Children) GetChildren() []string {
 	return nil
 }
 
+// Params are the vstorage module parameters.
+type Params struct {
+	// Limits on the bytes of data stored in subtrees of vstorage, each of which
+	// also has its usage accounted.
+	//
+	// There is no required order to this list of entries, but all the chain
+	// nodes must all serialize and deserialize the existing order without
+	// permuting it.
+	StorageQuotas []StorageQuota `protobuf:"bytes,1,rep,name=storage_quotas,json=storageQuotas,proto3" json:"storage_quotas"`
+	// Numbers of previous StreamCells to retain for paths written by append,
+	// each applying to the paths at and below its path unless a more specific
+	// entry applies.
+	StreamCellRetentions []StreamCellRetention `protobuf:"bytes,2,rep,name=stream_cell_retentions,json=streamCellRetentions,proto3" json:"stream_cell_retentions"`
+}
+
+func (m *Params) Reset()      { *m = Params{} }
+func (*Params) ProtoMessage() {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f80259d2fe3898c, []int{2}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetStorageQuotas() []StorageQuota {
+	if m != nil {
+		return m.StorageQuotas
+	}
+	return nil
+}
+
+func (m *Params) GetStreamCellRetentions() []StreamCellRetention {
+	if m != nil {
+		return m.StreamCellRetentions
+	}
+	return nil
+}
+
+// StorageQuota limits the bytes of data at and below each accounted path,
+// counting the length of the path plus the length of the value of every entry
+// with data.
+type StorageQuota struct {
+	// The accounted path, or with per_child the parent of every accounted path.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Whether each child of path is accounted and limited separately (e.g.,
+	// path "published" for every "published.<contract>").
+	PerChild bool `protobuf:"varint,2,opt,name=per_child,json=perChild,proto3" json:"per_child,omitempty"`
+	// The limit for each accounted path, or zero to request accounting without
+	// a limit.
+	MaxBytes uint64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+}
+
+func (m *StorageQuota) Reset()         { *m = StorageQuota{} }
+func (m *StorageQuota) String() string { return proto.CompactTextString(m) }
+func (*StorageQuota) ProtoMessage()    {}
+func (*StorageQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f80259d2fe3898c, []int{3}
+}
+func (m *StorageQuota) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageQuota.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageQuota.Merge(m, src)
+}
+func (m *StorageQuota) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageQuota proto.InternalMessageInfo
+
+func (m *StorageQuota) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StorageQuota) GetPerChild() bool {
+	if m != nil {
+		return m.PerChild
+	}
+	return false
+}
+
+func (m *StorageQuota) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+// StreamCellRetention requests that each append-only path at or below path
+// retain its previous StreamCells in a ring apart from vstorage data.
+type StreamCellRetention struct {
+	// The path at and below which retention applies.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The number of previous StreamCells to retain, or zero to retain none.
+	MaxCells uint32 `protobuf:"varint,2,opt,name=max_cells,json=maxCells,proto3" json:"max_cells,omitempty"`
+}
+
+func (m *StreamCellRetention) Reset()         { *m = StreamCellRetention{} }
+func (m *StreamCellRetention) String() string { return proto.CompactTextString(m) }
+func (*StreamCellRetention) ProtoMessage()    {}
+func (*StreamCellRetention) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f80259d2fe3898c, []int{4}
+}
+func (m *StreamCellRetention) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamCellRetention) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamCellRetention.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamCellRetention) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamCellRetention.Merge(m, src)
+}
+func (m *StreamCellRetention) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamCellRetention) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamCellRetention.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamCellRetention proto.InternalMessageInfo
+
+func (m *StreamCellRetention) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StreamCellRetention) GetMaxCells() uint32 {
+	if m != nil {
+		return m.MaxCells
+	}
+	return 0
+}
+
+// StorageUsage is the byte count of an accounted path.
+type StorageUsage struct {
+	Path  string `protobuf:"bytes,1,opt,name=path,proto3" json:"path" yaml:"path"`
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes" yaml:"bytes"`
+	// max_bytes is the applicable limit, or zero if there is none.
+	MaxBytes uint64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"maxBytes" yaml:"maxBytes"`
+}
+
+func (m *StorageUsage) Reset()         { *m = StorageUsage{} }
+func (m *StorageUsage) String() string { return proto.CompactTextString(m) }
+func (*StorageUsage) ProtoMessage()    {}
+func (*StorageUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f80259d2fe3898c, []int{5}
+}
+func (m *StorageUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsage.Merge(m, src)
+}
+func (m *StorageUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsage proto.InternalMessageInfo
+
+func (m *StorageUsage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *StorageUsage) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Data)(nil), "agoric.vstorage.Data")
 	proto.RegisterType((*Children)(nil), "agoric.vstorage.Children")
+	proto.RegisterType((*Params)(nil), "agoric.vstorage.Params")
+	proto.RegisterType((*StorageQuota)(nil), "agoric.vstorage.StorageQuota")
+	proto.RegisterType((*StreamCellRetention)(nil), "agoric.vstorage.StreamCellRetention")
+	proto.RegisterType((*StorageUsage)(nil), "agoric.vstorage.StorageUsage")
 }
 
 func init() { proto.RegisterFile("agoric/vstorage/vstorage.proto", fileDescriptor_7f80259d2fe3898c) }
 
 var fileDescriptor_7f80259d2fe3898c = []byte{
-	// 245 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4b, 0x4c, 0xcf, 0x2f,
-	0xca, 0x4c, 0xd6, 0x2f, 0x2b, 0x2e, 0xc9, 0x2f, 0x4a, 0x4c, 0x4f, 0x85, 0x33, 0xf4, 0x0a, 0x8a,
-	0xf2, 0x4b, 0xf2, 0x85, 0xf8, 0x21, 0xf2, 0x7a, 0x30, 0x61, 0x29, 0x91, 0xf4, 0xfc, 0xf4, 0x7c,
-	0xb0, 0x9c, 0x3e, 0x88, 0x05, 0x51, 0xa6, 0x64, 0xcb, 0xc5, 0xe2, 0x92, 0x58, 0x92, 0x28, 0xa4,
-	0xcf, 0xc5, 0x5a, 0x96, 0x98, 0x53, 0x9a, 0x2a, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0xe9, 0x24, 0xf9,
-	0xea, 0x9e, 0x3c, 0x44, 0xe0, 0xd3, 0x3d, 0x79, 0x9e, 0xca, 0xc4, 0xdc, 0x1c, 0x2b, 0x25, 0x30,
-	0x57, 0x29, 0x08, 0x22, 0x6c, 0xc5, 0xf2, 0x62, 0x81, 0x3c, 0x83, 0x92, 0x2f, 0x17, 0x87, 0x73,
-	0x46, 0x66, 0x4e, 0x4a, 0x51, 0x6a, 0x9e, 0x90, 0x35, 0x17, 0x47, 0x32, 0x94, 0x2d, 0xc1, 0xa8,
-	0xc0, 0xac, 0xc1, 0xe9, 0x24, 0xff, 0xea, 0x9e, 0x3c, 0x5c, 0xec, 0xd3, 0x3d, 0x79, 0x7e, 0x88,
-	0x41, 0x30, 0x11, 0xa5, 0x20, 0xb8, 0x24, 0xc4, 0x38, 0xa7, 0xd0, 0x13, 0x8f, 0xe4, 0x18, 0x2f,
-	0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18,
-	0x6e, 0x3c, 0x96, 0x63, 0x88, 0xb2, 0x4e, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf,
-	0xd5, 0x77, 0x84, 0xf8, 0x1c, 0xe2, 0x41, 0xdd, 0xe2, 0x94, 0x6c, 0xfd, 0xf4, 0xfc, 0x9c, 0xc4,
-	0xbc, 0x74, 0xfd, 0xe4, 0xfc, 0xe2, 0xdc, 0xfc, 0x62, 0xfd, 0x0a, 0x44, 0xa0, 0x94, 0x54, 0x16,
-	0xa4, 0x16, 0x27, 0xb1, 0x81, 0xfd, 0x6a, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x81, 0x6f, 0xc9,
-	0xde, 0x34, 0x01, 0x00, 0x00,
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0x31, 0x6f, 0xd3, 0x40,
+	0x14, 0xc7, 0x7d, 0xad, 0x5b, 0x39, 0xd7, 0x96, 0x4a, 0x47, 0x05, 0x06, 0x84, 0x2f, 0x3a, 0x31,
+	0x44, 0x42, 0xd8, 0x12, 0x6c, 0x29, 0x0c, 0xb8, 0x4c, 0x08, 0x24, 0x30, 0xea, 0xc2, 0x62, 0x2e,
+	0xee, 0xc9, 0x89, 0xb0, 0x73, 0xc6, 0x77, 0xa9, 0x92, 0x6f, 0xc1, 0xc8, 0x98, 0x99, 0x4f, 0xc1,
+	0xd8, 0xb1, 0x23, 0x93, 0x85, 0x92, 0xa5, 0xca, 0x98, 0x4f, 0x80, 0xee, 0xce, 0x4e, 0x22, 0x1a,
+	0x26, 0xbf, 0xf7, 0x7f, 0xcf, 0xbf, 0x7b, 0xef, 0xdd, 0x3b, 0xe8, 0xd1, 0x94, 0x97, 0x83, 0x24,
+	0xb8, 0x14, 0x92, 0x97, 0x34, 0x65, 0x2b, 0xc3, 0x2f, 0x4a, 0x2e, 0x39, 0x3a, 0x36, 0x71, 0xbf,
+	0x91, 0x1f, 0x9e, 0xa4, 0x3c, 0xe5, 0x3a, 0x16, 0x28, 0xcb, 0xa4, 0x91, 0x57, 0xd0, 0x7e, 0x43,
+	0x25, 0x45, 0x01, 0xdc, 0xbb, 0xa4, 0xd9, 0x88, 0xb9, 0xa0, 0x0d, 0x3a, 0xad, 0xf0, 0xc1, 0xa2,
+	0xc2, 0x46, 0x58, 0x56, 0xf8, 0x70, 0x42, 0xf3, 0xac, 0x4b, 0xb4, 0x4b, 0x22, 0x23, 0x77, 0xed,
+	0x9b, 0x29, 0xb6, 0xc8, 0x7b, 0xe8, 0x9c, 0xf5, 0x07, 0xd9, 0x45, 0xc9, 0x86, 0xe8, 0x14, 0x3a,
+	0x49, 0x6d, 0xbb, 0xa0, 0xbd, 0xdb, 0x69, 0x85, 0x78, 0x51, 0xe1, 0x95, 0xb6, 0xac, 0xf0, 0xb1,
+	0x01, 0x35, 0x0a, 0x89, 0x56, 0xc1, 0x1a, 0xf7, 0x0b, 0xc0, 0xfd, 0x0f, 0xb4, 0xa4, 0xb9, 0x40,
+	0x6f, 0xe1, 0x9d, 0xba, 0xf2, 0xf8, 0xdb, 0x88, 0x4b, 0x2a, 0x34, 0xf3, 0xe0, 0xf9, 0x63, 0xff,
+	0x9f, 0xc6, 0xfc, 0x4f, 0xe6, 0xfb, 0x51, 0x65, 0x85, 0xf6, 0x55, 0x85, 0xad, 0xe8, 0x48, 0x6c,
+	0x68, 0x02, 0x7d, 0x81, 0xf7, 0x84, 0x2c, 0x19, 0xcd, 0xe3, 0x84, 0x65, 0x59, 0x5c, 0x32, 0xc9,
+	0x86, 0x72, 0xc0, 0x87, 0xc2, 0xdd, 0xd1, 0xcc, 0x27, 0x5b, 0x98, 0x2a, 0xfd, 0x8c, 0x65, 0x59,
+	0xd4, 0x24, 0xd7, 0xe8, 0x13, 0x71, 0x3b, 0x24, 0xba, 0xce, 0x8f, 0x29, 0xb6, 0x6e, 0xa6, 0x18,
+	0x90, 0x1e, 0x3c, 0xdc, 0x2c, 0x08, 0x21, 0x68, 0x17, 0x54, 0xf6, 0xcd, 0x5c, 0x23, 0x6d, 0xa3,
+	0x47, 0xb0, 0x55, 0xb0, 0x32, 0xd6, 0xcd, 0xbb, 0x3b, 0x6d, 0xd0, 0x71, 0x22, 0xa7, 0x60, 0xa5,
+	0x9e, 0xa4, 0x0a, 0xe6, 0x74, 0x1c, 0xf7, 0x26, 0x92, 0x09, 0x77, 0xb7, 0x0d, 0x3a, 0x76, 0xe4,
+	0xe4, 0x74, 0x1c, 0x2a, 0x5f, 0x8f, 0x09, 0x90, 0x77, 0xf0, 0xee, 0x96, 0x02, 0xff, 0x77, 0x94,
+	0xa2, 0xa9, 0xbe, 0x85, 0x3e, 0xea, 0x48, 0xd3, 0xd4, 0x8f, 0x0d, 0xed, 0x27, 0x58, 0x95, 0x7c,
+	0x2e, 0x68, 0xca, 0xd0, 0xd3, 0x4d, 0x4e, 0x78, 0x7f, 0x51, 0x61, 0xed, 0x2f, 0x2b, 0x7c, 0x60,
+	0x2e, 0x50, 0x79, 0xa4, 0x3e, 0x20, 0x80, 0x7b, 0xa6, 0x54, 0x05, 0xb7, 0xcd, 0xe2, 0x68, 0x61,
+	0xbd, 0x38, 0xda, 0x25, 0x91, 0x91, 0xd1, 0xcb, 0x5b, 0xfd, 0x99, 0x3d, 0x69, 0x7a, 0x5c, 0xef,
+	0x49, 0xa3, 0x90, 0xf5, 0x00, 0xc2, 0xf3, 0xab, 0x99, 0x07, 0xae, 0x67, 0x1e, 0xf8, 0x33, 0xf3,
+	0xc0, 0xf7, 0xb9, 0x67, 0x5d, 0xcf, 0x3d, 0xeb, 0xf7, 0xdc, 0xb3, 0x3e, 0x9f, 0xa6, 0x03, 0xd9,
+	0x1f, 0xf5, 0xfc, 0x84, 0xe7, 0xc1, 0x6b, 0xf3, 0x36, 0xcc, 0xad, 0x3e, 0x13, 0x17, 0x5f, 0x83,
+	0x94, 0x67, 0x74, 0x98, 0x06, 0x09, 0x17, 0x39, 0x17, 0xc1, 0x78, 0xfd, 0x6c, 0xe4, 0xa4, 0x60,
+	0xa2, 0xb7, 0xaf, 0x5f, 0xc3, 0x8b, 0xbf, 0x03, 0x00, 0x07, 0xc8, 0x6c, 0x0e, 0x56, 0x03, 0x00,
+	0x00,
+}
+
+func (this *Params) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Params)
+	if !ok {
+		that2, ok := that.(Params)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.StorageQuotas) != len(that1.StorageQuotas) {
+		return false
+	}
+	for i := range this.StorageQuotas {
+		if !this.StorageQuotas[i].Equal(&that1.StorageQuotas[i]) {
+			return false
+		}
+	}
+	if len(this.StreamCellRetentions) != len(that1.StreamCellRetentions) {
+		return false
+	}
+	for i := range this.StreamCellRetentions {
+		if !this.StreamCellRetentions[i].Equal(&that1.StreamCellRetentions[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *StorageQuota) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StorageQuota)
+	if !ok {
+		that2, ok := that.(StorageQuota)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.PerChild != that1.PerChild {
+		return false
+	}
+	if this.MaxBytes != that1.MaxBytes {
+		return false
+	}
+	return true
 }
+func (this *StreamCellRetention) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
+	that1, ok := that.(*StreamCellRetention)
+	if !ok {
+		that2, ok := that.(StreamCellRetention)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.MaxCells != that1.MaxCells {
+		return false
+	}
+	return true
+}
 func (m *Data) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -200,55 +561,704 @@ func (m *Children) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0xa
 		}
 	}
-	return len(dAtA) - i, nil
-}
+	return len(dAtA) - i, nil
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StreamCellRetentions) > 0 {
+		for iNdEx := len(m.StreamCellRetentions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StreamCellRetentions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintVstorage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.StorageQuotas) > 0 {
+		for iNdEx := len(m.StorageQuotas) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StorageQuotas[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintVstorage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageQuota) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageQuota) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageQuota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		i = encodeVarintVstorage(dAtA, i, uint64(m.MaxBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PerChild {
+		i--
+		if m.PerChild {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintVstorage(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamCellRetention) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamCellRetention) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamCellRetention) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxCells != 0 {
+		i = encodeVarintVstorage(dAtA, i, uint64(m.MaxCells))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintVstorage(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		i = encodeVarintVstorage(dAtA, i, uint64(m.MaxBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintVstorage(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintVstorage(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintVstorage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovVstorage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Data) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovVstorage(uint64(l))
+	}
+	return n
+}
+
+func (m *Children) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Children) > 0 {
+		for _, s := range m.Children {
+			l = len(s)
+			n += 1 + l + sovVstorage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.StorageQuotas) > 0 {
+		for _, e := range m.StorageQuotas {
+			l = e.Size()
+			n += 1 + l + sovVstorage(uint64(l))
+		}
+	}
+	if len(m.StreamCellRetentions) > 0 {
+		for _, e := range m.StreamCellRetentions {
+			l = e.Size()
+			n += 1 + l + sovVstorage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StorageQuota) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovVstorage(uint64(l))
+	}
+	if m.PerChild {
+		n += 2
+	}
+	if m.MaxBytes != 0 {
+		n += 1 + sovVstorage(uint64(m.MaxBytes))
+	}
+	return n
+}
+
+func (m *StreamCellRetention) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovVstorage(uint64(l))
+	}
+	if m.MaxCells != 0 {
+		n += 1 + sovVstorage(uint64(m.MaxCells))
+	}
+	return n
+}
+
+func (m *StorageUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovVstorage(uint64(l))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovVstorage(uint64(m.Bytes))
+	}
+	if m.MaxBytes != 0 {
+		n += 1 + sovVstorage(uint64(m.MaxBytes))
+	}
+	return n
+}
+
+func sovVstorage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozVstorage(x uint64) (n int) {
+	return sovVstorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Data) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVstorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Data: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Data: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVstorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Children) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVstorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Children: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Children: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Children = append(m.Children, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVstorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVstorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageQuotas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageQuotas = append(m.StorageQuotas, StorageQuota{})
+			if err := m.StorageQuotas[len(m.StorageQuotas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamCellRetentions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StreamCellRetentions = append(m.StreamCellRetentions, StreamCellRetention{})
+			if err := m.StreamCellRetentions[len(m.StreamCellRetentions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVstorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func encodeVarintVstorage(dAtA []byte, offset int, v uint64) int {
-	offset -= sovVstorage(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *Data) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovVstorage(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *Children) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Children) > 0 {
-		for _, s := range m.Children {
-			l = len(s)
-			n += 1 + l + sovVstorage(uint64(l))
+func (m *StorageQuota) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVstorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageQuota: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageQuota: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PerChild", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PerChild = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVstorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVstorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func sovVstorage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozVstorage(x uint64) (n int) {
-	return sovVstorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Data) Unmarshal(dAtA []byte) error {
+func (m *StreamCellRetention) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -271,15 +1281,15 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Data: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamCellRetention: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Data: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamCellRetention: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -307,8 +1317,27 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCells", wireType)
+			}
+			m.MaxCells = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCells |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipVstorage(dAtA[iNdEx:])
@@ -330,7 +1359,7 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Children) Unmarshal(dAtA []byte) error {
+func (m *StorageUsage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -353,15 +1382,15 @@ func (m *Children) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Children: wiretype end group for non-group")
+			return fmt.Errorf("proto: StorageUsage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Children: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StorageUsage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -389,8 +1418,46 @@ func (m *Children) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Children = append(m.Children, string(dAtA[iNdEx:postIndex]))
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVstorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipVstorage(dAtA[iNdEx:])
@@ -9,6 +9,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	agoric "github.com/Agoric/agoric-sdk/golang/cosmos/types"
+	"github.com/Agoric/agoric-sdk/golang/cosmos/x/vstorage/types"
 )
 
 type vstorageHandler struct {
@@ -69,13 +70,17 @@ func (sh vstorageHandler) Receive(cctx context.Context, str string) (ret string,
 	// Handle generic paths.
 	switch msg.Method {
 	case "set":
-		for _, arg := range msg.Args {
-			var entry agoric.KVEntry
-			err = json.Unmarshal(arg, &entry)
+		// Apply every entry or none of them.
+		entries := make([]agoric.KVEntry, len(msg.Args))
+		for i, arg := range msg.Args {
+			err = json.Unmarshal(arg, &entries[i])
 			if err != nil {
 				return
 			}
-			keeper.SetStorageAndNotify(ctx, entry)
+		}
+		err = keeper.SetStoragesAndNotify(ctx, entries)
+		if err != nil {
+			return
 		}
 		return "true", nil
 
@@ -106,21 +111,40 @@ func (sh vstorageHandler) Receive(cctx context.Context, str string) (ret string,
 		return "true", nil
 
 	case "append":
-		for _, arg := range msg.Args {
-			var entry agoric.KVEntry
-			err = json.Unmarshal(arg, &entry)
+		// Apply every entry or none of them.
+		entries := make([]agoric.KVEntry, len(msg.Args))
+		for i, arg := range msg.Args {
+			err = json.Unmarshal(arg, &entries[i])
 			if err != nil {
 				return
 			}
-			if !entry.HasValue() {
-				err = fmt.Errorf("no value for append entry with path: %q", entry.Key())
-				return
-			}
-			err = keeper.AppendStorageValueAndNotify(ctx, entry.Key(), entry.StringValue())
-			if err != nil {
+			if !entries[i].HasValue() {
+				err = fmt.Errorf("no value for append entry with path: %q", entries[i].Key())
 				return
 			}
 		}
+		err = keeper.AppendStorageValuesAndNotify(ctx, entries)
+		if err != nil {
+			return
+		}
+		return "true", nil
+
+	case "removeSubtree":
+		// Remove path and everything below it, reporting a single subtree
+		// deletion.
+		var path string
+		err = unmarshalSinglePathFromArgs(msg.Args, &path)
+		if err != nil {
+			return
+		}
+		if err = types.ValidatePath(path); err != nil {
+			return
+		}
+		if len(path) == 0 {
+			err = fmt.Errorf("cannot remove the root subtree")
+			return
+		}
+		keeper.RemoveEntriesWithPrefixAndNotify(ctx, path)
 		return "true", nil
 
 	case "get":
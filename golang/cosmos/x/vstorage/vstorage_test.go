@@ -251,6 +251,60 @@ func TestSetWithoutNotify(t *testing.T) {
 	doTestSet(t, "setWithoutNotify", false)
 }
 
+func TestRemoveSubtree(t *testing.T) {
+	kit := makeTestKit()
+	keeper, handler, ctx, cctx := kit.keeper, kit.handler, kit.ctx, kit.cctx
+
+	keeper.SetStorage(ctx, agorictypes.NewKVEntry("tree", "t"))
+	keeper.SetStorage(ctx, agorictypes.NewKVEntry("tree.a.b", "ab"))
+	keeper.SetStorage(ctx, agorictypes.NewKVEntry("treetop", "kept"))
+
+	type testCase struct {
+		label       string
+		args        []interface{}
+		errContains *string
+	}
+	cases := []testCase{
+		{label: "subtree", args: []interface{}{"tree"}},
+		{label: "absent subtree", args: []interface{}{"absent"}},
+		{label: "root", args: []interface{}{""}, errContains: ptr("root")},
+		{label: "invalid path", args: []interface{}{"a..b"}, errContains: ptr("path")},
+		{label: "missing path", args: []interface{}{}, errContains: ptr("missing")},
+	}
+	for _, desc := range cases {
+		got, err := callReceive(handler, cctx, "removeSubtree", desc.args)
+		if desc.errContains == nil {
+			if err != nil {
+				t.Errorf("%s: got unexpected error %v", desc.label, err)
+			} else if got != "true" {
+				t.Errorf("%s: got unexpected response %q; want %q", desc.label, got, "true")
+			}
+		} else if err == nil {
+			t.Errorf("%s: got no error, want error %q", desc.label, *desc.errContains)
+		} else if !strings.Contains(err.Error(), *desc.errContains) {
+			t.Errorf("%s: got error %v, want error %q", desc.label, err, *desc.errContains)
+		}
+	}
+
+	for _, path := range []string{"tree", "tree.a", "tree.a.b"} {
+		if keeper.HasEntry(ctx, path) {
+			t.Errorf("got entry remaining at %q in removed subtree", path)
+		}
+	}
+	if got := keeper.GetEntry(ctx, "treetop").StringValue(); got != "kept" {
+		t.Errorf("got %q at sibling path, want %q", got, "kept")
+	}
+
+	// Only the present subtree is reported, as a single deletion.
+	keeper.FlushChangeEvents(ctx)
+	expectedEvents := sdk.Events{
+		agorictypes.NewStateDeleteSubtreeEvent(keeper.GetStoreName(), keeper.PathToEncodedKey("tree")),
+	}
+	if got := ctx.EventManager().Events(); !reflect.DeepEqual(got, expectedEvents) {
+		t.Errorf("got events %#v; want %#v", got, expectedEvents)
+	}
+}
+
 // TODO: TestAppend
 
 // TODO: TestChildrenAndSize
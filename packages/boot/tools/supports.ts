@@ -411,6 +411,7 @@ export const makeSwingsetTestKit = async (
         }
       case BridgeId.PROVISION:
       case BridgeId.PROVISION_SMART_WALLET:
+      case BridgeId.SWINGSET:
       case BridgeId.VTRANSFER:
       case BridgeId.WALLET:
         console.warn('Bridge returning undefined for', bridgeId, ':', obj);
@@ -557,7 +557,7 @@ export async function launch({
   let decohered;
   let afterCommitWorkDone = Promise.resolve();
 
-  async function performAction(action, inboundNum) {
+  async function performAction(action, inboundNum, context) {
     // blockManagerConsole.error('Performing action', action);
     let p;
     switch (action.type) {
@@ -587,7 +587,13 @@ export async function launch({
       }
 
       case ActionType.PLEASE_PROVISION: {
-        p = doBridgeInbound(BRIDGE_ID.PROVISION, action, inboundNum);
+        // The provisioner needs the action context to refund a failed
+        // provision.
+        p = doBridgeInbound(
+          BRIDGE_ID.PROVISION,
+          { ...action, context },
+          inboundNum,
+        );
         break;
       }
 
@@ -631,7 +637,7 @@ export async function launch({
     for await (const { action, context } of inboundQueue.consumeAll()) {
       const inboundNum = `${context.blockHeight}-${context.txHash}-${context.msgIdx}`;
       inboundQueueMetrics.decStat();
-      await performAction(action, inboundNum);
+      await performAction(action, inboundNum, context);
       keepGoing = await runSwingset();
       if (!keepGoing) {
         // any leftover actions will remain on the inbound queue for possible
@@ -124,7 +124,8 @@ harden(assertPathSegment);
  *   | 'children'
  *   | 'entries'
  *   | 'values'
- *   | 'size'} StorageGetByPathMessageMethod
+ *   | 'size'
+ *   | 'removeSubtree'} StorageGetByPathMessageMethod
  *
  *
  * @typedef {'set' | 'setWithoutNotify' | 'append'} StorageUpdateEntriesMessageMethod
@@ -188,6 +188,16 @@ export const makeFakeStorageKit = (rootPath, rootOptions) => {
         }
         break;
       }
+      case 'removeSubtree': {
+        trace('toStorage removeSubtree', message);
+        const [key] = message.args;
+        for (const path of [...data.keys()]) {
+          if (path === key || path.startsWith(`${key}.`)) {
+            data.delete(path);
+          }
+        }
+        return true;
+      }
       case 'size':
         // Intentionally incorrect because it counts non-child descendants,
         // but nevertheless supports a "has children" test.
@@ -147,6 +147,7 @@ export async function initEmulatedChain(config, configPath) {
       case BridgeId.DIBC:
       case BridgeId.PROVISION:
       case BridgeId.PROVISION_SMART_WALLET:
+      case BridgeId.SWINGSET:
       case BridgeId.WALLET:
         console.warn('Bridge returning undefined for', bridgeId, ':', obj);
         return undefined;
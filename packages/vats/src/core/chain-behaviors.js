@@ -134,20 +134,86 @@ harden(noProvisioner);
 export const bridgeProvisioner = async ({
   consume: {
     provisioning: provisioningP,
+    bridgeManager: bridgeManagerP,
     provisionBridgeManager: provisionBridgeManagerP,
     provisionWalletBridgeManager: provisionWalletBridgeManagerP,
   },
 }) => {
-  const [provisioning, provisionBridgeManager, provisionWalletBridgeManager] =
-    await Promise.all([
-      provisioningP,
-      provisionBridgeManagerP,
-      provisionWalletBridgeManagerP,
-    ]);
+  const [
+    provisioning,
+    bridgeManager,
+    provisionBridgeManager,
+    provisionWalletBridgeManager,
+  ] = await Promise.all([
+    provisioningP,
+    bridgeManagerP,
+    provisionBridgeManagerP,
+    provisionWalletBridgeManagerP,
+  ]);
   if (!provisionBridgeManager || !provisionWalletBridgeManager) {
     return;
   }
 
+  // Acknowledge the outcome of each smart wallet provision to the swingset
+  // module, which otherwise retries it after a timeout.
+  // See `handleSmartWalletProvisionAcknowledge` in
+  // golang/cosmos/x/swingset/swingset.go
+  const swingsetBridgeManager =
+    bridgeManager &&
+    (await makeScopedBridge(bridgeManager, BRIDGE_ID.SWINGSET));
+  /**
+   * @param {string} address
+   * @param {boolean} succeeded
+   * @param {string} [error]
+   */
+  const acknowledgeSmartWalletProvision = (address, succeeded, error = '') =>
+    E(swingsetBridgeManager)
+      .toBridge({
+        method: 'smartWalletProvisionAcknowledge',
+        args: [{ address, succeeded, error }],
+      })
+      .catch(e =>
+        console.error(`Error acknowledging provision of ${address}:`, e),
+      );
+  /**
+   * Refund the fee that the submitter of a failed provision was charged by
+   * the transaction of its action context.
+   * See `handleRefund` in golang/cosmos/x/swingset/swingset.go
+   *
+   * @param {any} obj
+   */
+  const refundProvisionFee = obj => {
+    const { context, submitter, fee } = obj;
+    if (!context || !fee || !fee.length) {
+      return undefined;
+    }
+    return E(swingsetBridgeManager)
+      .toBridge({
+        method: 'refund',
+        args: [{ context, address: submitter, amount: fee }],
+      })
+      .catch(e =>
+        console.error(`Error refunding provision fee to ${submitter}:`, e),
+      );
+  };
+  /** @param {any} obj */
+  const provisionSmartWallet = async obj => {
+    const provisionP = E(provisionWalletBridgeManager).fromBridge(obj);
+    if (!swingsetBridgeManager) {
+      return provisionP;
+    }
+    return provisionP.then(
+      async () => {
+        await acknowledgeSmartWalletProvision(obj.address, true);
+      },
+      async e => {
+        await acknowledgeSmartWalletProvision(obj.address, false, `${e}`);
+        await refundProvisionFee(obj);
+        throw e;
+      },
+    );
+  };
+
   // Register a provisioning handler over the bridge.
   const handler = provisioning
     ? Far('provisioningHandler', {
@@ -159,7 +225,7 @@ export const bridgeProvisioner = async ({
               let provisionP;
               if (powerFlags.includes(PowerFlags.SMART_WALLET)) {
                 // Only provision a smart wallet.
-                provisionP = E(provisionWalletBridgeManager).fromBridge(obj);
+                provisionP = provisionSmartWallet(obj);
               } else {
                 // Provision a mailbox and REPL.
                 provisionP = E(provisioning).pleaseProvision(
@@ -183,7 +249,9 @@ export const bridgeProvisioner = async ({
           }
         },
       })
-    : provisionWalletBridgeManager;
+    : Far('smartWalletProvisioningHandler', {
+        fromBridge: provisionSmartWallet,
+      });
   await E(provisionBridgeManager).initHandler(handler);
 };
 harden(bridgeProvisioner);